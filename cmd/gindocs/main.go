@@ -0,0 +1,139 @@
+// Command gindocs works with the OpenAPI documents served by gin-docs, e.g.
+// to generate a Go client from a running app's /docs/openapi.json or to
+// compare it with a published spec.
+//
+// Usage:
+//
+//	gindocs go-client [-package name] [-o file] <spec file or URL>
+//	gindocs diff [-json | -bump] [-breaking level] [-additive level]
+//	             [-cosmetic level] [-fail-on level] <baseline spec> <current spec>
+//
+// Levels are none, patch, minor or major.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/MUKE-coder/gin-docs/gindocs"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "go-client":
+		err = goClient(os.Args[2:])
+	case "diff":
+		err = diff(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gindocs:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage:
+  gindocs go-client [-package name] [-o file] <spec file or URL>
+  gindocs diff [-json | -bump] [-breaking level] [-additive level]
+               [-cosmetic level] [-fail-on level] <baseline spec> <current spec>`)
+	os.Exit(2)
+}
+
+// goClient writes a typed Go client for a spec.
+func goClient(args []string) error {
+	fs := flag.NewFlagSet("go-client", flag.ExitOnError)
+	pkg := fs.String("package", "client", "package name of the generated client")
+	out := fs.String("o", "", "output file (default: stdout)")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		usage()
+	}
+
+	spec, err := gindocs.LoadSpec(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	code := gindocs.GenerateGoClient(spec, *pkg)
+	if *out == "" {
+		_, err = os.Stdout.WriteString(code)
+		return err
+	}
+	return os.WriteFile(*out, []byte(code), 0o644)
+}
+
+// diff prints the changes between two specs and the version bump they call
+// for under the policy given by the flags. With -fail-on it returns an error,
+// and so a non-zero exit status, when the bump reaches that level.
+func diff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the diff as JSON")
+	bumpOnly := fs.Bool("bump", false, "print only the suggested bump")
+	var policy gindocs.VersionPolicy
+	bumpVar(fs, &policy.Breaking, "breaking", "bump for breaking changes (default major)")
+	bumpVar(fs, &policy.Additive, "additive", "bump for additions (default minor)")
+	bumpVar(fs, &policy.Cosmetic, "cosmetic", "bump for documentation changes (default patch)")
+	var failOn gindocs.BumpLevel
+	bumpVar(fs, &failOn, "fail-on", "exit with an error when the bump is at least this level")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		usage()
+	}
+
+	baseline, err := gindocs.LoadSpec(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	current, err := gindocs.LoadSpec(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	result := gindocs.CompareSpecs(baseline, current, policy)
+	result.Baseline = fs.Arg(0)
+
+	switch {
+	case *asJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	case *bumpOnly:
+		fmt.Println(result.Bump)
+	default:
+		for _, change := range result.Changes {
+			fmt.Printf("%-9s %s: %s\n", change.Category, change.Location, change.Message)
+		}
+		fmt.Printf("suggested bump: %s", result.Bump)
+		if result.SuggestedVersion != "" && result.Bump != gindocs.BumpNone {
+			fmt.Printf(" (%s -> %s)", result.CurrentVersion, result.SuggestedVersion)
+		}
+		fmt.Println()
+	}
+
+	if failOn != gindocs.BumpDefault && result.Bump >= failOn {
+		return fmt.Errorf("suggested bump %s reaches -fail-on %s", result.Bump, failOn)
+	}
+	return nil
+}
+
+// bumpVar defines a flag holding a bump level by name.
+func bumpVar(fs *flag.FlagSet, level *gindocs.BumpLevel, name, usage string) {
+	fs.Func(name, usage, func(value string) error {
+		for _, l := range []gindocs.BumpLevel{gindocs.BumpNone, gindocs.BumpPatch, gindocs.BumpMinor, gindocs.BumpMajor} {
+			if l.String() == value {
+				*level = l
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown level %q (want none, patch, minor or major)", value)
+	})
+}
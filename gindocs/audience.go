@@ -0,0 +1,38 @@
+package gindocs
+
+import "github.com/gin-gonic/gin"
+
+// requestAudience returns the audience the spec is served to: the result of
+// Config.Audience when set, otherwise the ?audience= query parameter.
+// An empty audience sees every operation.
+func (gd *GinDocs) requestAudience(c *gin.Context) string {
+	if gd.config.Audience != nil {
+		return gd.config.Audience(c)
+	}
+	return c.Query("audience")
+}
+
+// operationAudiences returns the audiences an operation is limited to.
+// Operations with the x-internal extension belong to "internal".
+func operationAudiences(op *OperationObject) []string {
+	audiences := op.Audiences
+	if internal, _ := op.Extensions["x-internal"].(bool); internal {
+		audiences = append(append([]string{}, audiences...), "internal")
+	}
+	return audiences
+}
+
+// inAudience reports whether an operation is shown to audience. Operations
+// without an audience are shown to everyone.
+func inAudience(op *OperationObject, audience string) bool {
+	audiences := operationAudiences(op)
+	if len(audiences) == 0 {
+		return true
+	}
+	for _, a := range audiences {
+		if a == audience {
+			return true
+		}
+	}
+	return false
+}
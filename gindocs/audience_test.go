@@ -0,0 +1,49 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAudienceFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/users", func(c *gin.Context) {})
+	r.GET("/api/admin/stats", func(c *gin.Context) {})
+	r.POST("/api/admin/jobs", func(c *gin.Context) {})
+	r.GET("/api/partners", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Group("/api/admin/*").Audience("internal")
+	gd.Route("POST /api/admin/jobs").Extension("x-internal", true)
+	gd.Route("GET /api/partners").Audience("public", "partner")
+
+	paths := func(query string) map[string]bool {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json"+query, nil))
+		var spec OpenAPISpec
+		if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+			t.Fatal(err)
+		}
+		found := make(map[string]bool)
+		for path := range spec.Paths {
+			found[path] = true
+		}
+		return found
+	}
+
+	public := paths("?audience=public")
+	if !public["/api/users"] || !public["/api/partners"] || public["/api/admin/stats"] || public["/api/admin/jobs"] {
+		t.Errorf("public spec paths = %v", public)
+	}
+	if all := paths(""); len(all) != 4 {
+		t.Errorf("unfiltered spec paths = %v", all)
+	}
+	if internal := paths("?audience=internal"); internal["/api/partners"] || !internal["/api/admin/jobs"] {
+		t.Errorf("internal spec paths = %v", internal)
+	}
+}
@@ -0,0 +1,64 @@
+package gindocs
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// authSchemeName returns the security scheme name generated for Config.Auth,
+// or "" when auth is disabled.
+func (gd *GinDocs) authSchemeName() string {
+	switch gd.config.Auth.Type {
+	case AuthBearer:
+		return "bearerAuth"
+	case AuthAPIKey:
+		return "apiKeyAuth"
+	case AuthBasic:
+		return "basicAuth"
+	}
+	return ""
+}
+
+// inferSecurity attaches security requirements for known auth middleware in
+// the route's handler chain. Operations with explicit security are left alone.
+func (gd *GinDocs) inferSecurity(route RouteMetadata, op *OperationObject) {
+	if len(op.Security) > 0 || len(route.Handlers) < 2 {
+		return
+	}
+
+	patterns := gd.config.SecurityMiddleware
+	if patterns == nil {
+		scheme := gd.authSchemeName()
+		if scheme == "" {
+			return
+		}
+		patterns = map[string]string{"*auth*": scheme, "*jwt*": scheme}
+	}
+
+	keys := make([]string, 0, len(patterns))
+	for pattern := range patterns {
+		keys = append(keys, pattern)
+	}
+	sort.Strings(keys)
+
+	added := make(map[string]bool)
+	// The last handler is the endpoint itself, not middleware.
+	for _, handler := range route.Handlers[:len(route.Handlers)-1] {
+		// Patterns with a dot match "pkg.Func"; others match the function
+		// name alone, so "*auth*" doesn't match every handler of an auth package.
+		qualified := strings.ToLower(handler[strings.LastIndex(handler, "/")+1:])
+		_, name, _ := strings.Cut(qualified, ".")
+		for _, pattern := range keys {
+			scheme := patterns[pattern]
+			subject := name
+			if strings.Contains(pattern, ".") {
+				subject = qualified
+			}
+			if matched, _ := path.Match(strings.ToLower(pattern), subject); matched && !added[scheme] {
+				added[scheme] = true
+				op.Security = append(op.Security, SecurityRequirement{scheme: []string{}})
+			}
+		}
+	}
+}
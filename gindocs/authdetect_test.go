@@ -0,0 +1,71 @@
+package gindocs
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func requireAuth(c *gin.Context) { c.Next() }
+
+func authLoginHandler(c *gin.Context) {}
+
+func TestInferSecurity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/me", requireAuth, func(c *gin.Context) {})
+	r.POST("/login", authLoginHandler)
+
+	gd := newGinDocs(r, nil, mergeConfig(Config{Auth: AuthConfig{Type: AuthBearer}}))
+	ops := make(map[string]*OperationObject)
+	for _, route := range gd.introspect() {
+		ops[route.Method+" "+route.Path] = gd.buildOperation(route)
+		if len(route.Handlers) == 0 || route.Handlers[len(route.Handlers)-1] != route.HandlerName {
+			t.Errorf("expected handler chain to end with %s, got %v", route.HandlerName, route.Handlers)
+		}
+	}
+
+	if sec := ops["GET /me"].Security; len(sec) != 1 || sec[0]["bearerAuth"] == nil {
+		t.Errorf("expected bearerAuth from requireAuth middleware, got %v", sec)
+	}
+	if mw := ops["GET /me"].Middlewares; mw != nil {
+		t.Errorf("expected no x-middlewares by default, got %v", mw)
+	}
+	if sec := ops["POST /login"].Security; len(sec) != 0 {
+		t.Errorf("the endpoint handler itself should not be treated as middleware, got %v", sec)
+	}
+
+	gd = newGinDocs(r, nil, mergeConfig(Config{ExposeMiddlewares: true}))
+	for _, route := range gd.introspect() {
+		if route.Path != "/me" {
+			continue
+		}
+		if mw := gd.buildOperation(route).Middlewares; len(mw) != 1 || handlerFuncName(mw[0]) != "requireAuth" {
+			t.Errorf("expected x-middlewares to list requireAuth, got %v", mw)
+		}
+	}
+}
+
+func TestInferSecurityMatchesFunctionNames(t *testing.T) {
+	tests := []struct {
+		patterns map[string]string
+		handlers []string
+		want     bool
+	}{
+		// Default patterns ignore the package, even when it is named for auth.
+		{nil, []string{"github.com/acme/app/authz.RequestID", "main.getUser"}, false},
+		{nil, []string{"github.com/acme/app/mw.JWTAuth.func1", "main.getUser"}, true},
+		{nil, []string{"github.com/acme/app/mw.(*Authenticator).Handle-fm", "main.getUser"}, true},
+		// Patterns with a dot still match the package.
+		{map[string]string{"authz.require*": "bearerAuth"}, []string{"github.com/acme/app/authz.RequireUser.func1", "main.getUser"}, true},
+		{map[string]string{"authz.require*": "bearerAuth"}, []string{"github.com/acme/app/mw.RequireUser", "main.getUser"}, false},
+	}
+	for _, tt := range tests {
+		gd := newGinDocs(gin.New(), nil, mergeConfig(Config{Auth: AuthConfig{Type: AuthBearer}, SecurityMiddleware: tt.patterns}))
+		op := &OperationObject{}
+		gd.inferSecurity(RouteMetadata{Handlers: tt.handlers}, op)
+		if got := len(op.Security) == 1; got != tt.want {
+			t.Errorf("%v with %v: security = %v", tt.handlers, tt.patterns, op.Security)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// CodeSample is a request example in one language. Samples are emitted as
+// x-codeSamples, which Scalar and Redoc show in a code samples panel.
+type CodeSample struct {
+	Lang   string `json:"lang"`
+	Label  string `json:"label,omitempty"`
+	Source string `json:"source"`
+}
+
+// CodeSampleRequest is the example request of an operation that code samples
+// are rendered from.
+type CodeSampleRequest struct {
+	// Method is the HTTP method.
+	Method string
+
+	// URL is the full request URL with path parameters and required query
+	// parameters filled in.
+	URL string
+
+	// Headers are the request headers, including credential placeholders
+	// such as "Bearer <token>".
+	Headers []CodeSampleHeader
+
+	// Body is the indented JSON request body, or empty.
+	Body string
+
+	// Operation is the documented operation.
+	Operation *OperationObject
+}
+
+// CodeSampleHeader is a request header of a CodeSampleRequest.
+type CodeSampleHeader struct {
+	Name  string
+	Value string
+}
+
+// credential describes where an operation's security scheme expects its
+// secret. Var names the placeholder ("token", "apiKey", "basicAuth").
+type credential struct {
+	In     string
+	Name   string
+	Prefix string
+	Var    string
+}
+
+// securityCredentials returns the credentials of the first accepted security
+// requirement.
+func securityCredentials(security []SecurityRequirement, schemes map[string]*SecuritySchemeObject) []credential {
+	if len(security) == 0 {
+		return nil
+	}
+	var creds []credential
+	for _, name := range sortedKeys(security[0]) {
+		scheme := schemes[name]
+		if scheme == nil {
+			continue
+		}
+		switch {
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"):
+			creds = append(creds, credential{In: "header", Name: "Authorization", Prefix: "Bearer ", Var: "token"})
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			creds = append(creds, credential{In: "header", Name: "Authorization", Prefix: "Basic ", Var: "basicAuth"})
+		case scheme.Type == "apiKey":
+			creds = append(creds, credential{In: scheme.In, Name: scheme.Name, Var: "apiKey"})
+		}
+	}
+	return creds
+}
+
+// defaultSampleBaseURL is the base URL of code samples when no Servers are
+// configured. The served spec swaps in the request's server.
+const defaultSampleBaseURL = "http://localhost:8080"
+
+// withSampleBaseURL returns a copy of spec whose code samples call baseURL
+// instead of defaultSampleBaseURL. Operations are copied, not modified.
+func withSampleBaseURL(spec *OpenAPISpec, baseURL string) *OpenAPISpec {
+	rebased := *spec
+	rebased.Paths = make(map[string]*PathItem, len(spec.Paths))
+	for path, pathItem := range spec.Paths {
+		item := *pathItem
+		for _, method := range httpMethods {
+			op := pathItem.GetOperation(method)
+			if op == nil || len(op.CodeSamples) == 0 {
+				continue
+			}
+			withBase := *op
+			withBase.CodeSamples = make([]CodeSample, len(op.CodeSamples))
+			for i, sample := range op.CodeSamples {
+				sample.Source = strings.ReplaceAll(sample.Source, defaultSampleBaseURL, baseURL)
+				withBase.CodeSamples[i] = sample
+			}
+			item.SetOperation(method, &withBase)
+		}
+		rebased.Paths[path] = &item
+	}
+	return &rebased
+}
+
+// addCodeSamples attaches curl, JavaScript and Go samples, plus those of
+// hook, to every operation.
+func addCodeSamples(spec *OpenAPISpec, hook func(CodeSampleRequest) []CodeSample) {
+	var schemas map[string]*SchemaObject
+	var schemes map[string]*SecuritySchemeObject
+	if spec.Components != nil {
+		schemas = spec.Components.Schemas
+		schemes = spec.Components.SecuritySchemes
+	}
+	baseURL := defaultSampleBaseURL
+	if len(spec.Servers) > 0 {
+		baseURL = spec.Servers[0].ResolvedURL()
+	}
+	placeholders := map[string]string{"token": "<token>", "basicAuth": "<credentials>", "apiKey": "<api-key>"}
+
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+			security := op.Security
+			if security == nil {
+				security = spec.Security
+			}
+
+			req := CodeSampleRequest{Method: method, Operation: op}
+			target := path
+			query := url.Values{}
+			for _, param := range op.Parameters {
+				value := fmt.Sprint(sampleValue(param.Schema, schemas, param.Name, map[string]bool{}))
+				switch {
+				case param.In == "path":
+					target = strings.ReplaceAll(target, "{"+param.Name+"}", url.PathEscape(value))
+				case param.In == "query" && param.Required:
+					query.Set(param.Name, value)
+				case param.In == "header" && param.Required:
+					req.Headers = append(req.Headers, CodeSampleHeader{Name: param.Name, Value: value})
+				}
+			}
+			for _, cred := range securityCredentials(security, schemes) {
+				value := cred.Prefix + placeholders[cred.Var]
+				switch cred.In {
+				case "header":
+					req.Headers = append(req.Headers, CodeSampleHeader{Name: cred.Name, Value: value})
+				case "query":
+					query.Set(cred.Name, value)
+				case "cookie":
+					req.Headers = append(req.Headers, CodeSampleHeader{Name: "Cookie", Value: cred.Name + "=" + value})
+				}
+			}
+			if op.RequestBody != nil {
+				if media, ok := op.RequestBody.Content["application/json"]; ok {
+					data, err := json.MarshalIndent(sampleValue(media.Schema, schemas, "", map[string]bool{}), "", "  ")
+					if err == nil {
+						req.Body = string(data)
+						req.Headers = append(req.Headers, CodeSampleHeader{Name: "Content-Type", Value: "application/json"})
+					}
+				}
+			}
+			req.URL = baseURL + target
+			if len(query) > 0 {
+				// Keep credential placeholders readable.
+				req.URL += "?" + strings.NewReplacer("%3C", "<", "%3E", ">").Replace(query.Encode())
+			}
+
+			op.CodeSamples = []CodeSample{
+				{Lang: "Shell", Label: "curl", Source: curlSample(req)},
+				{Lang: "JavaScript", Label: "fetch", Source: fetchSample(req)},
+				{Lang: "Go", Label: "net/http", Source: goSample(req)},
+			}
+			if hook != nil {
+				op.CodeSamples = append(op.CodeSamples, hook(req)...)
+			}
+		}
+	}
+}
+
+// curlSample renders a request as a curl command.
+func curlSample(req CodeSampleRequest) string {
+	quote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+
+	var b strings.Builder
+	b.WriteString("curl")
+	if req.Method != "GET" {
+		b.WriteString(" -X " + req.Method)
+	}
+	b.WriteString(" " + quote(req.URL))
+	for _, h := range req.Headers {
+		b.WriteString(" \\\n  -H " + quote(h.Name+": "+h.Value))
+	}
+	if req.Body != "" {
+		b.WriteString(" \\\n  -d " + quote(req.Body))
+	}
+	return b.String()
+}
+
+// fetchSample renders a request as a JavaScript fetch call.
+func fetchSample(req CodeSampleRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "const response = await fetch(%s, {\n", strconv.Quote(req.URL))
+	fmt.Fprintf(&b, "  method: %q,\n", req.Method)
+	if len(req.Headers) > 0 {
+		b.WriteString("  headers: {\n")
+		for _, h := range req.Headers {
+			fmt.Fprintf(&b, "    %s: %s,\n", strconv.Quote(h.Name), strconv.Quote(h.Value))
+		}
+		b.WriteString("  },\n")
+	}
+	if req.Body != "" {
+		fmt.Fprintf(&b, "  body: JSON.stringify(%s),\n", strings.ReplaceAll(req.Body, "\n", "\n  "))
+	}
+	b.WriteString("});\nconst data = await response.json();")
+	return b.String()
+}
+
+// goSample renders a request with net/http.
+func goSample(req CodeSampleRequest) string {
+	var b strings.Builder
+	body := "nil"
+	if req.Body != "" {
+		literal := "`" + req.Body + "`"
+		if strings.Contains(req.Body, "`") {
+			literal = strconv.Quote(req.Body)
+		}
+		fmt.Fprintf(&b, "body := strings.NewReader(%s)\n", literal)
+		body = "body"
+	}
+	fmt.Fprintf(&b, "req, err := http.NewRequest(%q, %s, %s)\n", req.Method, strconv.Quote(req.URL), body)
+	b.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+	for _, h := range req.Headers {
+		fmt.Fprintf(&b, "req.Header.Set(%s, %s)\n", strconv.Quote(h.Name), strconv.Quote(h.Value))
+	}
+	b.WriteString("resp, err := http.DefaultClient.Do(req)\nif err != nil {\n\tlog.Fatal(err)\n}\ndefer resp.Body.Close()")
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package gindocs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCodeSamples(t *testing.T) {
+	type CreateUser struct {
+		Name string `json:"name" docs:"example:Ada"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/users/:id/notes", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{
+		Servers:     []ServerInfo{{URL: "https://api.example.com"}},
+		Auth:        AuthConfig{Type: AuthBearer},
+		CodeSamples: true,
+		CodeSampleHook: func(req CodeSampleRequest) []CodeSample {
+			return []CodeSample{{Lang: "HTTPie", Source: "http " + req.Method + " " + req.URL}}
+		},
+	})
+	gd.Route("POST /users/:id/notes").RequestBody(CreateUser{}).Security("bearerAuth")
+
+	op := gd.Spec().Paths["/users/{id}/notes"].Post
+	if len(op.CodeSamples) != 4 {
+		t.Fatalf("expected 4 code samples, got %+v", op.CodeSamples)
+	}
+	curl := op.CodeSamples[0].Source
+	for _, want := range []string{
+		"curl -X POST 'https://api.example.com/users/1/notes'",
+		"-H 'Authorization: Bearer <token>'",
+		"-d '{\n  \"name\": \"Ada\"\n}'",
+	} {
+		if !strings.Contains(curl, want) {
+			t.Errorf("expected %q in curl sample:\n%s", want, curl)
+		}
+	}
+	if !strings.Contains(op.CodeSamples[2].Source, `req.Header.Set("Authorization", "Bearer <token>")`) {
+		t.Errorf("Go sample:\n%s", op.CodeSamples[2].Source)
+	}
+	if got := op.CodeSamples[3].Source; got != "http POST https://api.example.com/users/1/notes" {
+		t.Errorf("hook sample = %q", got)
+	}
+}
+
+func TestCodeSamplesRequestServer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	gd := Mount(r, nil, Config{CodeSamples: true})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil)
+	req.Host = "api.example.com"
+	r.ServeHTTP(w, req)
+	body := w.Body.String()
+	if !strings.Contains(body, "curl 'http://api.example.com/users'") || strings.Contains(body, "localhost") {
+		t.Errorf("expected samples to call the request's server, got %s", body)
+	}
+
+	// The cached spec keeps the default base URL.
+	if src := gd.Spec().Paths["/users"].Get.CodeSamples[0].Source; src != "curl 'http://localhost:8080/users'" {
+		t.Errorf("cached sample = %q", src)
+	}
+}
@@ -0,0 +1,371 @@
+package gindocs
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sourceFiles caches parsed Go files by path. Files that fail to parse are
+// cached as nil.
+var (
+	sourceFilesMu sync.Mutex
+	sourceFiles   = map[string]*parsedFile{}
+)
+
+// parsedFile is a Go file parsed with its comments.
+type parsedFile struct {
+	fset *token.FileSet
+	file *ast.File
+}
+
+// parseSourceFile parses a Go file, returning nil if it can't be read.
+func parseSourceFile(path string) *parsedFile {
+	sourceFilesMu.Lock()
+	defer sourceFilesMu.Unlock()
+
+	if f, ok := sourceFiles[path]; ok {
+		return f
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	var f *parsedFile
+	if err == nil {
+		f = &parsedFile{fset: fset, file: file}
+	}
+	sourceFiles[path] = f
+	return f
+}
+
+// handlerComment returns the summary and description from the doc comment of
+// the handler function named handlerName (as reported by the runtime). The
+// function is looked up in file. Closures (".funcN") only take the comment
+// of a handler factory that returns them at line; inline closures, such as
+// those registered in main, get none. Method values have no file of their
+// own; their package is looked up under root.
+func handlerComment(root, file, handlerName string, line int) (summary, description string) {
+	pkgPath, receiver, name := splitHandlerName(handlerName)
+	closure := isClosureName(handlerName)
+
+	var match *ast.FuncDecl
+	if f := parseSourceFile(file); f != nil {
+		for _, decl := range f.file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if closure {
+				if returnsFuncLitAt(f.fset, fn, line) {
+					match = fn
+					break
+				}
+				continue
+			}
+			if fn.Name.Name == name && receiverName(fn) == receiver {
+				match = fn
+				break
+			}
+		}
+	} else if name != "" && !closure {
+		for _, f := range packageFiles(root, pkgPath) {
+			for _, decl := range f.file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == name && receiverName(fn) == receiver {
+					match = fn
+				}
+			}
+			if match != nil {
+				break
+			}
+		}
+	}
+
+	if match == nil || match.Doc == nil {
+		return "", ""
+	}
+	return splitDocComment(match.Doc.Text(), match.Name.Name)
+}
+
+// isClosureName reports whether a runtime function name is that of a
+// function literal, e.g. "main.main.func1".
+func isClosureName(handlerName string) bool {
+	for _, part := range strings.Split(handlerName[strings.LastIndex(handlerName, "/")+1:], ".") {
+		if rest, ok := strings.CutPrefix(part, "func"); ok && rest != "" && unicode.IsDigit(rune(rest[0])) {
+			return true
+		}
+	}
+	return false
+}
+
+// returnsFuncLitAt reports whether fn returns a function literal that starts
+// at line, as handler factories do.
+func returnsFuncLitAt(fset *token.FileSet, fn *ast.FuncDecl, line int) bool {
+	if fn.Body == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || found {
+			return !found
+		}
+		for _, result := range ret.Results {
+			if lit, ok := result.(*ast.FuncLit); ok && fset.Position(lit.Pos()).Line == line {
+				found = true
+			}
+		}
+		return false
+	})
+	return found
+}
+
+// packageDirsCache caches the package name of every directory under a root.
+var (
+	packageDirsMu    sync.Mutex
+	packageDirsCache = map[string]map[string]string{}
+)
+
+// packageFiles returns the parsed files of the package with import path
+// pkgPath found under root. A directory matches when its package name is
+// the last element of pkgPath and its path relative to root is a suffix of
+// pkgPath.
+func packageFiles(root, pkgPath string) []*parsedFile {
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+	last := pkgPath[strings.LastIndex(pkgPath, "/")+1:]
+
+	var files []*parsedFile
+	for _, dir := range sortedKeys(packageDirs(root)) {
+		if packageDirs(root)[dir] != last {
+			continue
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		if pkgPath != "main" && rel != "." && !strings.HasSuffix(pkgPath, "/"+rel) {
+			continue
+		}
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".go") {
+				if f := parseSourceFile(filepath.Join(dir, entry.Name())); f != nil {
+					files = append(files, f)
+				}
+			}
+		}
+	}
+	return files
+}
+
+// packageDirs returns the package name of every directory with Go files
+// under root, skipping vendor, testdata and hidden directories.
+func packageDirs(root string) map[string]string {
+	packageDirsMu.Lock()
+	defer packageDirsMu.Unlock()
+
+	if dirs, ok := packageDirsCache[root]; ok {
+		return dirs
+	}
+	dirs := map[string]string{}
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (name == "vendor" || name == "testdata" || name == "node_modules" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, seen := dirs[filepath.Dir(path)]; seen || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		if file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly); err == nil {
+			dirs[filepath.Dir(path)] = file.Name.Name
+		}
+		return nil
+	})
+	packageDirsCache[root] = dirs
+	return dirs
+}
+
+// splitHandlerName returns the package path, receiver type and function
+// name of a runtime function name such as
+// "example.com/app/handlers.(*UserHandler).List-fm" or "main.listUsers.func1".
+func splitHandlerName(handlerName string) (pkgPath, receiver, name string) {
+	slash := strings.LastIndex(handlerName, "/") + 1
+	dot := strings.Index(handlerName[slash:], ".")
+	if dot < 0 {
+		return "", "", ""
+	}
+	pkgPath = handlerName[:slash+dot]
+	parts := strings.Split(strings.TrimSuffix(handlerName[slash+dot+1:], "-fm"), ".")
+
+	if len(parts) >= 2 && (strings.HasPrefix(parts[0], "(") || startsUpper(parts[1])) && !strings.HasPrefix(parts[1], "func") {
+		receiver = strings.Trim(parts[0], "(*)")
+		if i := strings.Index(receiver, "["); i >= 0 {
+			receiver = receiver[:i]
+		}
+		return pkgPath, receiver, parts[1]
+	}
+	name = parts[0]
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return pkgPath, "", name
+}
+
+// receiverName returns the receiver type name of a method, or "".
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	t := fn.Recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+		case *ast.IndexExpr:
+			t = x.X
+		case *ast.IndexListExpr:
+			t = x.X
+		case *ast.Ident:
+			return x.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// splitDocComment splits a doc comment into its first sentence and the
+// rest. A leading identifier, as in "ListUsers returns all users.", is
+// dropped from the summary, and annotation lines starting with "@" are
+// ignored.
+func splitDocComment(text, ident string) (summary, description string) {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "@") {
+			lines = append(lines, line)
+		}
+	}
+	text = strings.TrimSpace(strings.Join(lines, "\n"))
+	if text == "" {
+		return "", ""
+	}
+
+	// The first sentence ends at a period followed by whitespace, or at
+	// the end of the first paragraph.
+	end := strings.Index(text, "\n\n")
+	if end < 0 {
+		end = len(text)
+	}
+	for i := 0; i < end; i++ {
+		if text[i] == '.' && (i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\n') {
+			end = i + 1
+			break
+		}
+	}
+	summary = strings.Join(strings.Fields(text[:end]), " ")
+	description = strings.TrimSpace(text[end:])
+
+	if rest, ok := strings.CutPrefix(summary, ident+" "); ok && rest != "" {
+		r, size := utf8.DecodeRuneInString(rest)
+		summary = string(unicode.ToUpper(r)) + rest[size:]
+	}
+	return strings.TrimSuffix(summary, "."), description
+}
+
+// startsUpper reports whether s starts with an upper-case letter.
+func startsUpper(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(r)
+}
+
+// typeComments returns the doc comment of the named struct type t and the
+// comments of its fields by field name, looked up in the package source
+// under root. A field's doc comment wins over its trailing line comment.
+func typeComments(root string, t reflect.Type) (doc string, fields map[string]string) {
+	name := t.Name()
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" || t.PkgPath() == "" {
+		return "", nil
+	}
+
+	for _, f := range packageFiles(root, t.PkgPath()) {
+		for _, decl := range f.file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gen.Specs {
+				spec := s.(*ast.TypeSpec)
+				if spec.Name.Name != name {
+					continue
+				}
+				group := spec.Doc
+				if group == nil && len(gen.Specs) == 1 {
+					group = gen.Doc
+				}
+				doc = commentText(group)
+
+				fields = make(map[string]string)
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					for _, field := range st.Fields.List {
+						text := commentText(field.Doc)
+						if text == "" {
+							text = commentText(field.Comment)
+						}
+						for _, ident := range field.Names {
+							if text != "" {
+								fields[ident.Name] = strings.TrimSuffix(text, ".")
+							}
+						}
+					}
+				}
+				return doc, fields
+			}
+		}
+	}
+	return "", nil
+}
+
+// commentText returns the text of a comment group without annotation lines
+// starting with "@", with lines joined into paragraphs.
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	var paragraphs []string
+	var current []string
+	for _, line := range strings.Split(group.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "@") {
+			continue
+		}
+		if line == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, " "))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, " "))
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,102 @@
+package gindocs
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listWidgets returns every widget.
+//
+// Results are sorted by name.
+func listWidgets(c *gin.Context) {}
+
+type widgetHandler struct{}
+
+// Get fetches one widget. Missing widgets return 404.
+//
+// @Router /widgets/{id} [get]
+func (h *widgetHandler) Get(c *gin.Context) {}
+
+// newWidgetCreator returns the handler that creates widgets.
+func newWidgetCreator() gin.HandlerFunc {
+	return func(c *gin.Context) {}
+}
+
+// setupProbeRoutes registers the health probes.
+func setupProbeRoutes(r *gin.Engine) {
+	r.GET("/healthz", func(c *gin.Context) {})
+	r.GET("/readyz", func(c *gin.Context) {})
+}
+
+func TestHandlerComments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := &widgetHandler{}
+	r.GET("/widgets", listWidgets)
+	r.GET("/widgets/:id", h.Get)
+	r.POST("/widgets", newWidgetCreator())
+	setupProbeRoutes(r)
+	gd := Mount(r, nil, Config{HandlerComments: true})
+
+	spec := gd.getSpec()
+	tests := []struct {
+		op                   *OperationObject
+		summary, description string
+	}{
+		{spec.Paths["/widgets"].Get, "Returns every widget", "Results are sorted by name."},
+		{spec.Paths["/widgets/{id}"].Get, "Fetches one widget", "Missing widgets return 404."},
+		// Closures returned by a factory take the factory's comment.
+		{spec.Paths["/widgets"].Post, "Returns the handler that creates widgets", ""},
+		// Inline closures don't take the comment of the function around them.
+		{spec.Paths["/healthz"].Get, generateSummary("GET", "/healthz"), ""},
+		{spec.Paths["/readyz"].Get, generateSummary("GET", "/readyz"), ""},
+	}
+	for _, tt := range tests {
+		if tt.op.Summary != tt.summary || tt.op.Description != tt.description {
+			t.Errorf("got %q / %q, want %q / %q", tt.op.Summary, tt.op.Description, tt.summary, tt.description)
+		}
+	}
+}
+
+// commentedWidget is a widget sold in the store.
+type commentedWidget struct {
+	// Name is shown in listings.
+	Name  string `json:"name"`
+	Price int    `json:"price"` // in cents
+	SKU   string `json:"sku" docs:"description:Stock keeping unit"`
+}
+
+func TestModelComments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gd := Mount(gin.New(), nil, Config{ModelComments: true, Models: []interface{}{commentedWidget{}}, KeepUnusedSchemas: true})
+
+	schema := gd.getSpec().Components.Schemas["commentedWidget"]
+	if schema == nil {
+		t.Fatal("commentedWidget schema missing")
+	}
+	if schema.Description != "commentedWidget is a widget sold in the store." {
+		t.Errorf("description = %q", schema.Description)
+	}
+	want := map[string]string{"name": "Name is shown in listings", "price": "in cents", "sku": "Stock keeping unit"}
+	for name, description := range want {
+		if got := schema.Properties[name].Description; got != description {
+			t.Errorf("%s description = %q, want %q", name, got, description)
+		}
+	}
+}
+
+func TestSplitHandlerName(t *testing.T) {
+	tests := []struct{ in, pkgPath, receiver, name string }{
+		{"main.listUsers", "main", "", "listUsers"},
+		{"example.com/app/handlers.(*UserHandler).List-fm", "example.com/app/handlers", "UserHandler", "List"},
+		{"example.com/app/handlers.UserHandler.List-fm", "example.com/app/handlers", "UserHandler", "List"},
+		{"main.NewRouter.func1", "main", "", "NewRouter"},
+	}
+	for _, tt := range tests {
+		pkgPath, receiver, name := splitHandlerName(tt.in)
+		if pkgPath != tt.pkgPath || receiver != tt.receiver || name != tt.name {
+			t.Errorf("splitHandlerName(%q) = %q, %q, %q", tt.in, pkgPath, receiver, name)
+		}
+	}
+}
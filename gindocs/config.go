@@ -1,5 +1,12 @@
 package gindocs
 
+import (
+	"io/fs"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
 // UIType represents the documentation UI to serve.
 type UIType int
 
@@ -10,6 +17,31 @@ const (
 	UIScalar
 )
 
+// SchemaNaming selects how component schema names are derived from Go types.
+type SchemaNaming int
+
+const (
+	// SchemaNamingShort uses the type name, e.g. "User" (default). Types that
+	// collide are renamed with their package.
+	SchemaNamingShort SchemaNaming = iota
+	// SchemaNamingPackage prefixes the package name, e.g. "billing.User".
+	SchemaNamingPackage
+	// SchemaNamingFullPath prefixes the import path, e.g.
+	// "github.com.acme.app.billing.User".
+	SchemaNamingFullPath
+)
+
+// CyclePolicy selects how recursive types are documented.
+type CyclePolicy int
+
+const (
+	// CycleRef documents a recursive field as a $ref to its schema (default).
+	CycleRef CyclePolicy = iota
+	// CycleTruncate documents a recursive field as a plain object, for tools
+	// that can't handle recursive schemas.
+	CycleTruncate
+)
+
 // AuthType represents the authentication method for "Try It" functionality.
 type AuthType int
 
@@ -29,10 +61,15 @@ type Config struct {
 	// Prefix is the URL prefix for docs endpoints (default: "/docs").
 	Prefix string
 
+	// BasePath is the path the app is served under behind a gateway (e.g.
+	// "/myapp"). It prefixes the URLs used by the docs pages and the derived
+	// server URL. X-Forwarded-Prefix from a trusted proxy overrides it.
+	BasePath string
+
 	// Title is the API title shown in the docs (default: auto-detect from module name).
 	Title string
 
-	// Description is the API description.
+	// Description is the API description, in markdown.
 	Description string
 
 	// Version is the API version (default: "1.0.0").
@@ -41,47 +78,346 @@ type Config struct {
 	// UI selects the documentation UI: UIScalar (default) or UISwagger.
 	UI UIType
 
-	// ScalarTheme sets the Scalar UI theme (default: "kepler").
-	// Options: alternate, default, moon, purple, solarized, bluePlanet,
-	// saturn, kepler, mars, deepSpace, laserwave.
+	// Scalar configures the Scalar UI: theme, layout and display options.
+	Scalar ScalarConfig
+
+	// Swagger configures the behavior of Swagger UI.
+	Swagger SwaggerConfig
+
+	// ScalarTheme sets the Scalar UI theme.
+	//
+	// Deprecated: use Scalar.Theme.
 	ScalarTheme string
 
 	// DevMode re-introspects routes on every request when true.
 	// Defaults to auto-detection from GIN_MODE.
 	DevMode bool
 
+	// Disabled leaves the docs routes unregistered, e.g. as a production
+	// kill switch. Mount still returns a usable *GinDocs.
+	Disabled bool
+
+	// EnabledEnvironments lists the Gin modes ("debug", "release", "test")
+	// the docs routes are registered in. By default they are registered in
+	// every mode except release, so production has to opt in explicitly.
+	EnabledEnvironments []string
+
 	// ReadOnly disables "Try It" functionality when true.
 	ReadOnly bool
 
 	// Auth configures authentication for "Try It" requests.
 	Auth AuthConfig
 
-	// Servers lists API server URLs for "Try It" requests.
+	// SecurityMiddleware maps glob patterns on middleware function names
+	// (matched case-insensitively, e.g. "middleware.RequireAuth*") to security
+	// scheme names. Patterns without a dot match the function name without
+	// its package. Routes whose handler chain includes a matching middleware
+	// get that security requirement. When nil and Auth is set, "*auth*" and
+	// "*jwt*" map to the Auth scheme.
+	SecurityMiddleware map[string]string
+
+	// ExposeMiddlewares lists each operation's middleware chain as
+	// x-middlewares. Off by default, since handler function names reveal
+	// the internals of the service.
+	ExposeMiddlewares bool
+
+	// Servers lists API server URLs for "Try It" requests. When empty, the
+	// served spec uses the scheme and host of the docs request.
 	Servers []ServerInfo
 
+	// TrustedProxies lists proxy IPs or CIDR ranges whose X-Forwarded-Proto
+	// and X-Forwarded-Host headers are honored when deriving the server URL.
+	TrustedProxies []string
+
 	// Contact holds API contact information.
 	Contact ContactInfo
 
 	// License holds API license information.
 	License LicenseInfo
 
+	// TermsOfService is a URL to the API's terms of service.
+	TermsOfService string
+
+	// InfoExtensions adds vendor extension (x-*) fields to the info object,
+	// e.g. {"x-data-classification": "confidential"}.
+	InfoExtensions map[string]interface{}
+
+	// ExternalDocs links the whole API to external documentation, such as a
+	// guides site.
+	ExternalDocs ExternalDocsInfo
+
+	// NetworkRequirements documents how clients must connect (IP allowlist,
+	// TLS, SNI). It is rendered as a docs section and emitted as x-network.
+	NetworkRequirements *NetworkRequirements
+
 	// Logo is a URL to a custom logo displayed in the UI.
 	Logo string
 
+	// Favicon is the URL of the docs pages' favicon.
+	Favicon string
+
+	// PageTitle is the browser title of the docs pages (default: Title).
+	PageTitle string
+
+	// MetaTags adds <meta> tags to the docs pages by name, e.g.
+	// {"og:image": "https://example.com/card.png"}. "og:" tags are written
+	// with the property attribute. og:title and og:description default to
+	// the page title and Description.
+	MetaTags map[string]string
+
 	// ExcludeRoutes is a list of glob patterns for routes to exclude from docs.
 	ExcludeRoutes []string
 
 	// ExcludePrefixes is a list of path prefixes for routes to exclude from docs.
 	ExcludePrefixes []string
 
+	// IncludePrefixes limits the docs to routes under these path prefixes.
+	// Empty documents every route.
+	IncludePrefixes []string
+
 	// Models is a list of GORM model instances to register as schemas.
 	Models []interface{}
 
-	// CustomSections adds extra documentation sections rendered as markdown.
+	// Pagination documents page, per-page, and sort query parameters on every
+	// GET list endpoint (no trailing path parameter).
+	Pagination *PaginationConfig
+
+	// ListConventions documents sort, filter, and search query parameters on
+	// GET list endpoints, with allowed fields taken from the listed model.
+	ListConventions *ListConventions
+
+	// ErrorModel is the body schema of inferred 4xx and 5xx responses
+	// (pass a struct instance, e.g. ErrorResponse{}). Inferred error
+	// responses have no body when nil.
+	ErrorModel interface{}
+
+	// ValidationErrorModel is the body schema of the validation error response
+	// documented for request bodies with binding rules (default: ValidationError).
+	ValidationErrorModel interface{}
+
+	// ValidationErrorStatus is the status code of that response (default: 422).
+	ValidationErrorStatus int
+
+	// CustomSections adds extra documentation sections. Their content is
+	// markdown, rendered to HTML with raw HTML escaped.
 	CustomSections []Section
 
+	// LandingPage, when set, serves a landing page at Prefix linking to the
+	// API reference (moved to {Prefix}/reference), versions, guides and
+	// exports.
+	LandingPage *LandingPage
+
+	// ContentSecurityPolicy sends a Content-Security-Policy header with the
+	// docs pages that allows their inline scripts and styles by a nonce
+	// generated per request.
+	ContentSecurityPolicy bool
+
+	// CSPNonce returns the nonce of the app's own Content-Security-Policy
+	// for a request. The docs pages add it to their inline scripts and
+	// styles, and no header is sent unless ContentSecurityPolicy is set.
+	CSPNonce func(c *gin.Context) string
+
+	// GuidesDir is a directory of markdown guides served as pages under
+	// {Prefix}/guides, with navigation between them. index.md, if present,
+	// is the landing page.
+	GuidesDir string
+
+	// Guides serves the guides from a file system, such as an embed.FS,
+	// instead of GuidesDir.
+	Guides fs.FS
+
 	// CustomCSS is custom CSS injected into the documentation UI.
 	CustomCSS string
+
+	// HeadHTML is raw HTML added at the end of the <head> of both UIs, such
+	// as analytics snippets. It is trusted and not escaped.
+	HeadHTML string
+
+	// BodyFooterHTML is raw HTML added at the end of the <body> of both UIs,
+	// such as a support chat widget. It is trusted and not escaped.
+	BodyFooterHTML string
+
+	// UIAssets supplies the UI files embedded by the static HTML export:
+	// api-reference.js for Scalar; swagger-ui.css, swagger-ui-bundle.js and
+	// swagger-ui-standalone-preset.js for Swagger UI. Missing files are
+	// downloaded from the CDN.
+	UIAssets fs.FS
+
+	// HandlerComments documents operations from the doc comment above each
+	// handler function: the first sentence becomes the summary and the rest
+	// the description. Overrides take precedence. The handler source must be
+	// readable at runtime.
+	HandlerComments bool
+
+	// ModelComments describes schemas from the doc comments of their struct
+	// types and properties from the comments of their fields, unless a docs
+	// tag is present. The model source must be readable at runtime.
+	ModelComments bool
+
+	// SourceLinks adds a "View source" link to every operation in DevMode,
+	// pointing at the handler's file and line (also emitted as x-source).
+	SourceLinks bool
+
+	// SourceURLTemplate turns a source location into a code host URL.
+	// {file} and {line} are substituted, e.g.
+	// "https://github.com/org/repo/blob/main/{file}#L{line}".
+	SourceURLTemplate string
+
+	// SourceRoot is trimmed from handler file paths before they are
+	// rendered (default: the working directory).
+	SourceRoot string
+
+	// PreferValidateTag gives `validate:"..."` rules precedence over
+	// `binding:"..."` rules when a field has both. Both tags are always read.
+	PreferValidateTag bool
+
+	// DisableNullable stops pointer and sql.Null* fields from being documented as nullable.
+	DisableNullable bool
+
+	// TypeSchemas maps Go types to fixed schemas, overriding the built-in
+	// handling (e.g., reflect.TypeOf(sql.NullTime{}) → a "date" string).
+	TypeSchemas map[reflect.Type]*SchemaObject
+
+	// SensitiveFieldNames are field name suffixes (case and separators
+	// ignored) documented as writeOnly in request bodies and GORM models, and
+	// left out of model response schemas (default: password, secret, token).
+	// Response-only types keep them. Set to an empty slice to disable.
+	SensitiveFieldNames []string
+
+	// FeatureFlags decides, per request, whether operations marked with
+	// Route(...).FeatureFlag(name) are included in the served spec.
+	// When nil, flagged operations are always included.
+	FeatureFlags func(c *gin.Context, flag string) bool
+
+	// Audience decides, per request, which audience the spec is served to
+	// (e.g. "public" for partners), hiding operations limited to other
+	// audiences with Route(...).Audience. When nil, the ?audience= query
+	// parameter is used, and requests without it see every operation.
+	Audience func(c *gin.Context) string
+
+	// SchemaHook is called after each component schema is registered, for
+	// global tweaks such as stripping internal fields or injecting examples.
+	SchemaHook func(name string, s *SchemaObject)
+
+	// OperationHook is called after each operation is built, as a last-mile
+	// escape hatch for setting extensions, security, or summaries per route.
+	OperationHook func(route RouteMetadata, op *OperationObject)
+
+	// SpecHook is called with the assembled spec before it is served or
+	// exported, for final adjustments such as injecting webhooks or components.
+	SpecHook func(spec *OpenAPISpec)
+
+	// SchemaNaming selects how schema names are derived from Go types.
+	// Whatever the strategy, two types never share a name: the later one is
+	// renamed with its package and a build warning is recorded.
+	SchemaNaming SchemaNaming
+
+	// InlineSchemas serves fully dereferenced specs: every $ref to a component
+	// schema is replaced by the schema itself, except recursive references.
+	// Individual requests can ask for this with ?resolve=true.
+	InlineSchemas bool
+
+	// MaxSchemaDepth limits how deeply nested structs are expanded. Deeper
+	// structs are documented as plain objects and reported in Warnings.
+	// 0 means no limit.
+	MaxSchemaDepth int
+
+	// CyclePolicy selects how recursive types are documented: CycleRef
+	// (default) or CycleTruncate.
+	CyclePolicy CyclePolicy
+
+	// KeepUnusedSchemas keeps component schemas that no operation references,
+	// such as Models and their Create/Update variants. By default they are
+	// pruned to keep the spec small.
+	KeepUnusedSchemas bool
+
+	// SchemaViews generates <Name>Request and <Name>Response schemas for models
+	// with readOnly or writeOnly fields (e.g., id excluded from requests,
+	// password excluded from responses) and references the matching view
+	// from request bodies and responses.
+	SchemaViews bool
+
+	// MethodNotAllowed documents a 405 response with an Allow header on every
+	// operation, listing the methods its path supports. Enable this when the
+	// router sets HandleMethodNotAllowed.
+	MethodNotAllowed bool
+
+	// CodeSamples adds curl, JavaScript fetch and Go request examples to
+	// every operation as x-codeSamples, shown by Scalar and Redoc.
+	CodeSamples bool
+
+	// CodeSampleHook returns extra code samples for an operation, e.g. in
+	// other languages. It runs when CodeSamples is set.
+	CodeSampleHook func(CodeSampleRequest) []CodeSample
+
+	// PayloadEstimates adds x-payload-estimate (example response size and
+	// nesting depth) to every operation with a typed success response.
+	PayloadEstimates bool
+
+	// PayloadWarnBytes flags estimates above this size with exceedsThreshold,
+	// hinting that the operation needs pagination or field selection.
+	PayloadWarnBytes int
+
+	// OverridesFile is the path of a YAML or JSON file with route summaries,
+	// descriptions, tags, parameter descriptions, examples and tag
+	// descriptions, loaded at Mount, e.g. "docs/overrides.yaml". Its values
+	// take precedence over overrides made in code.
+	OverridesFile string
+
+	// MergeSpecs lists OpenAPI 3 documents (JSON or YAML file paths or URLs)
+	// whose paths, tags and components are merged into the generated spec,
+	// e.g. hand-written specs of legacy services behind the same gateway.
+	// Generated operations and components win on conflicts, which are
+	// reported by Warnings.
+	MergeSpecs []string
+
+	// BaselineSpec is the path to a previously published OpenAPI JSON document.
+	// When set, /docs/diff compares the current spec against it.
+	BaselineSpec string
+
+	// VersionPolicy decides which version bump each kind of change requires
+	// (default: breaking → major, additive → minor, cosmetic → patch).
+	// Fields left at BumpDefault keep their default; BumpNone means no bump.
+	VersionPolicy VersionPolicy
+
+	// TrafficSource supplies observed request counts for /docs/usage, which
+	// reports undocumented-but-used routes and documented-but-dead operations.
+	// Use NewUsageCounter for an in-process counter middleware.
+	TrafficSource TrafficSource
+
+	// MockServer serves example responses for every documented operation at
+	// {prefix}/mock/<path>. Send X-Mock-Status to pick a documented status code.
+	MockServer bool
+
+	// SandboxSeed registers POST {prefix}/sandbox/seed, which inserts example
+	// rows for every model in Models using the *gorm.DB passed to Mount.
+	// Only enable this for sandbox or development databases. Outside DevMode
+	// the route is only registered when SandboxSeedToken is set.
+	SandboxSeed bool
+
+	// SandboxSeedToken, when set, must be sent in the X-Seed-Token header
+	// to call the seed endpoint. It is required outside DevMode.
+	SandboxSeedToken string
+
+	// Strict enforces documentation rules. Call MustCheck after registering
+	// overrides to fail at startup; until the policy is met, every spec
+	// build also panics with a *StrictError, so no docs are served.
+	Strict *StrictPolicy
+}
+
+// PaginationConfig describes the API's pagination convention.
+type PaginationConfig struct {
+	// PageParam is the page number query parameter (default: "page").
+	PageParam string
+
+	// PerPageParam is the page size query parameter (default: "per_page").
+	PerPageParam string
+
+	// MaxPerPage is the largest accepted page size; 0 means no limit.
+	MaxPerPage int
+
+	// SortParam is the sort query parameter (default: "sort").
+	SortParam string
 }
 
 // AuthConfig configures authentication for the "Try It" feature.
@@ -100,6 +436,15 @@ type AuthConfig struct {
 
 	// BearerFormat describes the bearer token format (e.g., "JWT").
 	BearerFormat string
+
+	// DevToken pre-fills the Bearer token or API key in the UI in DevMode
+	// (default: $GINDOCS_DEV_TOKEN). It is never used outside DevMode.
+	DevToken string
+
+	// TokenEndpoint adds a "Sign in" form to the UI that gets a token from
+	// the app's login route and uses it for "Try It" requests (Bearer auth
+	// only).
+	TokenEndpoint *TokenEndpoint
 }
 
 // ServerInfo describes an API server.
@@ -109,6 +454,22 @@ type ServerInfo struct {
 
 	// Description describes this server.
 	Description string
+
+	// Variables documents the {name} placeholders in URL, e.g.
+	// "https://{tenant}.api.example.com".
+	Variables map[string]ServerVariable
+}
+
+// ServerVariable describes a placeholder in a server URL.
+type ServerVariable struct {
+	// Default is the value used when the client picks none. Required.
+	Default string
+
+	// Enum restricts the variable to these values.
+	Enum []string
+
+	// Description describes the variable.
+	Description string
 }
 
 // ContactInfo holds API contact information.
@@ -132,6 +493,79 @@ type LicenseInfo struct {
 	URL string
 }
 
+// ExternalDocsInfo links to external documentation.
+type ExternalDocsInfo struct {
+	// URL is the documentation URL.
+	URL string
+
+	// Description describes the linked documentation.
+	Description string
+}
+
+// NetworkRequirements describes the network conditions for calling the API.
+type NetworkRequirements struct {
+	// AllowedIPRanges lists the client CIDR ranges allowed to connect.
+	AllowedIPRanges []string `json:"allowedIpRanges,omitempty"`
+
+	// MinTLSVersion is the minimum TLS version accepted (e.g., "1.2").
+	MinTLSVersion string `json:"minTlsVersion,omitempty"`
+
+	// MutualTLS requires clients to present a certificate.
+	MutualTLS bool `json:"mutualTls,omitempty"`
+
+	// SNI is the server name clients must send during the TLS handshake.
+	SNI string `json:"sni,omitempty"`
+
+	// Notes holds any additional guidance.
+	Notes string `json:"notes,omitempty"`
+}
+
+// ScalarConfig holds the display options of the Scalar UI.
+type ScalarConfig struct {
+	// Theme is the color theme (default: "kepler"). Options: alternate,
+	// default, moon, purple, solarized, bluePlanet, saturn, kepler, mars,
+	// deepSpace, laserwave, none.
+	Theme string
+
+	// Layout is "modern" (default) or "classic".
+	Layout string
+
+	// DarkMode starts the UI in dark mode.
+	DarkMode bool
+
+	// HideDownloadButton hides the button that downloads the spec.
+	HideDownloadButton bool
+
+	// DefaultOpenAllTags expands every tag in the sidebar on load.
+	DefaultOpenAllTags bool
+}
+
+// SwaggerConfig holds the behavior options of Swagger UI. Zero values keep
+// the Swagger UI defaults.
+type SwaggerConfig struct {
+	// DocExpansion is "list" (default: tags expanded), "full" (operations
+	// expanded too) or "none".
+	DocExpansion string
+
+	// Filter shows a search box that filters operations by tag.
+	Filter bool
+
+	// PersistAuthorization keeps entered credentials across page reloads.
+	PersistAuthorization bool
+
+	// TryItOutEnabled opens "Try it out" on every operation by default
+	// (default: true unless ReadOnly).
+	TryItOutEnabled *bool
+
+	// DefaultModelsExpandDepth is how deep the Schemas section is expanded
+	// (default: 1); -1 hides it.
+	DefaultModelsExpandDepth *int
+
+	// OperationsSorter orders operations within a tag: "alpha" (by path)
+	// or "method". They keep the spec order by default.
+	OperationsSorter string
+}
+
 // Section represents a custom documentation section.
 type Section struct {
 	// Title is the section heading.
@@ -139,15 +573,21 @@ type Section struct {
 
 	// Content is the section body in markdown.
 	Content string
+
+	// Tag, when set, attaches the section to the description of the tag
+	// with that name instead of rendering it below the UI.
+	Tag string
 }
 
 // defaultConfig returns a Config with sensible defaults applied.
 func defaultConfig() Config {
 	return Config{
-		Prefix:      "/docs",
-		Version:     "1.0.0",
-		UI:          UIScalar,
-		ScalarTheme: "kepler",
+		Prefix:              "/docs",
+		Version:             "1.0.0",
+		UI:                  UIScalar,
+		Scalar:              ScalarConfig{Theme: "kepler"},
+		VersionPolicy:       defaultVersionPolicy(),
+		SensitiveFieldNames: []string{"password", "secret", "token"},
 	}
 }
 
@@ -163,6 +603,9 @@ func mergeConfig(configs ...Config) Config {
 	if c.Prefix != "" {
 		cfg.Prefix = c.Prefix
 	}
+	if c.BasePath != "" {
+		cfg.BasePath = c.BasePath
+	}
 	if c.Title != "" {
 		cfg.Title = c.Title
 	}
@@ -174,41 +617,199 @@ func mergeConfig(configs ...Config) Config {
 	}
 	// Always take the user's UI choice — UISwagger is 0, UIScalar is 1.
 	cfg.UI = c.UI
-	if c.ScalarTheme != "" {
-		cfg.ScalarTheme = c.ScalarTheme
+	theme := cfg.Scalar.Theme
+	cfg.Scalar = c.Scalar
+	switch {
+	case cfg.Scalar.Theme != "":
+	case c.ScalarTheme != "":
+		cfg.Scalar.Theme = c.ScalarTheme
+	default:
+		cfg.Scalar.Theme = theme
 	}
+	cfg.ScalarTheme = cfg.Scalar.Theme
+	cfg.Swagger = c.Swagger
 	cfg.DevMode = c.DevMode
 	cfg.ReadOnly = c.ReadOnly
+	cfg.Disabled = c.Disabled
+	cfg.EnabledEnvironments = c.EnabledEnvironments
 	if c.Auth.Type != AuthNone {
 		cfg.Auth = c.Auth
 	}
+	if c.SecurityMiddleware != nil {
+		cfg.SecurityMiddleware = c.SecurityMiddleware
+	}
+	cfg.ExposeMiddlewares = c.ExposeMiddlewares
 	if len(c.Servers) > 0 {
 		cfg.Servers = c.Servers
 	}
+	if len(c.TrustedProxies) > 0 {
+		cfg.TrustedProxies = c.TrustedProxies
+	}
 	if c.Contact != (ContactInfo{}) {
 		cfg.Contact = c.Contact
 	}
 	if c.License != (LicenseInfo{}) {
 		cfg.License = c.License
 	}
+	if c.TermsOfService != "" {
+		cfg.TermsOfService = c.TermsOfService
+	}
+	if len(c.InfoExtensions) > 0 {
+		cfg.InfoExtensions = c.InfoExtensions
+	}
+	if c.ExternalDocs != (ExternalDocsInfo{}) {
+		cfg.ExternalDocs = c.ExternalDocs
+	}
 	if c.Logo != "" {
 		cfg.Logo = c.Logo
 	}
+	if c.Favicon != "" {
+		cfg.Favicon = c.Favicon
+	}
+	if c.PageTitle != "" {
+		cfg.PageTitle = c.PageTitle
+	}
+	if c.MetaTags != nil {
+		cfg.MetaTags = c.MetaTags
+	}
 	if len(c.ExcludeRoutes) > 0 {
 		cfg.ExcludeRoutes = c.ExcludeRoutes
 	}
 	if len(c.ExcludePrefixes) > 0 {
 		cfg.ExcludePrefixes = c.ExcludePrefixes
 	}
+	if len(c.IncludePrefixes) > 0 {
+		cfg.IncludePrefixes = c.IncludePrefixes
+	}
 	if len(c.Models) > 0 {
 		cfg.Models = c.Models
 	}
+	if c.Pagination != nil {
+		pagination := *c.Pagination
+		if pagination.PageParam == "" {
+			pagination.PageParam = "page"
+		}
+		if pagination.PerPageParam == "" {
+			pagination.PerPageParam = "per_page"
+		}
+		if pagination.SortParam == "" {
+			pagination.SortParam = "sort"
+		}
+		cfg.Pagination = &pagination
+	}
+	if c.ListConventions != nil {
+		cfg.ListConventions = c.ListConventions
+	}
+	if c.ErrorModel != nil {
+		cfg.ErrorModel = c.ErrorModel
+	}
+	if c.ValidationErrorModel != nil {
+		cfg.ValidationErrorModel = c.ValidationErrorModel
+	}
+	if c.ValidationErrorStatus != 0 {
+		cfg.ValidationErrorStatus = c.ValidationErrorStatus
+	}
 	if len(c.CustomSections) > 0 {
 		cfg.CustomSections = c.CustomSections
 	}
 	if c.CustomCSS != "" {
 		cfg.CustomCSS = c.CustomCSS
 	}
+	if c.LandingPage != nil {
+		cfg.LandingPage = c.LandingPage
+	}
+	cfg.ContentSecurityPolicy = c.ContentSecurityPolicy
+	if c.CSPNonce != nil {
+		cfg.CSPNonce = c.CSPNonce
+	}
+	if c.HeadHTML != "" {
+		cfg.HeadHTML = c.HeadHTML
+	}
+	if c.BodyFooterHTML != "" {
+		cfg.BodyFooterHTML = c.BodyFooterHTML
+	}
+	if c.GuidesDir != "" {
+		cfg.GuidesDir = c.GuidesDir
+	}
+	if c.Guides != nil {
+		cfg.Guides = c.Guides
+	}
+	if c.UIAssets != nil {
+		cfg.UIAssets = c.UIAssets
+	}
+	cfg.HandlerComments = c.HandlerComments
+	cfg.ModelComments = c.ModelComments
+	cfg.SourceLinks = c.SourceLinks
+	if c.SourceURLTemplate != "" {
+		cfg.SourceURLTemplate = c.SourceURLTemplate
+	}
+	if c.SourceRoot != "" {
+		cfg.SourceRoot = c.SourceRoot
+	}
+	cfg.PreferValidateTag = c.PreferValidateTag
+	cfg.DisableNullable = c.DisableNullable
+	if c.TypeSchemas != nil {
+		cfg.TypeSchemas = c.TypeSchemas
+	}
+	if c.SensitiveFieldNames != nil {
+		cfg.SensitiveFieldNames = c.SensitiveFieldNames
+	}
+	cfg.MethodNotAllowed = c.MethodNotAllowed
+	cfg.SchemaViews = c.SchemaViews
+	cfg.KeepUnusedSchemas = c.KeepUnusedSchemas
+	cfg.SchemaNaming = c.SchemaNaming
+	cfg.CyclePolicy = c.CyclePolicy
+	cfg.InlineSchemas = c.InlineSchemas
+	if c.MaxSchemaDepth > 0 {
+		cfg.MaxSchemaDepth = c.MaxSchemaDepth
+	}
+	if c.NetworkRequirements != nil {
+		cfg.NetworkRequirements = c.NetworkRequirements
+	}
+	if c.FeatureFlags != nil {
+		cfg.FeatureFlags = c.FeatureFlags
+	}
+	if c.Audience != nil {
+		cfg.Audience = c.Audience
+	}
+	if c.SchemaHook != nil {
+		cfg.SchemaHook = c.SchemaHook
+	}
+	if c.OperationHook != nil {
+		cfg.OperationHook = c.OperationHook
+	}
+	if c.SpecHook != nil {
+		cfg.SpecHook = c.SpecHook
+	}
+	cfg.CodeSamples = c.CodeSamples
+	if c.CodeSampleHook != nil {
+		cfg.CodeSampleHook = c.CodeSampleHook
+	}
+	cfg.PayloadEstimates = c.PayloadEstimates
+	if c.PayloadWarnBytes > 0 {
+		cfg.PayloadWarnBytes = c.PayloadWarnBytes
+	}
+	cfg.MockServer = c.MockServer
+	cfg.SandboxSeed = c.SandboxSeed
+	if c.SandboxSeedToken != "" {
+		cfg.SandboxSeedToken = c.SandboxSeedToken
+	}
+	if c.OverridesFile != "" {
+		cfg.OverridesFile = c.OverridesFile
+	}
+	if len(c.MergeSpecs) > 0 {
+		cfg.MergeSpecs = c.MergeSpecs
+	}
+	if c.BaselineSpec != "" {
+		cfg.BaselineSpec = c.BaselineSpec
+	}
+	if c.TrafficSource != nil {
+		cfg.TrafficSource = c.TrafficSource
+	}
+	if c.Strict != nil {
+		cfg.Strict = c.Strict
+	}
+	cfg.VersionPolicy = c.VersionPolicy.withDefaults()
 
 	return cfg
 }
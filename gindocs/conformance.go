@@ -0,0 +1,191 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Spec returns the generated OpenAPI specification.
+func (gd *GinDocs) Spec() *OpenAPISpec {
+	return gd.getSpec()
+}
+
+// ValidateResponse checks a JSON response body against the schema documented
+// for the operation and status code. path may be a route ("/users/:id") or a
+// concrete request path ("/users/42"). writeOnly properties in the body are
+// reported, since they should never be returned.
+func (gd *GinDocs) ValidateResponse(method, path string, status int, body []byte) error {
+	spec := gd.getSpec()
+	op, _ := matchOperation(spec, strings.ToUpper(method), ginPathToOpenAPI(path))
+	if op == nil {
+		return fmt.Errorf("no documented operation for %s %s", method, path)
+	}
+
+	resp, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		return fmt.Errorf("status %d is not documented for %s %s", status, method, path)
+	}
+	media, ok := resp.Content["application/json"]
+	if !ok || media.Schema == nil {
+		if len(strings.TrimSpace(string(body))) > 0 {
+			return fmt.Errorf("status %d is documented without a JSON body", status)
+		}
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+
+	var schemas map[string]*SchemaObject
+	if spec.Components != nil {
+		schemas = spec.Components.Schemas
+	}
+	if problems := validateValue(value, media.Schema, schemas, "$"); len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateValue checks a JSON-decoded value against a schema and returns one
+// message per mismatch, prefixed with the value's location.
+func validateValue(v interface{}, schema *SchemaObject, schemas map[string]*SchemaObject, at string) []string {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		resolved, ok := schemas[strings.TrimPrefix(schema.Ref, RefPath(""))]
+		if !ok {
+			return []string{at + ": unresolved " + schema.Ref}
+		}
+		return validateValue(v, resolved, schemas, at)
+	}
+
+	if v == nil {
+		if schema.Nullable || schema.Type == "" {
+			return nil
+		}
+		return []string{at + ": is null"}
+	}
+
+	var problems []string
+	for _, part := range schema.AllOf {
+		problems = append(problems, validateValue(v, part, schemas, at)...)
+	}
+	if len(schema.OneOf) > 0 {
+		matches := 0
+		for _, variant := range schema.OneOf {
+			if len(validateValue(v, variant, schemas, at)) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			problems = append(problems, fmt.Sprintf("%s: matches %d oneOf variants, want 1", at, matches))
+		}
+	}
+	if len(schema.AnyOf) > 0 {
+		matched := false
+		for _, variant := range schema.AnyOf {
+			if len(validateValue(v, variant, schemas, at)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			problems = append(problems, at+": matches no anyOf variant")
+		}
+	}
+
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, v) {
+		problems = append(problems, fmt.Sprintf("%s: %v is not one of %v", at, v, schema.Enum))
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return append(problems, at+": expected object")
+		}
+		for _, name := range schema.Required {
+			if _, ok := obj[name]; !ok {
+				problems = append(problems, at+": missing required property "+name)
+			}
+		}
+		for name, value := range obj {
+			prop, ok := schema.Properties[name]
+			if !ok {
+				continue
+			}
+			if prop.WriteOnly {
+				problems = append(problems, at+"."+name+": writeOnly property returned in response")
+				continue
+			}
+			problems = append(problems, validateValue(value, prop, schemas, at+"."+name)...)
+		}
+	case "array":
+		items, ok := v.([]interface{})
+		if !ok {
+			return append(problems, at+": expected array")
+		}
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			problems = append(problems, fmt.Sprintf("%s: has %d items, want at least %d", at, len(items), *schema.MinItems))
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			problems = append(problems, fmt.Sprintf("%s: has %d items, want at most %d", at, len(items), *schema.MaxItems))
+		}
+		for i, item := range items {
+			problems = append(problems, validateValue(item, schema.Items, schemas, fmt.Sprintf("%s[%d]", at, i))...)
+		}
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return append(problems, at+": expected string")
+		}
+		if schema.MinLength != nil && len([]rune(s)) < *schema.MinLength {
+			problems = append(problems, fmt.Sprintf("%s: shorter than %d characters", at, *schema.MinLength))
+		}
+		if schema.MaxLength != nil && len([]rune(s)) > *schema.MaxLength {
+			problems = append(problems, fmt.Sprintf("%s: longer than %d characters", at, *schema.MaxLength))
+		}
+		if schema.Pattern != "" {
+			if re, err := regexp.Compile(schema.Pattern); err == nil && !re.MatchString(s) {
+				problems = append(problems, fmt.Sprintf("%s: does not match %s", at, schema.Pattern))
+			}
+		}
+	case "integer", "number":
+		n, ok := v.(float64)
+		if !ok {
+			return append(problems, at+": expected "+schema.Type)
+		}
+		if schema.Type == "integer" && n != float64(int64(n)) {
+			problems = append(problems, at+": expected integer")
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			problems = append(problems, fmt.Sprintf("%s: %v is below the minimum %v", at, n, *schema.Minimum))
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			problems = append(problems, fmt.Sprintf("%s: %v is above the maximum %v", at, n, *schema.Maximum))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			problems = append(problems, at+": expected boolean")
+		}
+	}
+
+	return problems
+}
+
+// enumContains reports whether v equals one of the enum values.
+func enumContains(enum []interface{}, v interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package gindocs
+
+import (
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OperationCoverage records which parts of an operation are documented
+// explicitly rather than inferred.
+type OperationCoverage struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	OperationID string   `json:"operationId,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+
+	// Summary is set when the summary isn't the generated one.
+	Summary bool `json:"summary"`
+
+	// RequestBody is set when a request body is documented. It is only
+	// counted for POST, PUT and PATCH.
+	RequestBody bool `json:"requestBody"`
+
+	// TypedResponse is set when a 2xx response has a schema.
+	TypedResponse bool `json:"typedResponse"`
+
+	// Example is set when a request or response carries an example.
+	Example bool `json:"example"`
+}
+
+// CoverageStats totals coverage over a set of operations.
+type CoverageStats struct {
+	Operations     int `json:"operations"`
+	Summaries      int `json:"summaries"`
+	BodyOperations int `json:"bodyOperations"`
+	RequestBodies  int `json:"requestBodies"`
+	TypedResponses int `json:"typedResponses"`
+	Examples       int `json:"examples"`
+
+	// Percent is the share of applicable checks that pass.
+	Percent float64 `json:"percent"`
+}
+
+// CoverageReport summarizes documentation completeness.
+type CoverageReport struct {
+	Total CoverageStats `json:"total"`
+
+	// Tags breaks the totals down by each operation's first tag, so
+	// coverage can be tracked per team. Untagged operations are under "untagged".
+	Tags map[string]CoverageStats `json:"tags"`
+
+	Operations []OperationCoverage `json:"operations"`
+}
+
+// Coverage reports which operations have hand-written summaries, request
+// bodies, typed responses and examples versus pure inference.
+func (gd *GinDocs) Coverage() CoverageReport {
+	return generateCoverageReport(gd.getSpec())
+}
+
+// generateCoverageReport inspects every operation in spec.
+func generateCoverageReport(spec *OpenAPISpec) CoverageReport {
+	report := CoverageReport{
+		Tags:       make(map[string]CoverageStats),
+		Operations: []OperationCoverage{},
+	}
+
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+
+			cov := operationCoverage(method, path, op)
+			report.Operations = append(report.Operations, cov)
+
+			tag := "untagged"
+			if len(op.Tags) > 0 {
+				tag = op.Tags[0]
+			}
+			stats := report.Tags[tag]
+			stats.add(cov)
+			report.Tags[tag] = stats
+			report.Total.add(cov)
+		}
+	}
+
+	report.Total.Percent = report.Total.percent()
+	for tag, stats := range report.Tags {
+		stats.Percent = stats.percent()
+		report.Tags[tag] = stats
+	}
+	return report
+}
+
+// operationCoverage checks a single operation.
+func operationCoverage(method, path string, op *OperationObject) OperationCoverage {
+	cov := OperationCoverage{
+		Method:      method,
+		Path:        path,
+		OperationID: op.OperationID,
+		Tags:        op.Tags,
+		Summary:     hasCustomSummary(method, path, op),
+	}
+
+	if op.RequestBody != nil {
+		for _, media := range op.RequestBody.Content {
+			cov.RequestBody = cov.RequestBody || media.Schema != nil
+			cov.Example = cov.Example || media.Example != nil
+		}
+	}
+	for code, resp := range op.Responses {
+		for _, media := range resp.Content {
+			if strings.HasPrefix(code, "2") && media.Schema != nil {
+				cov.TypedResponse = true
+			}
+			cov.Example = cov.Example || media.Example != nil
+		}
+	}
+
+	return cov
+}
+
+// add counts an operation's coverage.
+func (s *CoverageStats) add(cov OperationCoverage) {
+	s.Operations++
+	if cov.Summary {
+		s.Summaries++
+	}
+	if takesBody(cov.Method) {
+		s.BodyOperations++
+		if cov.RequestBody {
+			s.RequestBodies++
+		}
+	}
+	if cov.TypedResponse {
+		s.TypedResponses++
+	}
+	if cov.Example {
+		s.Examples++
+	}
+}
+
+// percent returns the share of passing checks, rounded to one decimal.
+func (s CoverageStats) percent() float64 {
+	checks := 3*s.Operations + s.BodyOperations
+	if checks == 0 {
+		return 0
+	}
+	passed := s.Summaries + s.RequestBodies + s.TypedResponses + s.Examples
+	return math.Round(float64(passed)/float64(checks)*1000) / 10
+}
+
+// takesBody reports whether requests with method usually carry a body.
+func takesBody(method string) bool {
+	return method == "POST" || method == "PUT" || method == "PATCH"
+}
+
+// hasCustomSummary reports whether op has a summary other than the one
+// generated from its method and path.
+func hasCustomSummary(method, path string, op *OperationObject) bool {
+	return op.Summary != "" && op.Summary != generateSummary(method, openAPIPathToGin(path))
+}
+
+// handleCoverage serves the documentation coverage report.
+func (gd *GinDocs) handleCoverage(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache")
+	// Report on the spec this request may see, so hidden operations stay hidden.
+	c.JSON(http.StatusOK, generateCoverageReport(gd.requestSpec(c)))
+}
@@ -0,0 +1,63 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCoverage(t *testing.T) {
+	type Item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/items", func(c *gin.Context) {})
+	r.POST("/items", func(c *gin.Context) {})
+	r.GET("/users", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Route("GET /items").Tags("Items").Summary("List items").Response(200, []Item{}, "Items")
+	gd.Route("POST /items").Tags("Items").RequestBody(Item{}).ResponseJSON(201, `{"id":1,"name":"a"}`, "Created")
+
+	report := gd.Coverage()
+	if len(report.Operations) != 3 {
+		t.Fatalf("expected 3 operations, got %d", len(report.Operations))
+	}
+
+	want := CoverageStats{Operations: 3, Summaries: 1, BodyOperations: 1, RequestBodies: 1, TypedResponses: 2, Examples: 1, Percent: 50}
+	if report.Total != want {
+		t.Errorf("total = %+v, want %+v", report.Total, want)
+	}
+	if items := report.Tags["Items"]; items.Operations != 2 || items.Percent != 71.4 {
+		t.Errorf("Items stats = %+v", items)
+	}
+	if _, ok := report.Tags["untagged"]; ok {
+		t.Error("expected /users to be tagged by inference")
+	}
+}
+
+func TestCoverageEndpointFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/items", func(c *gin.Context) {})
+	r.GET("/beta", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{FeatureFlags: func(c *gin.Context, flag string) bool { return false }})
+	gd.Route("GET /beta").FeatureFlag("beta")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/coverage", nil))
+	var report CoverageReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Operations) != 1 || report.Operations[0].Path != "/items" {
+		t.Errorf("operations = %+v, want only /items", report.Operations)
+	}
+}
@@ -0,0 +1,78 @@
+package gindocs
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cspNonce returns the nonce for the inline scripts and styles of the
+// request's docs page: Config.CSPNonce, else a random one when
+// Config.ContentSecurityPolicy is set, else "".
+func (gd *GinDocs) cspNonce(c *gin.Context) string {
+	if gd.config.CSPNonce != nil {
+		return gd.config.CSPNonce(c)
+	}
+	if !gd.config.ContentSecurityPolicy {
+		return ""
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// writeHTML writes a docs page, adding the CSP nonce to its inline script
+// and style tags and sending the recommended Content-Security-Policy header
+// when configured.
+func (gd *GinDocs) writeHTML(c *gin.Context, html string) {
+	if nonce := gd.cspNonce(c); nonce != "" {
+		html = applyNonce(html, nonce)
+		if gd.config.ContentSecurityPolicy {
+			c.Header("Content-Security-Policy", gd.recommendedCSP(nonce))
+		}
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+}
+
+// applyNonce adds a nonce attribute to every script and style tag of html.
+func applyNonce(html, nonce string) string {
+	attr := ` nonce="` + nonce + `"`
+	return strings.NewReplacer(
+		"<script>", "<script"+attr+">",
+		"<script ", "<script"+attr+" ",
+		"<style>", "<style"+attr+">",
+		"<style ", "<style"+attr+" ",
+	).Replace(html)
+}
+
+// recommendedCSP returns a Content-Security-Policy under which the docs
+// pages work: scripts need the nonce or come from the UI CDN, and requests
+// may go to this origin and the configured servers. Inline styles stay
+// allowed because both UIs set styles at runtime.
+func (gd *GinDocs) recommendedCSP(nonce string) string {
+	connect := []string{"'self'"}
+	for _, server := range gd.config.Servers {
+		if u, err := url.Parse(server.URL); err == nil && u.Scheme != "" && u.Host != "" && !strings.Contains(u.Host, "{") {
+			connect = append(connect, u.Scheme+"://"+u.Host)
+		}
+	}
+
+	directives := []string{
+		"default-src 'self'",
+		"script-src 'self' 'nonce-" + nonce + "' https://cdn.jsdelivr.net",
+		"style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net https://fonts.googleapis.com https://fonts.scalar.com",
+		"font-src 'self' data: https://cdn.jsdelivr.net https://fonts.gstatic.com https://fonts.scalar.com",
+		"img-src 'self' data: https:",
+		"connect-src " + strings.Join(connect, " "),
+		"object-src 'none'",
+		"base-uri 'self'",
+		"frame-ancestors 'self'",
+	}
+	return strings.Join(directives, "; ")
+}
@@ -0,0 +1,76 @@
+package gindocs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestContentSecurityPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	gd := Mount(r, nil, Config{
+		ContentSecurityPolicy: true,
+		Servers:               []ServerInfo{{URL: "https://api.acme.test/v1"}},
+	})
+	gd.Version("v1", PrefixFilter("/v1"))
+
+	get := func() (string, string) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs?ui=swagger", nil))
+		return w.Body.String(), w.Header().Get("Content-Security-Policy")
+	}
+
+	body, csp := get()
+	m := regexp.MustCompile(`'nonce-([^']+)'`).FindStringSubmatch(csp)
+	if m == nil {
+		t.Fatalf("expected a nonce in the CSP header, got %q", csp)
+	}
+	nonce := m[1]
+	if !strings.Contains(csp, "connect-src 'self' https://api.acme.test") {
+		t.Errorf("expected the servers in connect-src, got %q", csp)
+	}
+	if n := strings.Count(body, "<script"); n == 0 || n != strings.Count(body, `<script nonce="`+nonce+`"`) {
+		t.Errorf("expected every script tag to carry the nonce")
+	}
+	if strings.Contains(body, "onchange=") {
+		t.Error("expected no inline event handlers")
+	}
+	if _, again := get(); again == csp {
+		t.Error("expected a new nonce per request")
+	}
+}
+
+func TestCSPNonceFromApp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	Mount(r, nil, Config{UI: UIScalar, CSPNonce: func(c *gin.Context) string { return "app-nonce" }})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if !strings.Contains(w.Body.String(), `<script nonce="app-nonce">`) {
+		t.Error("expected the app's nonce on inline scripts")
+	}
+	if w.Header().Get("Content-Security-Policy") != "" {
+		t.Error("expected no CSP header without ContentSecurityPolicy")
+	}
+}
+
+func TestCSPNonceEditor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	Mount(r, nil, Config{DevMode: true, ContentSecurityPolicy: true, CSPNonce: func(c *gin.Context) string { return "edit-nonce" }})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/edit", nil))
+	if !strings.Contains(w.Body.String(), `<script nonce="edit-nonce">`) {
+		t.Error("expected the nonce on the playground's scripts")
+	}
+	if !strings.Contains(w.Header().Get("Content-Security-Policy"), "edit-nonce") {
+		t.Error("expected a CSP header on the playground")
+	}
+}
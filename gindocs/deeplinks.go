@@ -0,0 +1,75 @@
+package gindocs
+
+import (
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// findOperation locates an operation by ID. Returns the method, path, and operation.
+func findOperation(spec *OpenAPISpec, operationID string) (string, string, *OperationObject) {
+	for path, pathItem := range spec.Paths {
+		for _, method := range httpMethods {
+			if op := pathItem.GetOperation(method); op != nil && op.OperationID == operationID {
+				return method, path, op
+			}
+		}
+	}
+	return "", "", nil
+}
+
+// operationAnchor returns the URL fragment that selects an operation in the given UI.
+// Both UIs are configured to anchor operations by operationId.
+func operationAnchor(uiType UIType, op *OperationObject) string {
+	tag := "default"
+	if len(op.Tags) > 0 {
+		tag = op.Tags[0]
+	}
+
+	switch uiType {
+	case UIScalar:
+		return "#tag/" + scalarSlug(tag) + "/" + op.OperationID
+	default:
+		// Swagger UI deep links are #/{tag}/{operationId} with whitespace escaped.
+		return "#/" + strings.Join(strings.Fields(tag), "%20") + "/" + op.OperationID
+	}
+}
+
+// scalarSlug mirrors Scalar's default tag slug: lowercase with runs of
+// non-alphanumeric characters collapsed to hyphens.
+func scalarSlug(s string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			hyphen = false
+		} else if !hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// handleOperationLink redirects /docs/op/{operationId} to the operation in the UI.
+func (gd *GinDocs) handleOperationLink(c *gin.Context) {
+	_, _, op := findOperation(gd.requestSpec(c), c.Param("operationId"))
+	if op == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "operation not found"})
+		return
+	}
+
+	uiType := gd.config.UI
+	query := ""
+	switch c.Query("ui") {
+	case "scalar":
+		uiType, query = UIScalar, "?ui=scalar"
+	case "swagger":
+		uiType, query = UISwagger, "?ui=swagger"
+	}
+
+	c.Redirect(http.StatusFound, gd.referenceURL(c)+query+operationAnchor(uiType, op))
+}
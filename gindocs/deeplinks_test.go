@@ -0,0 +1,47 @@
+package gindocs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOperationLink(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/widgets", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Route("GET /widgets").OperationID("listWidgets").Tags("Widget Admin")
+
+	tests := []struct {
+		url, wantSuffix string
+		wantStatus      int
+	}{
+		{"/docs/op/listWidgets", "/docs#tag/widget-admin/listWidgets", http.StatusFound},
+		{"/docs/op/listWidgets?ui=swagger", "/docs?ui=swagger#/Widget%20Admin/listWidgets", http.StatusFound},
+		{"/docs/op/missing", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+		if w.Code != tt.wantStatus || !strings.HasSuffix(w.Header().Get("Location"), tt.wantSuffix) {
+			t.Errorf("%s: got %d %q", tt.url, w.Code, w.Header().Get("Location"))
+		}
+	}
+}
+
+func TestScalarSlug(t *testing.T) {
+	for in, want := range map[string]string{
+		"Widgets":          "widgets",
+		"Widget Admin":     "widget-admin",
+		"  Users & Roles ": "users-roles",
+	} {
+		if got := scalarSlug(in); got != want {
+			t.Errorf("scalarSlug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
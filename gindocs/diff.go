@@ -0,0 +1,435 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BumpLevel represents a semantic version bump.
+type BumpLevel int
+
+const (
+	// BumpDefault leaves a VersionPolicy field at its default. It is never
+	// suggested.
+	BumpDefault BumpLevel = iota
+	// BumpNone means no version change is needed.
+	BumpNone
+	// BumpPatch suggests a patch version bump.
+	BumpPatch
+	// BumpMinor suggests a minor version bump.
+	BumpMinor
+	// BumpMajor suggests a major version bump.
+	BumpMajor
+)
+
+// String returns the lowercase name of the bump level.
+func (b BumpLevel) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	case BumpDefault:
+		return "default"
+	default:
+		return "none"
+	}
+}
+
+// MarshalJSON encodes the bump level as its name.
+func (b BumpLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// ChangeCategory classifies a spec change by its impact on API consumers.
+type ChangeCategory string
+
+const (
+	// ChangeBreaking is a change that can break existing clients.
+	ChangeBreaking ChangeCategory = "breaking"
+	// ChangeAdditive is a backwards-compatible addition.
+	ChangeAdditive ChangeCategory = "additive"
+	// ChangeCosmetic is a documentation-only change.
+	ChangeCosmetic ChangeCategory = "cosmetic"
+)
+
+// VersionPolicy maps change categories to the version bump they require.
+// Fields left at BumpDefault use the default; set BumpNone for no bump.
+type VersionPolicy struct {
+	// Breaking is the bump for breaking changes (default: BumpMajor).
+	Breaking BumpLevel
+
+	// Additive is the bump for backwards-compatible additions (default: BumpMinor).
+	Additive BumpLevel
+
+	// Cosmetic is the bump for documentation-only changes (default: BumpPatch).
+	Cosmetic BumpLevel
+}
+
+// defaultVersionPolicy returns the standard semver policy.
+func defaultVersionPolicy() VersionPolicy {
+	return VersionPolicy{
+		Breaking: BumpMajor,
+		Additive: BumpMinor,
+		Cosmetic: BumpPatch,
+	}
+}
+
+// withDefaults returns p with its BumpDefault fields set from the default
+// policy.
+func (p VersionPolicy) withDefaults() VersionPolicy {
+	def := defaultVersionPolicy()
+	if p.Breaking == BumpDefault {
+		p.Breaking = def.Breaking
+	}
+	if p.Additive == BumpDefault {
+		p.Additive = def.Additive
+	}
+	if p.Cosmetic == BumpDefault {
+		p.Cosmetic = def.Cosmetic
+	}
+	return p
+}
+
+// levelFor returns the bump level the policy assigns to a change category.
+func (p VersionPolicy) levelFor(category ChangeCategory) BumpLevel {
+	switch category {
+	case ChangeBreaking:
+		return p.Breaking
+	case ChangeAdditive:
+		return p.Additive
+	case ChangeCosmetic:
+		return p.Cosmetic
+	}
+	return BumpNone
+}
+
+// SpecChange describes a single difference between two specs.
+type SpecChange struct {
+	// Category classifies the change.
+	Category ChangeCategory `json:"category"`
+
+	// Location identifies where the change happened (e.g., "GET /users" or "schemas.User").
+	Location string `json:"location"`
+
+	// Message describes the change.
+	Message string `json:"message"`
+}
+
+// SpecDiff is the result of comparing the current spec against a baseline.
+type SpecDiff struct {
+	// Baseline is the path of the baseline spec.
+	Baseline string `json:"baseline"`
+
+	// CurrentVersion is the configured API version.
+	CurrentVersion string `json:"currentVersion"`
+
+	// Bump is the suggested version bump.
+	Bump BumpLevel `json:"bump"`
+
+	// SuggestedVersion is CurrentVersion with Bump applied, if it is valid semver.
+	SuggestedVersion string `json:"suggestedVersion,omitempty"`
+
+	// Changes lists every detected change.
+	Changes []SpecChange `json:"changes"`
+}
+
+// Diff compares the current spec against Config.BaselineSpec and suggests a version bump.
+func (gd *GinDocs) Diff() (*SpecDiff, error) {
+	return gd.diffAgainst(gd.getSpec(), nil)
+}
+
+// diffAgainst compares spec against Config.BaselineSpec. narrow, when set,
+// is applied to the baseline too, so operations and schemas hidden from spec
+// aren't reported as removed.
+func (gd *GinDocs) diffAgainst(spec *OpenAPISpec, narrow func(*OpenAPISpec) *OpenAPISpec) (*SpecDiff, error) {
+	if gd.config.BaselineSpec == "" {
+		return nil, fmt.Errorf("gindocs: no baseline spec configured")
+	}
+
+	baseline, err := loadSpecFile(gd.config.BaselineSpec)
+	if err != nil {
+		return nil, err
+	}
+	if narrow != nil {
+		baseline = narrow(baseline)
+	}
+
+	diff := CompareSpecs(baseline, spec, gd.config.VersionPolicy)
+	diff.Baseline = gd.config.BaselineSpec
+	return diff, nil
+}
+
+// CompareSpecs lists the changes from baseline to current and suggests a
+// bump of current's info.version under policy. Fields of policy left at
+// BumpDefault use the default policy.
+func CompareSpecs(baseline, current *OpenAPISpec, policy VersionPolicy) *SpecDiff {
+	changes := DiffSpecs(baseline, current)
+	bump := SuggestBump(changes, policy)
+
+	return &SpecDiff{
+		CurrentVersion:   current.Info.Version,
+		Bump:             bump,
+		SuggestedVersion: bumpVersion(current.Info.Version, bump),
+		Changes:          changes,
+	}
+}
+
+// loadSpecFile reads an OpenAPI JSON document from disk.
+func loadSpecFile(path string) (*OpenAPISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gindocs: reading baseline spec: %w", err)
+	}
+
+	var spec OpenAPISpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("gindocs: parsing baseline spec: %w", err)
+	}
+
+	return &spec, nil
+}
+
+// SuggestBump returns the highest bump the policy assigns to any of the
+// changes. Fields of policy left at BumpDefault use the default policy.
+func SuggestBump(changes []SpecChange, policy VersionPolicy) BumpLevel {
+	policy = policy.withDefaults()
+	bump := BumpNone
+	for _, c := range changes {
+		if level := policy.levelFor(c.Category); level > bump {
+			bump = level
+		}
+	}
+	return bump
+}
+
+// bumpVersion applies a bump to a semver string. Returns "" if the version isn't semver.
+func bumpVersion(version string, bump BumpLevel) string {
+	prefix := ""
+	if strings.HasPrefix(version, "v") {
+		prefix = "v"
+		version = version[1:]
+	}
+
+	// Drop pre-release and build metadata.
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return ""
+		}
+		nums[i] = n
+	}
+
+	switch bump {
+	case BumpMajor:
+		nums[0]++
+		nums[1], nums[2] = 0, 0
+	case BumpMinor:
+		nums[1]++
+		nums[2] = 0
+	case BumpPatch:
+		nums[2]++
+	}
+
+	return fmt.Sprintf("%s%d.%d.%d", prefix, nums[0], nums[1], nums[2])
+}
+
+// DiffSpecs compares two specs and returns the changes from old to updated.
+func DiffSpecs(old, updated *OpenAPISpec) []SpecChange {
+	var changes []SpecChange
+
+	add := func(category ChangeCategory, location, format string, args ...interface{}) {
+		changes = append(changes, SpecChange{
+			Category: category,
+			Location: location,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Operations.
+	for _, path := range unionKeys(old.Paths, updated.Paths) {
+		oldItem, newItem := old.Paths[path], updated.Paths[path]
+		if oldItem == nil {
+			oldItem = &PathItem{}
+		}
+		if newItem == nil {
+			newItem = &PathItem{}
+		}
+
+		for _, method := range httpMethods {
+			oldOp, newOp := oldItem.GetOperation(method), newItem.GetOperation(method)
+			location := method + " " + path
+
+			switch {
+			case oldOp == nil && newOp == nil:
+				continue
+			case newOp == nil:
+				add(ChangeBreaking, location, "operation removed")
+			case oldOp == nil:
+				add(ChangeAdditive, location, "operation added")
+			default:
+				diffOperation(location, oldOp, newOp, add)
+			}
+		}
+	}
+
+	// Component schemas.
+	var oldSchemas, newSchemas map[string]*SchemaObject
+	if old.Components != nil {
+		oldSchemas = old.Components.Schemas
+	}
+	if updated.Components != nil {
+		newSchemas = updated.Components.Schemas
+	}
+	for _, name := range unionKeys(oldSchemas, newSchemas) {
+		oldSchema, newSchema := oldSchemas[name], newSchemas[name]
+		location := "schemas." + name
+
+		switch {
+		case newSchema == nil:
+			add(ChangeBreaking, location, "schema removed")
+		case oldSchema == nil:
+			add(ChangeAdditive, location, "schema added")
+		default:
+			diffSchema(location, oldSchema, newSchema, add)
+		}
+	}
+
+	return changes
+}
+
+// diffOperation compares two versions of the same operation.
+func diffOperation(location string, old, updated *OperationObject, add func(ChangeCategory, string, string, ...interface{})) {
+	if old.Summary != updated.Summary || old.Description != updated.Description {
+		add(ChangeCosmetic, location, "summary or description changed")
+	}
+	if !old.Deprecated && updated.Deprecated {
+		add(ChangeAdditive, location, "operation deprecated")
+	}
+
+	// Parameters are identified by location and name.
+	oldParams := make(map[string]ParameterObject)
+	for _, p := range old.Parameters {
+		oldParams[p.In+":"+p.Name] = p
+	}
+	newParams := make(map[string]ParameterObject)
+	for _, p := range updated.Parameters {
+		newParams[p.In+":"+p.Name] = p
+	}
+	for _, key := range unionKeys(oldParams, newParams) {
+		oldParam, inOld := oldParams[key]
+		newParam, inNew := newParams[key]
+		switch {
+		case !inNew:
+			add(ChangeBreaking, location, "parameter %q removed", key)
+		case !inOld && newParam.Required:
+			add(ChangeBreaking, location, "required parameter %q added", key)
+		case !inOld:
+			add(ChangeAdditive, location, "optional parameter %q added", key)
+		case !oldParam.Required && newParam.Required:
+			add(ChangeBreaking, location, "parameter %q became required", key)
+		}
+	}
+
+	// Request body.
+	switch {
+	case old.RequestBody == nil && updated.RequestBody != nil && updated.RequestBody.Required:
+		add(ChangeBreaking, location, "required request body added")
+	case old.RequestBody == nil && updated.RequestBody != nil:
+		add(ChangeAdditive, location, "optional request body added")
+	case old.RequestBody != nil && updated.RequestBody == nil:
+		add(ChangeBreaking, location, "request body removed")
+	}
+
+	// Responses.
+	for _, code := range unionKeys(old.Responses, updated.Responses) {
+		oldResp, newResp := old.Responses[code], updated.Responses[code]
+		switch {
+		case newResp == nil:
+			add(ChangeBreaking, location, "response %s removed", code)
+		case oldResp == nil:
+			add(ChangeAdditive, location, "response %s added", code)
+		case oldResp.Description != newResp.Description:
+			add(ChangeCosmetic, location, "response %s description changed", code)
+		}
+	}
+}
+
+// diffSchema compares two versions of the same component schema.
+func diffSchema(location string, old, updated *SchemaObject, add func(ChangeCategory, string, string, ...interface{})) {
+	if old.Type != updated.Type || old.Format != updated.Format {
+		add(ChangeBreaking, location, "type changed from %q to %q", old.Type+formatSuffix(old.Format), updated.Type+formatSuffix(updated.Format))
+	}
+	if old.Description != updated.Description {
+		add(ChangeCosmetic, location, "description changed")
+	}
+
+	oldRequired := make(map[string]bool)
+	for _, r := range old.Required {
+		oldRequired[r] = true
+	}
+	for _, r := range updated.Required {
+		if !oldRequired[r] {
+			add(ChangeBreaking, location, "property %q became required", r)
+		}
+	}
+
+	for _, prop := range unionKeys(old.Properties, updated.Properties) {
+		oldProp, newProp := old.Properties[prop], updated.Properties[prop]
+		propLocation := location + "." + prop
+		switch {
+		case newProp == nil:
+			add(ChangeBreaking, propLocation, "property removed")
+		case oldProp == nil:
+			add(ChangeAdditive, propLocation, "property added")
+		case oldProp.Ref != newProp.Ref || oldProp.Type != newProp.Type || oldProp.Format != newProp.Format:
+			add(ChangeBreaking, propLocation, "property type changed")
+		case oldProp.Description != newProp.Description:
+			add(ChangeCosmetic, propLocation, "description changed")
+		}
+	}
+}
+
+// formatSuffix renders a schema format for change messages.
+func formatSuffix(format string) string {
+	if format == "" {
+		return ""
+	}
+	return " (" + format + ")"
+}
+
+// unionKeys returns the sorted union of the keys of two maps.
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for k := range a {
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	for k := range b {
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
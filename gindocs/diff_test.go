@@ -0,0 +1,167 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func diffTestSpec() *OpenAPISpec {
+	return &OpenAPISpec{
+		Paths: map[string]*PathItem{
+			"/users": {
+				Get: &OperationObject{
+					Summary:   "List users",
+					Responses: map[string]*Response{"200": {Description: "OK"}},
+				},
+			},
+		},
+		Components: &ComponentsObject{
+			Schemas: map[string]*SchemaObject{
+				"User": {
+					Type: "object",
+					Properties: map[string]*SchemaObject{
+						"id":   {Type: "integer"},
+						"name": {Type: "string"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDiffSpecs(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(*OpenAPISpec)
+		wantBump BumpLevel
+	}{
+		{"unchanged", func(s *OpenAPISpec) {}, BumpNone},
+		{"summary changed", func(s *OpenAPISpec) {
+			s.Paths["/users"].Get.Summary = "List all users"
+		}, BumpPatch},
+		{"operation added", func(s *OpenAPISpec) {
+			s.Paths["/users"].Post = &OperationObject{Responses: map[string]*Response{}}
+		}, BumpMinor},
+		{"property added", func(s *OpenAPISpec) {
+			s.Components.Schemas["User"].Properties["email"] = &SchemaObject{Type: "string"}
+		}, BumpMinor},
+		{"operation removed", func(s *OpenAPISpec) {
+			delete(s.Paths, "/users")
+		}, BumpMajor},
+		{"property type changed", func(s *OpenAPISpec) {
+			s.Components.Schemas["User"].Properties["id"] = &SchemaObject{Type: "string"}
+		}, BumpMajor},
+		{"required param added", func(s *OpenAPISpec) {
+			s.Paths["/users"].Get.Parameters = []ParameterObject{{Name: "org", In: "query", Required: true}}
+		}, BumpMajor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := diffTestSpec()
+			tt.mutate(updated)
+
+			changes := DiffSpecs(diffTestSpec(), updated)
+			if got := SuggestBump(changes, defaultVersionPolicy()); got != tt.wantBump {
+				t.Errorf("bump = %v, want %v (changes: %+v)", got, tt.wantBump, changes)
+			}
+		})
+	}
+}
+
+func TestSuggestBump_Policy(t *testing.T) {
+	changes := []SpecChange{{Category: ChangeBreaking}}
+	policy := VersionPolicy{Breaking: BumpMinor, Additive: BumpPatch, Cosmetic: BumpNone}
+
+	if got := SuggestBump(changes, policy); got != BumpMinor {
+		t.Errorf("bump = %v, want %v", got, BumpMinor)
+	}
+
+	// An explicit BumpNone is kept, unlike BumpDefault.
+	cosmetic := []SpecChange{{Category: ChangeCosmetic}}
+	if got := SuggestBump(cosmetic, policy); got != BumpNone {
+		t.Errorf("bump = %v, want %v", got, BumpNone)
+	}
+	if got := SuggestBump(cosmetic, VersionPolicy{}); got != BumpPatch {
+		t.Errorf("bump = %v, want the default %v", got, BumpPatch)
+	}
+}
+
+func TestVersionPolicyMerge(t *testing.T) {
+	cfg := mergeConfig(Config{VersionPolicy: VersionPolicy{Additive: BumpNone, Cosmetic: BumpMinor}})
+	want := VersionPolicy{Breaking: BumpMajor, Additive: BumpNone, Cosmetic: BumpMinor}
+	if cfg.VersionPolicy != want {
+		t.Errorf("policy = %+v, want %+v", cfg.VersionPolicy, want)
+	}
+}
+
+func TestBumpVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		bump    BumpLevel
+		want    string
+	}{
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"1.2.3", BumpPatch, "1.2.4"},
+		{"1.2.3", BumpNone, "1.2.3"},
+		{"v0.9.1", BumpMinor, "v0.10.0"},
+		{"1.0.0-beta.1", BumpPatch, "1.0.1"},
+		{"latest", BumpMajor, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+"/"+tt.bump.String(), func(t *testing.T) {
+			if got := bumpVersion(tt.version, tt.bump); got != tt.want {
+				t.Errorf("bumpVersion(%q, %v) = %q, want %q", tt.version, tt.bump, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffEndpointAudience(t *testing.T) {
+	baseline := diffTestSpec()
+	baseline.Paths["/jobs"] = &PathItem{Get: &OperationObject{
+		Audiences: []string{"internal"},
+		Responses: map[string]*Response{"200": {Description: "OK"}},
+	}}
+	data, err := json.Marshal(baseline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	r.GET("/admin", func(c *gin.Context) {})
+	gd := Mount(r, nil, Config{BaselineSpec: path})
+	gd.Route("GET /admin").Audience("internal")
+
+	diff := func(query string) string {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/diff"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: got %d", query, w.Code)
+		}
+		return w.Body.String()
+	}
+
+	// Internal operations, added or removed, stay out of the public diff.
+	if body := diff("?audience=public"); strings.Contains(body, "/admin") || strings.Contains(body, "/jobs") {
+		t.Errorf("expected no internal operations in the public diff, got %s", body)
+	}
+	if body := diff(""); !strings.Contains(body, "GET /admin") || !strings.Contains(body, "GET /jobs") {
+		t.Errorf("expected the internal operations in the full diff, got %s", body)
+	}
+}
@@ -0,0 +1,127 @@
+package gindocs
+
+import (
+	"fmt"
+	"html/template"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleEditor serves the DevMode spec playground.
+func (gd *GinDocs) handleEditor(c *gin.Context) {
+	title := gd.config.Title
+	if title == "" {
+		title = "API Documentation"
+	}
+
+	gd.writeHTML(c, renderEditorHTML(title, gd.docsURL(c)+"/openapi.json"))
+}
+
+// renderEditorHTML renders a page that loads the spec, lets summaries and
+// descriptions be edited, and shows the edited spec and the matching
+// Route overrides live. It uses no external assets.
+func renderEditorHTML(title, specURL string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>%s — Playground</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 0; padding: 24px 32px; color: #1a1a2e; }
+        .panes { display: grid; grid-template-columns: 1fr 1fr; gap: 24px; }
+        .op { border: 1px solid #e2e2ea; border-radius: 6px; padding: 12px; margin-bottom: 12px; }
+        .op.changed { border-color: #49cc90; }
+        .op code { font-weight: 600; }
+        label { display: block; font-size: 12px; color: #666; margin-top: 8px; }
+        input, textarea { width: 100%%; box-sizing: border-box; font: inherit; padding: 6px; border: 1px solid #ccc; border-radius: 4px; }
+        textarea { min-height: 60px; }
+        pre { background: #f5f5fa; padding: 12px; border-radius: 6px; overflow: auto; max-height: 45vh; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <h1>%s — Playground</h1>
+    <p>Edit summaries and descriptions. Paste the generated overrides back into your project to keep the changes.</p>
+    <div class="panes">
+        <div id="ops">Loading…</div>
+        <div>
+            <h2>Overrides</h2>
+            <pre id="snippet">// No changes yet.</pre>
+            <h2>Spec</h2>
+            <pre id="spec"></pre>
+        </div>
+    </div>
+    <script>
+    (function() {
+        var methods = ["get", "post", "put", "patch", "delete", "head", "options"];
+        var spec, original = {};
+
+        function ginPath(path) {
+            return path.replace(/\{([^}]+)\}/g, ":$1");
+        }
+
+        function goString(s) {
+            return JSON.stringify(s);
+        }
+
+        function render() {
+            var lines = [];
+            Object.keys(original).sort().forEach(function(key) {
+                var op = original[key].op, before = original[key];
+                var calls = [];
+                if ((op.summary || "") !== before.summary) calls.push("Summary(" + goString(op.summary || "") + ")");
+                if ((op.description || "") !== before.description) calls.push("Description(" + goString(op.description || "") + ")");
+                document.getElementById("op-" + key).classList.toggle("changed", calls.length > 0);
+                if (calls.length > 0) {
+                    lines.push("docs.Route(" + goString(key) + ").\n    " + calls.join(".\n    "));
+                }
+            });
+            document.getElementById("snippet").textContent = lines.length ? lines.join("\n\n") : "// No changes yet.";
+            document.getElementById("spec").textContent = JSON.stringify(spec, null, 2);
+        }
+
+        function field(label, value, multiline, onInput) {
+            var wrap = document.createElement("label");
+            wrap.textContent = label;
+            var input = document.createElement(multiline ? "textarea" : "input");
+            input.value = value;
+            input.addEventListener("input", function() { onInput(input.value); render(); });
+            wrap.appendChild(input);
+            return wrap;
+        }
+
+        fetch(%q).then(function(r) { return r.json(); }).then(function(data) {
+            spec = data;
+            var container = document.getElementById("ops");
+            container.textContent = "";
+            Object.keys(spec.paths || {}).sort().forEach(function(path) {
+                methods.forEach(function(method) {
+                    var op = spec.paths[path][method];
+                    if (!op) return;
+                    var key = method.toUpperCase() + " " + ginPath(path);
+                    original[key] = { op: op, summary: op.summary || "", description: op.description || "" };
+
+                    var box = document.createElement("div");
+                    box.className = "op";
+                    box.id = "op-" + key;
+                    var heading = document.createElement("code");
+                    heading.textContent = method.toUpperCase() + " " + path;
+                    box.appendChild(heading);
+                    box.appendChild(field("Summary", op.summary || "", false, function(v) { op.summary = v; }));
+                    box.appendChild(field("Description", op.description || "", true, function(v) { op.description = v; }));
+                    container.appendChild(box);
+                });
+            });
+            render();
+        }).catch(function(err) {
+            document.getElementById("ops").textContent = "Failed to load spec: " + err;
+        });
+    })();
+    </script>
+</body>
+</html>`,
+		template.HTMLEscapeString(title),
+		template.HTMLEscapeString(title),
+		specURL,
+	)
+}
@@ -0,0 +1,41 @@
+package gindocs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestEditor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	Mount(r, nil, Config{DevMode: true, Title: "Users <API>"})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/edit", nil))
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "<h1>Users &lt;API&gt; — Playground</h1>") {
+		t.Error("expected the escaped title")
+	}
+	if !strings.Contains(body, `fetch("/docs/openapi.json")`) {
+		t.Error("expected the page to load the spec")
+	}
+	if strings.Contains(body, "cdn.jsdelivr.net") {
+		t.Error("expected no external assets")
+	}
+
+	// The playground is a DevMode feature.
+	r = gin.New()
+	Mount(r, nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/edit", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 outside DevMode, got %d", w.Code)
+	}
+}
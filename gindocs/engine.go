@@ -30,29 +30,84 @@ type GinDocs struct {
 	// routeOverrides holds per-route documentation overrides.
 	routeOverrides map[string]*RouteOverride
 
-	// groupOverrides holds group-level documentation overrides.
-	groupOverrides map[string]*GroupOverride
+	// handlerOverrides holds RouteFor overrides keyed by handler function name.
+	handlerOverrides map[string]*RouteOverride
+
+	// hidden holds "METHOD /path" keys excluded with Hide.
+	hidden map[string]bool
+
+	// manualRoutes holds "METHOD /path" keys documented with AddRoute.
+	manualRoutes map[string]bool
+
+	// groupOverrides holds group-level documentation overrides in the
+	// order they were registered.
+	groupOverrides []*GroupOverride
+
+	// subs holds the sub-documenters scoped to a path prefix.
+	subs []*SubDocs
+
+	// versions holds the named spec documents registered with Version;
+	// versionProblems describes the Version calls that were ignored.
+	versions        []specVersion
+	versionProblems []string
+
+	// fileOverrides holds Config.OverridesFile, loaded at Mount;
+	// overridesErr is set when it couldn't be read.
+	fileOverrides *overridesFile
+	overridesErr  error
+
+	// mergeSources holds the Config.MergeSpecs documents, loaded at Mount.
+	mergeSources []mergeSource
+
+	// downloadedAssets caches the UI assets fetched from the CDN for
+	// static exports, by URL; assetsMu guards it.
+	downloadedAssets map[string]string
+	assetsMu         sync.Mutex
+
+	// modelsMu guards config.Models against AddModels.
+	modelsMu sync.RWMutex
+
+	// disabled is set when Mount registered no docs routes, so Version
+	// doesn't register any either.
+	disabled bool
 
 	// built tracks whether the spec has been generated.
 	built bool
+
+	// warnings holds non-fatal issues found by the last build.
+	warnings []string
 }
 
 // newGinDocs creates a new GinDocs engine with the given configuration.
 func newGinDocs(router *gin.Engine, db *gorm.DB, config Config) *GinDocs {
 	gd := &GinDocs{
-		router:   router,
-		db:       db,
-		config:   config,
-		registry: newTypeRegistry(),
+		router: router,
+		db:     db,
+		config: config,
 	}
+	gd.registry = gd.newRegistry()
 	return gd
 }
 
+// newRegistry creates a TypeRegistry configured from the engine's config.
+func (gd *GinDocs) newRegistry() *TypeRegistry {
+	registry := newTypeRegistry()
+	registry.disableNullable = gd.config.DisableNullable
+	registry.preferValidate = gd.config.PreferValidateTag
+	registry.typeSchemas = gd.config.TypeSchemas
+	registry.schemaHook = gd.config.SchemaHook
+	registry.naming = gd.config.SchemaNaming
+	registry.maxDepth = gd.config.MaxSchemaDepth
+	registry.cyclePolicy = gd.config.CyclePolicy
+	registry.modelComments = gd.config.ModelComments
+	registry.sourceRoot = gd.config.SourceRoot
+	return registry
+}
+
 // getSpec returns the current OpenAPI spec, building it if necessary.
 func (gd *GinDocs) getSpec() *OpenAPISpec {
 	if gd.config.DevMode {
-		gd.buildSpec()
-		return gd.spec
+		return gd.buildSpec()
 	}
 
 	gd.specMu.RLock()
@@ -62,20 +117,55 @@ func (gd *GinDocs) getSpec() *OpenAPISpec {
 	}
 	gd.specMu.RUnlock()
 
-	gd.buildSpec()
-	return gd.spec
+	return gd.buildSpec()
+}
+
+// buildSpec generates the OpenAPI specification from the router and models
+// and returns the spec it built. It panics with a *StrictError when the spec
+// violates Config.Strict.
+func (gd *GinDocs) buildSpec() *OpenAPISpec {
+	spec, err := gd.checkedBuild()
+	if err != nil {
+		panic(err)
+	}
+	return spec
 }
 
-// buildSpec generates the OpenAPI specification from the router and models.
-func (gd *GinDocs) buildSpec() {
+// checkedBuild generates the spec and checks it against Config.Strict. A
+// violating spec is not cached, so every build fails until it is fixed.
+func (gd *GinDocs) checkedBuild() (*OpenAPISpec, error) {
 	gd.specMu.Lock()
 	defer gd.specMu.Unlock()
 
 	// Reset registry for fresh build.
-	gd.registry = newTypeRegistry()
+	gd.registry = gd.newRegistry()
+
+	spec := gd.assembleSpec()
+	if policy := gd.config.Strict; policy != nil {
+		if err := strictCheck(policy, spec); err != nil {
+			gd.built = false
+			return nil, err
+		}
+	}
 
-	gd.spec = gd.assembleSpec()
+	gd.spec = spec
 	gd.built = true
+	return spec, nil
+}
+
+// Rebuild regenerates the spec right away from the router's current routes
+// and models. Call it after registering routes at runtime, e.g. from plugins
+// or feature flags, to refresh the docs without DevMode's per-request cost.
+func (gd *GinDocs) Rebuild() {
+	gd.buildSpec()
+}
+
+// InvalidateCache marks the spec as stale, so it is rebuilt on the next
+// request. Unlike Rebuild, it defers the work until the docs are read.
+func (gd *GinDocs) InvalidateCache() {
+	gd.specMu.Lock()
+	gd.built = false
+	gd.specMu.Unlock()
 }
 
 // generateSummary creates a human-readable summary from method and path.
@@ -0,0 +1,77 @@
+package gindocs
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	// enumsMu guards enums.
+	enumsMu sync.RWMutex
+	// enums holds the allowed values of types registered with RegisterEnum.
+	enums = make(map[reflect.Type][]interface{})
+)
+
+// RegisterEnum documents the allowed values of a named string or integer type.
+// Every field of that type then references one shared enum component schema
+// instead of repeating oneof tags:
+//
+//	type Status string
+//
+//	const (
+//		StatusDraft     Status = "draft"
+//		StatusPublished Status = "published"
+//	)
+//
+//	func init() {
+//		gindocs.RegisterEnum(StatusDraft, StatusPublished)
+//	}
+func RegisterEnum[T any](values ...T) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	plain := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		plain = append(plain, enumValue(reflect.ValueOf(v)))
+	}
+
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	enums[t] = plain
+}
+
+// enumValue converts a value of a named type to its underlying basic value.
+func enumValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	return v.Interface()
+}
+
+// enumSchema registers the enum component for a type registered with
+// RegisterEnum and returns a $ref to it, or nil if the type isn't an enum.
+func enumSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
+	enumsMu.RLock()
+	values, ok := enums[t]
+	enumsMu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	name := registry.nameFor(t)
+	if !registry.Has(name) {
+		schema := kindToSchema(t, registry)
+		schema.Enum = values
+		registry.Register(name, schema)
+	}
+
+	return SchemaRef(name)
+}
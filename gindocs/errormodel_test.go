@@ -0,0 +1,44 @@
+package gindocs
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TestAPIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func TestErrorModel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users/:id", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{ErrorModel: TestAPIError{}})
+	op := gd.Spec().Paths["/users/{id}"].Get
+
+	var errors int
+	for code, resp := range op.Responses {
+		if code < "400" {
+			if resp.Content != nil {
+				t.Errorf("%s: expected no error body on a success response, got %+v", code, resp.Content)
+			}
+			continue
+		}
+		errors++
+		if media := resp.Content["application/json"]; media.Schema == nil || media.Schema.Ref != RefPath("TestAPIError") {
+			t.Errorf("%s: expected the error model, got %+v", code, resp.Content)
+		}
+	}
+	if errors == 0 {
+		t.Fatalf("expected inferred error responses, got %v", sortedKeys(op.Responses))
+	}
+
+	r = gin.New()
+	r.GET("/users/:id", func(c *gin.Context) {})
+	if resp := Mount(r, nil).Spec().Paths["/users/{id}"].Get.Responses["404"]; resp == nil || resp.Content != nil {
+		t.Errorf("expected a bodiless 404 without an ErrorModel, got %+v", resp)
+	}
+}
@@ -1,15 +1,19 @@
 package gindocs
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
 // PostmanCollection represents a Postman v2.1 collection.
 type PostmanCollection struct {
-	Info PostmanInfo   `json:"info"`
-	Item []PostmanItem `json:"item"`
+	Info     PostmanInfo       `json:"info"`
+	Item     []PostmanItem     `json:"item"`
+	Auth     *PostmanAuth      `json:"auth,omitempty"`
+	Variable []PostmanVariable `json:"variable,omitempty"`
 }
 
 // PostmanInfo holds collection metadata.
@@ -21,9 +25,9 @@ type PostmanInfo struct {
 
 // PostmanItem represents a folder or request in a Postman collection.
 type PostmanItem struct {
-	Name    string            `json:"name"`
-	Item    []PostmanItem     `json:"item,omitempty"`
-	Request *PostmanRequest   `json:"request,omitempty"`
+	Name    string          `json:"name"`
+	Item    []PostmanItem   `json:"item,omitempty"`
+	Request *PostmanRequest `json:"request,omitempty"`
 }
 
 // PostmanRequest represents a Postman request.
@@ -32,21 +36,35 @@ type PostmanRequest struct {
 	Header      []PostmanHeader `json:"header,omitempty"`
 	Body        *PostmanBody    `json:"body,omitempty"`
 	URL         PostmanURL      `json:"url"`
+	Auth        *PostmanAuth    `json:"auth,omitempty"`
 	Description string          `json:"description,omitempty"`
 }
 
 // PostmanHeader represents a request header.
 type PostmanHeader struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
-	Type  string `json:"type"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
 }
 
 // PostmanBody represents a request body.
 type PostmanBody struct {
-	Mode string `json:"mode"`
-	Raw  string `json:"raw,omitempty"`
-	Options *PostmanBodyOptions `json:"options,omitempty"`
+	Mode       string              `json:"mode"`
+	Raw        string              `json:"raw,omitempty"`
+	URLEncoded []PostmanFormParam  `json:"urlencoded,omitempty"`
+	FormData   []PostmanFormParam  `json:"formdata,omitempty"`
+	Options    *PostmanBodyOptions `json:"options,omitempty"`
+}
+
+// PostmanFormParam is a field of a urlencoded or form-data body.
+type PostmanFormParam struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
 }
 
 // PostmanBodyOptions holds body format options.
@@ -61,14 +79,109 @@ type PostmanRawOptions struct {
 
 // PostmanURL represents a Postman URL.
 type PostmanURL struct {
-	Raw      string   `json:"raw"`
-	Protocol string   `json:"protocol,omitempty"`
-	Host     []string `json:"host,omitempty"`
-	Path     []string `json:"path,omitempty"`
+	Raw      string              `json:"raw"`
+	Protocol string              `json:"protocol,omitempty"`
+	Host     []string            `json:"host,omitempty"`
+	Path     []string            `json:"path,omitempty"`
+	Query    []PostmanQueryParam `json:"query,omitempty"`
+	Variable []PostmanVariable   `json:"variable,omitempty"`
+}
+
+// PostmanQueryParam represents a query parameter. Optional parameters are
+// included but disabled.
+type PostmanQueryParam struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// PostmanVariable represents a collection or path variable.
+type PostmanVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PostmanAuth represents collection or request authentication. Type is
+// "bearer", "basic", "apikey" or "noauth"; the matching field holds its
+// settings.
+type PostmanAuth struct {
+	Type   string             `json:"type"`
+	Bearer []PostmanAuthParam `json:"bearer,omitempty"`
+	Basic  []PostmanAuthParam `json:"basic,omitempty"`
+	APIKey []PostmanAuthParam `json:"apikey,omitempty"`
+}
+
+// PostmanAuthParam is a setting of a PostmanAuth.
+type PostmanAuthParam struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+// postmanAuth returns the Postman authentication for a credential, with its
+// secrets taken from collection variables.
+func postmanAuth(cred credential) *PostmanAuth {
+	param := func(key, value string) PostmanAuthParam {
+		return PostmanAuthParam{Key: key, Value: value, Type: "string"}
+	}
+	switch cred.Var {
+	case "token":
+		return &PostmanAuth{Type: "bearer", Bearer: []PostmanAuthParam{param("token", "{{token}}")}}
+	case "basicAuth":
+		return &PostmanAuth{Type: "basic", Basic: []PostmanAuthParam{
+			param("username", "{{username}}"), param("password", "{{password}}"),
+		}}
+	default:
+		return &PostmanAuth{Type: "apikey", APIKey: []PostmanAuthParam{
+			param("key", cred.Name), param("value", "{{apiKey}}"), param("in", cred.In),
+		}}
+	}
+}
+
+// authVariables returns the collection variables holding a credential's
+// secrets.
+func authVariables(cred credential) []PostmanVariable {
+	switch cred.Var {
+	case "basicAuth":
+		return []PostmanVariable{
+			{Key: "username", Value: "", Type: "string"},
+			{Key: "password", Value: "", Type: "secret"},
+		}
+	default:
+		return []PostmanVariable{{Key: cred.Var, Value: "", Type: "secret"}}
+	}
+}
+
+// exampleString returns a schema's example value as parameter text.
+func exampleString(schema *SchemaObject, schemas map[string]*SchemaObject, name string) string {
+	v := sampleValue(schema, schemas, name, map[string]bool{})
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// exampleJSON returns an indented example JSON document for a schema.
+func exampleJSON(schema *SchemaObject, schemas map[string]*SchemaObject) string {
+	data, err := json.MarshalIndent(sampleValue(schema, schemas, "", map[string]bool{}), "", "  ")
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
 }
 
 // generatePostmanCollection creates a Postman v2.1 collection from the spec.
-func generatePostmanCollection(spec *OpenAPISpec) *PostmanCollection {
+// Requests use a {{baseUrl}} variable, and the collection authenticates with
+// the preferred security scheme (Config.Auth's), or else the first one, with
+// its secrets in collection variables.
+func generatePostmanCollection(spec *OpenAPISpec, preferred string) *PostmanCollection {
 	collection := &PostmanCollection{
 		Info: PostmanInfo{
 			Name:        spec.Info.Title,
@@ -77,39 +190,75 @@ func generatePostmanCollection(spec *OpenAPISpec) *PostmanCollection {
 		},
 	}
 
+	var schemas map[string]*SchemaObject
+	var schemes map[string]*SecuritySchemeObject
+	if spec.Components != nil {
+		schemas = spec.Components.Schemas
+		schemes = spec.Components.SecuritySchemes
+	}
+
 	// Determine base URL.
 	baseURL := "http://localhost:8080"
 	if len(spec.Servers) > 0 {
-		baseURL = spec.Servers[0].URL
+		baseURL = spec.Servers[0].ResolvedURL()
+	}
+	collection.Variable = append(collection.Variable, PostmanVariable{Key: "baseUrl", Value: baseURL, Type: "string"})
+
+	// The collection authenticates with the preferred scheme, or the first
+	// one; requests using another scheme, or none, override it.
+	names := sortedKeys(schemes)
+	if _, ok := schemes[preferred]; ok {
+		names = append([]string{preferred}, names...)
+	}
+	var collectionCred *credential
+	for _, name := range names {
+		if creds := securityCredentials([]SecurityRequirement{{name: {}}}, schemes); len(creds) > 0 {
+			collectionCred = &creds[0]
+			collection.Auth = postmanAuth(creds[0])
+			collection.Variable = append(collection.Variable, authVariables(creds[0])...)
+			break
+		}
+	}
+	declared := map[string]bool{}
+	for _, v := range collection.Variable {
+		declared[v.Key] = true
 	}
 
 	// Group requests by tag.
 	tagFolders := make(map[string]*PostmanItem)
 	var ungrouped []PostmanItem
 
-	for path, pathItem := range spec.Paths {
-		operations := []struct {
-			method string
-			op     *OperationObject
-		}{
-			{"GET", pathItem.Get},
-			{"POST", pathItem.Post},
-			{"PUT", pathItem.Put},
-			{"PATCH", pathItem.Patch},
-			{"DELETE", pathItem.Delete},
-			{"HEAD", pathItem.Head},
-			{"OPTIONS", pathItem.Options},
-		}
-
-		for _, entry := range operations {
-			if entry.op == nil {
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
 				continue
 			}
 
-			item := createPostmanItem(entry.method, path, baseURL, entry.op)
+			item := createPostmanItem(method, path, op, schemas)
+
+			security := op.Security
+			if security == nil {
+				security = spec.Security
+			}
+			creds := securityCredentials(security, schemes)
+			switch {
+			case len(creds) == 0:
+				if collectionCred != nil {
+					item.Request.Auth = &PostmanAuth{Type: "noauth"}
+				}
+			case collectionCred == nil || creds[0] != *collectionCred:
+				item.Request.Auth = postmanAuth(creds[0])
+				for _, v := range authVariables(creds[0]) {
+					if !declared[v.Key] {
+						declared[v.Key] = true
+						collection.Variable = append(collection.Variable, v)
+					}
+				}
+			}
 
-			if len(entry.op.Tags) > 0 {
-				tag := entry.op.Tags[0]
+			if len(op.Tags) > 0 {
+				tag := op.Tags[0]
 				folder, ok := tagFolders[tag]
 				if !ok {
 					folder = &PostmanItem{Name: tag}
@@ -123,16 +272,17 @@ func generatePostmanCollection(spec *OpenAPISpec) *PostmanCollection {
 	}
 
 	// Add folders to collection.
-	for _, folder := range tagFolders {
-		collection.Item = append(collection.Item, *folder)
+	for _, tag := range sortedKeys(tagFolders) {
+		collection.Item = append(collection.Item, *tagFolders[tag])
 	}
 	collection.Item = append(collection.Item, ungrouped...)
 
 	return collection
 }
 
-// createPostmanItem creates a Postman request item from an operation.
-func createPostmanItem(method, path, baseURL string, op *OperationObject) PostmanItem {
+// createPostmanItem creates a Postman request item from an operation, with
+// its path variables, query parameters, headers and an example body.
+func createPostmanItem(method, path string, op *OperationObject, schemas map[string]*SchemaObject) PostmanItem {
 	// Convert OpenAPI path params to Postman format.
 	postmanPath := path
 	postmanPath = strings.ReplaceAll(postmanPath, "{", ":")
@@ -143,58 +293,194 @@ func createPostmanItem(method, path, baseURL string, op *OperationObject) Postma
 		name = method + " " + path
 	}
 
-	rawURL := baseURL + postmanPath
-	pathSegments := strings.Split(strings.TrimPrefix(postmanPath, "/"), "/")
-
-	item := PostmanItem{
-		Name: name,
-		Request: &PostmanRequest{
-			Method:      method,
-			Description: op.Description,
-			URL: PostmanURL{
-				Raw:  rawURL,
-				Path: pathSegments,
-			},
-			Header: []PostmanHeader{
-				{Key: "Content-Type", Value: "application/json", Type: "text"},
-				{Key: "Accept", Value: "application/json", Type: "text"},
-			},
+	request := &PostmanRequest{
+		Method:      method,
+		Description: op.Description,
+		URL: PostmanURL{
+			Host: []string{"{{baseUrl}}"},
+			Path: strings.Split(strings.TrimPrefix(postmanPath, "/"), "/"),
+		},
+		Header: []PostmanHeader{
+			{Key: "Accept", Value: "application/json", Type: "text"},
 		},
 	}
 
-	// Add request body for appropriate methods.
-	if op.RequestBody != nil && (method == "POST" || method == "PUT" || method == "PATCH") {
-		item.Request.Body = &PostmanBody{
+	var query []string
+	for _, param := range op.Parameters {
+		value := exampleString(param.Schema, schemas, param.Name)
+		switch param.In {
+		case "path":
+			request.URL.Variable = append(request.URL.Variable, PostmanVariable{
+				Key: param.Name, Value: value, Description: param.Description,
+			})
+		case "query":
+			request.URL.Query = append(request.URL.Query, PostmanQueryParam{
+				Key: param.Name, Value: value, Description: param.Description, Disabled: !param.Required,
+			})
+			if param.Required {
+				query = append(query, param.Name+"="+value)
+			}
+		case "header":
+			request.Header = append(request.Header, PostmanHeader{
+				Key: param.Name, Value: value, Type: "text", Description: param.Description, Disabled: !param.Required,
+			})
+		}
+	}
+	request.URL.Raw = "{{baseUrl}}" + postmanPath
+	if len(query) > 0 {
+		request.URL.Raw += "?" + strings.Join(query, "&")
+	}
+
+	if op.RequestBody != nil {
+		request.Body = postmanBody(op.RequestBody, schemas)
+		if request.Body != nil {
+			contentType := "application/json"
+			switch request.Body.Mode {
+			case "urlencoded":
+				contentType = "application/x-www-form-urlencoded"
+			case "formdata":
+				contentType = "multipart/form-data"
+			}
+			request.Header = append(request.Header, PostmanHeader{Key: "Content-Type", Value: contentType, Type: "text"})
+		}
+	}
+
+	return PostmanItem{Name: name, Request: request}
+}
+
+// postmanBody returns an example body for a request body: raw JSON, or form
+// fields for form content.
+func postmanBody(body *RequestBodyObject, schemas map[string]*SchemaObject) *PostmanBody {
+	if media, ok := body.Content["application/json"]; ok {
+		return &PostmanBody{
 			Mode: "raw",
-			Raw:  "{}",
+			Raw:  exampleJSON(media.Schema, schemas),
 			Options: &PostmanBodyOptions{
 				Raw: PostmanRawOptions{Language: "json"},
 			},
 		}
 	}
 
-	return item
+	for _, mode := range []struct{ mediaType, name string }{
+		{"application/x-www-form-urlencoded", "urlencoded"},
+		{"multipart/form-data", "formdata"},
+	} {
+		media, ok := body.Content[mode.mediaType]
+		if !ok {
+			continue
+		}
+		schema := media.Schema
+		if schema != nil && schema.Ref != "" {
+			schema = schemas[strings.TrimPrefix(schema.Ref, RefPath(""))]
+		}
+		var fields []PostmanFormParam
+		if schema != nil {
+			required := make(map[string]bool, len(schema.Required))
+			for _, name := range schema.Required {
+				required[name] = true
+			}
+			for _, name := range sortedKeys(schema.Properties) {
+				prop := schema.Properties[name]
+				field := PostmanFormParam{Key: name, Type: "text", Disabled: !required[name]}
+				if prop != nil {
+					field.Description = prop.Description
+				}
+				if prop != nil && prop.Format == "binary" {
+					field.Type = "file"
+				} else {
+					field.Value = exampleString(prop, schemas, name)
+				}
+				fields = append(fields, field)
+			}
+		}
+		result := &PostmanBody{Mode: mode.name}
+		if mode.name == "urlencoded" {
+			result.URLEncoded = fields
+		} else {
+			result.FormData = fields
+		}
+		return result
+	}
+	return nil
+}
+
+// PostmanEnvironment represents a Postman environment.
+type PostmanEnvironment struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Values []PostmanEnvValue `json:"values"`
+	Scope  string            `json:"_postman_variable_scope"`
+}
+
+// PostmanEnvValue is a variable of a Postman environment.
+type PostmanEnvValue struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// generatePostmanEnvironment creates a Postman environment for the server at
+// index, declaring baseUrl and the credential variables of the collection.
+// ok is false when there is no such server.
+func generatePostmanEnvironment(spec *OpenAPISpec, index int, preferred string) (env *PostmanEnvironment, ok bool) {
+	name := "localhost"
+	baseURL := "http://localhost:8080"
+	if len(spec.Servers) > 0 {
+		if index < 0 || index >= len(spec.Servers) {
+			return nil, false
+		}
+		server := spec.Servers[index]
+		baseURL = server.ResolvedURL()
+		name = server.Description
+		if name == "" {
+			name = baseURL
+		}
+	} else if index != 0 {
+		return nil, false
+	}
+
+	env = &PostmanEnvironment{
+		ID:    fmt.Sprintf("gindocs-env-%d", index),
+		Name:  spec.Info.Title + " - " + name,
+		Scope: "environment",
+	}
+	for _, v := range generatePostmanCollection(spec, preferred).Variable {
+		value := v.Value
+		if v.Key == "baseUrl" {
+			value = baseURL
+		}
+		typ := v.Type
+		if typ == "string" || typ == "" {
+			typ = "default"
+		}
+		env.Values = append(env.Values, PostmanEnvValue{Key: v.Key, Value: value, Type: typ, Enabled: true})
+	}
+	return env, true
 }
 
 // InsomniaExport represents an Insomnia v4 export.
 type InsomniaExport struct {
-	Type      string           `json:"_type"`
-	Format    int              `json:"__export_format"`
-	Source    string           `json:"__export_source"`
+	Type      string             `json:"_type"`
+	Format    int                `json:"__export_format"`
+	Source    string             `json:"__export_source"`
 	Resources []InsomniaResource `json:"resources"`
 }
 
 // InsomniaResource represents a resource in an Insomnia export.
 type InsomniaResource struct {
-	ID          string      `json:"_id"`
-	Type        string      `json:"_type"`
-	ParentID    string      `json:"parentId,omitempty"`
-	Name        string      `json:"name"`
-	Description string      `json:"description,omitempty"`
-	URL         string      `json:"url,omitempty"`
-	Method      string      `json:"method,omitempty"`
-	Body        interface{} `json:"body,omitempty"`
-	Headers     []InsomniaHeader `json:"headers,omitempty"`
+	ID             string              `json:"_id"`
+	Type           string              `json:"_type"`
+	ParentID       string              `json:"parentId,omitempty"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description,omitempty"`
+	URL            string              `json:"url,omitempty"`
+	Method         string              `json:"method,omitempty"`
+	Body           interface{}         `json:"body,omitempty"`
+	Parameters     []InsomniaParameter `json:"parameters,omitempty"`
+	Headers        []InsomniaHeader    `json:"headers,omitempty"`
+	Authentication *InsomniaAuth       `json:"authentication,omitempty"`
+	Data           map[string]string   `json:"data,omitempty"`
 }
 
 // InsomniaHeader represents a header in an Insomnia request.
@@ -203,7 +489,58 @@ type InsomniaHeader struct {
 	Value string `json:"value"`
 }
 
-// generateInsomniaExport creates an Insomnia v4 export from the spec.
+// InsomniaParameter represents a query parameter or form field. Optional
+// ones are included but disabled.
+type InsomniaParameter struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// InsomniaBody represents a request body: raw text, or form params.
+type InsomniaBody struct {
+	MimeType string              `json:"mimeType"`
+	Text     string              `json:"text,omitempty"`
+	Params   []InsomniaParameter `json:"params,omitempty"`
+}
+
+// InsomniaAuth represents request authentication. Type is "bearer",
+// "basic" or "apikey"; the other fields are set as that type needs.
+type InsomniaAuth struct {
+	Type     string `json:"type"`
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Value    string `json:"value,omitempty"`
+	AddTo    string `json:"addTo,omitempty"`
+}
+
+// insomniaAuth returns the Insomnia authentication for a credential, with
+// its secrets taken from environment variables, and those variables.
+func insomniaAuth(cred credential) (*InsomniaAuth, []string) {
+	switch cred.Var {
+	case "token":
+		return &InsomniaAuth{Type: "bearer", Token: "{{ _.token }}"}, []string{"token"}
+	case "basicAuth":
+		return &InsomniaAuth{Type: "basic", Username: "{{ _.username }}", Password: "{{ _.password }}"}, []string{"username", "password"}
+	default:
+		addTo := "header"
+		switch cred.In {
+		case "query":
+			addTo = "queryParams"
+		case "cookie":
+			addTo = "cookie"
+		}
+		return &InsomniaAuth{Type: "apikey", Key: cred.Name, Value: "{{ _.api_key }}", AddTo: addTo}, []string{"api_key"}
+	}
+}
+
+// generateInsomniaExport creates an Insomnia v4 export from the spec. A base
+// environment holds base_url, credentials and path parameters, with a
+// sub-environment per server when there are several.
 func generateInsomniaExport(spec *OpenAPISpec) *InsomniaExport {
 	export := &InsomniaExport{
 		Type:   "export",
@@ -211,9 +548,16 @@ func generateInsomniaExport(spec *OpenAPISpec) *InsomniaExport {
 		Source: "gindocs",
 	}
 
+	var schemas map[string]*SchemaObject
+	var schemes map[string]*SecuritySchemeObject
+	if spec.Components != nil {
+		schemas = spec.Components.Schemas
+		schemes = spec.Components.SecuritySchemes
+	}
+
 	baseURL := "http://localhost:8080"
 	if len(spec.Servers) > 0 {
-		baseURL = spec.Servers[0].URL
+		baseURL = spec.Servers[0].ResolvedURL()
 	}
 
 	// Add workspace.
@@ -225,6 +569,31 @@ func generateInsomniaExport(spec *OpenAPISpec) *InsomniaExport {
 		Description: spec.Info.Description,
 	})
 
+	// Add environments; the base environment's data is filled in below.
+	envData := map[string]string{"base_url": baseURL}
+	export.Resources = append(export.Resources, InsomniaResource{
+		ID:       "env_gindocs",
+		Type:     "environment",
+		ParentID: workspaceID,
+		Name:     "Base Environment",
+		Data:     envData,
+	})
+	if len(spec.Servers) > 1 {
+		for i, server := range spec.Servers {
+			name := server.Description
+			if name == "" {
+				name = server.ResolvedURL()
+			}
+			export.Resources = append(export.Resources, InsomniaResource{
+				ID:       fmt.Sprintf("env_gindocs_%d", i),
+				Type:     "environment",
+				ParentID: "env_gindocs",
+				Name:     name,
+				Data:     map[string]string{"base_url": server.ResolvedURL()},
+			})
+		}
+	}
+
 	// Add folders for each tag.
 	tagFolderIDs := make(map[string]string)
 	for _, tag := range spec.Tags {
@@ -240,20 +609,10 @@ func generateInsomniaExport(spec *OpenAPISpec) *InsomniaExport {
 
 	// Add requests.
 	requestIdx := 0
-	for path, pathItem := range spec.Paths {
-		operations := []struct {
-			method string
-			op     *OperationObject
-		}{
-			{"GET", pathItem.Get},
-			{"POST", pathItem.Post},
-			{"PUT", pathItem.Put},
-			{"PATCH", pathItem.Patch},
-			{"DELETE", pathItem.Delete},
-		}
-
-		for _, entry := range operations {
-			if entry.op == nil {
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
 				continue
 			}
 
@@ -261,39 +620,69 @@ func generateInsomniaExport(spec *OpenAPISpec) *InsomniaExport {
 			reqID := fmt.Sprintf("req_%d", requestIdx)
 
 			parentID := workspaceID
-			if len(entry.op.Tags) > 0 {
-				if fid, ok := tagFolderIDs[entry.op.Tags[0]]; ok {
+			if len(op.Tags) > 0 {
+				if fid, ok := tagFolderIDs[op.Tags[0]]; ok {
 					parentID = fid
 				}
 			}
 
-			// Convert OpenAPI path params to Insomnia format.
-			insomniaPath := path
-			insomniaPath = strings.ReplaceAll(insomniaPath, "{", "{{ ")
-			insomniaPath = strings.ReplaceAll(insomniaPath, "}", " }}")
-
-			name := entry.op.Summary
+			name := op.Summary
 			if name == "" {
-				name = entry.method + " " + path
+				name = method + " " + path
 			}
 
 			resource := InsomniaResource{
-				ID:       reqID,
-				Type:     "request",
-				ParentID: parentID,
-				Name:     name,
-				URL:      baseURL + insomniaPath,
-				Method:   entry.method,
+				ID:          reqID,
+				Type:        "request",
+				ParentID:    parentID,
+				Name:        name,
+				Description: op.Description,
+				Method:      method,
 				Headers: []InsomniaHeader{
-					{Name: "Content-Type", Value: "application/json"},
 					{Name: "Accept", Value: "application/json"},
 				},
 			}
 
-			if entry.op.RequestBody != nil {
-				resource.Body = map[string]interface{}{
-					"mimeType": "application/json",
-					"text":     "{}",
+			// Path parameters become environment variables.
+			insomniaPath := path
+			for _, param := range op.Parameters {
+				value := exampleString(param.Schema, schemas, param.Name)
+				switch param.In {
+				case "path":
+					insomniaPath = strings.ReplaceAll(insomniaPath, "{"+param.Name+"}", "{{ _."+param.Name+" }}")
+					if _, ok := envData[param.Name]; !ok {
+						envData[param.Name] = value
+					}
+				case "query":
+					resource.Parameters = append(resource.Parameters, InsomniaParameter{
+						Name: param.Name, Value: value, Description: param.Description, Disabled: !param.Required,
+					})
+				case "header":
+					if param.Required {
+						resource.Headers = append(resource.Headers, InsomniaHeader{Name: param.Name, Value: value})
+					}
+				}
+			}
+			resource.URL = "{{ _.base_url }}" + insomniaPath
+
+			security := op.Security
+			if security == nil {
+				security = spec.Security
+			}
+			if creds := securityCredentials(security, schemes); len(creds) > 0 {
+				auth, vars := insomniaAuth(creds[0])
+				resource.Authentication = auth
+				for _, v := range vars {
+					if _, ok := envData[v]; !ok {
+						envData[v] = ""
+					}
+				}
+			}
+
+			if op.RequestBody != nil {
+				if body := insomniaBody(op.RequestBody, schemas); body != nil {
+					resource.Body = body
+					resource.Headers = append(resource.Headers, InsomniaHeader{Name: "Content-Type", Value: body.MimeType})
 				}
 			}
 
@@ -304,16 +693,139 @@ func generateInsomniaExport(spec *OpenAPISpec) *InsomniaExport {
 	return export
 }
 
+// insomniaBody returns an example body for a request body: JSON text, or
+// form params for form content.
+func insomniaBody(body *RequestBodyObject, schemas map[string]*SchemaObject) *InsomniaBody {
+	postman := postmanBody(body, schemas)
+	if postman == nil {
+		return nil
+	}
+	switch postman.Mode {
+	case "raw":
+		return &InsomniaBody{MimeType: "application/json", Text: postman.Raw}
+	case "urlencoded":
+		return &InsomniaBody{MimeType: "application/x-www-form-urlencoded", Params: insomniaParams(postman.URLEncoded)}
+	default:
+		return &InsomniaBody{MimeType: "multipart/form-data", Params: insomniaParams(postman.FormData)}
+	}
+}
+
+// insomniaParams converts form fields to Insomnia params.
+func insomniaParams(fields []PostmanFormParam) []InsomniaParameter {
+	params := make([]InsomniaParameter, 0, len(fields))
+	for _, f := range fields {
+		param := InsomniaParameter{Name: f.Key, Value: f.Value, Description: f.Description, Disabled: f.Disabled}
+		if f.Type == "file" {
+			param.Type = "file"
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// InsomniaV5 represents an Insomnia v5 collection (insomnia.yaml).
+type InsomniaV5 struct {
+	Type         string           `json:"type"`
+	Name         string           `json:"name"`
+	Meta         InsomniaV5Meta   `json:"meta"`
+	Collection   []InsomniaV5Item `json:"collection"`
+	Environments *InsomniaV5Env   `json:"environments,omitempty"`
+}
+
+// InsomniaV5Meta holds the ID and description of a v5 item.
+type InsomniaV5Meta struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+}
+
+// InsomniaV5Item is a folder, with children, or a request.
+type InsomniaV5Item struct {
+	URL            string              `json:"url,omitempty"`
+	Name           string              `json:"name"`
+	Meta           InsomniaV5Meta      `json:"meta"`
+	Method         string              `json:"method,omitempty"`
+	Body           interface{}         `json:"body,omitempty"`
+	Parameters     []InsomniaParameter `json:"parameters,omitempty"`
+	Headers        []InsomniaHeader    `json:"headers,omitempty"`
+	Authentication *InsomniaAuth       `json:"authentication,omitempty"`
+	Children       []InsomniaV5Item    `json:"children,omitempty"`
+}
+
+// InsomniaV5Env is an environment with its sub-environments.
+type InsomniaV5Env struct {
+	Name            string            `json:"name"`
+	Meta            InsomniaV5Meta    `json:"meta"`
+	Data            map[string]string `json:"data,omitempty"`
+	SubEnvironments []InsomniaV5Env   `json:"subEnvironments,omitempty"`
+}
+
+// generateInsomniaV5 creates an Insomnia v5 collection from the spec, with
+// the same requests and environments as the v4 export.
+func generateInsomniaV5(spec *OpenAPISpec) *InsomniaV5 {
+	resources := generateInsomniaExport(spec).Resources
+	children := make(map[string][]InsomniaResource)
+	for _, r := range resources {
+		children[r.ParentID] = append(children[r.ParentID], r)
+	}
+
+	var items func(parentID string) []InsomniaV5Item
+	items = func(parentID string) []InsomniaV5Item {
+		var result []InsomniaV5Item
+		for _, r := range children[parentID] {
+			if r.Type != "request" && r.Type != "request_group" {
+				continue
+			}
+			result = append(result, InsomniaV5Item{
+				URL:            r.URL,
+				Name:           r.Name,
+				Meta:           InsomniaV5Meta{ID: r.ID, Description: r.Description},
+				Method:         r.Method,
+				Body:           r.Body,
+				Parameters:     r.Parameters,
+				Headers:        r.Headers,
+				Authentication: r.Authentication,
+				Children:       items(r.ID),
+			})
+		}
+		return result
+	}
+
+	workspace := resources[0]
+	v5 := &InsomniaV5{
+		Type:       "collection.insomnia.rest/5.0",
+		Name:       workspace.Name,
+		Meta:       InsomniaV5Meta{ID: workspace.ID, Description: workspace.Description},
+		Collection: items(workspace.ID),
+	}
+	for _, env := range children[workspace.ID] {
+		if env.Type != "environment" {
+			continue
+		}
+		v5.Environments = &InsomniaV5Env{Name: env.Name, Meta: InsomniaV5Meta{ID: env.ID}, Data: env.Data}
+		for _, sub := range children[env.ID] {
+			v5.Environments.SubEnvironments = append(v5.Environments.SubEnvironments,
+				InsomniaV5Env{Name: sub.Name, Meta: InsomniaV5Meta{ID: sub.ID}, Data: sub.Data})
+		}
+	}
+	return v5
+}
+
 // specToYAML converts an OpenAPI spec to a basic YAML representation.
 // Uses a simple JSON-to-YAML converter to avoid external dependencies.
+// Keys keep their JSON order, so the output is stable between builds.
 func specToYAML(spec *OpenAPISpec) ([]byte, error) {
-	data, err := json.Marshal(spec)
+	return toYAML(spec)
+}
+
+// toYAML encodes v as JSON and converts it to YAML, keeping the key order.
+func toYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
 
-	var obj interface{}
-	if err := json.Unmarshal(data, &obj); err != nil {
+	obj, err := decodeOrdered(json.NewDecoder(bytes.NewReader(data)))
+	if err != nil {
 		return nil, err
 	}
 
@@ -322,29 +834,69 @@ func specToYAML(spec *OpenAPISpec) ([]byte, error) {
 	return []byte(buf.String()), nil
 }
 
+// yamlMap is a JSON object whose entries keep their original order.
+type yamlMap []yamlEntry
+
+// yamlEntry is one key/value pair of a yamlMap.
+type yamlEntry struct {
+	key   string
+	value interface{}
+}
+
+// decodeOrdered decodes the next JSON value, returning objects as yamlMaps.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		m := yamlMap{}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, yamlEntry{key: keyTok.(string), value: value})
+		}
+		_, err := dec.Token()
+		return m, err
+	case json.Delim('['):
+		arr := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err := dec.Token()
+		return arr, err
+	}
+
+	return tok, nil
+}
+
 // writeYAML writes a Go value as YAML to the builder.
 func writeYAML(buf *strings.Builder, v interface{}, indent int) {
 	prefix := strings.Repeat("  ", indent)
 
 	switch val := v.(type) {
-	case map[string]interface{}:
+	case yamlMap:
 		if len(val) == 0 {
 			buf.WriteString("{}\n")
 			return
 		}
-		for key, value := range val {
+		for _, entry := range val {
 			buf.WriteString(prefix)
-			buf.WriteString(key)
+			buf.WriteString(yamlKey(entry.key))
 			buf.WriteString(":")
-
-			switch value.(type) {
-			case map[string]interface{}, []interface{}:
-				buf.WriteString("\n")
-				writeYAML(buf, value, indent+1)
-			default:
-				buf.WriteString(" ")
-				writeYAML(buf, value, indent+1)
-			}
+			writeYAMLValue(buf, entry.value, indent+1)
 		}
 
 	case []interface{}:
@@ -355,52 +907,25 @@ func writeYAML(buf *strings.Builder, v interface{}, indent int) {
 		for _, item := range val {
 			buf.WriteString(prefix)
 			buf.WriteString("- ")
-			switch item.(type) {
-			case map[string]interface{}:
-				// Inline first key, indent rest.
-				m := item.(map[string]interface{})
-				first := true
-				for key, value := range m {
-					if first {
-						buf.WriteString(key)
-						buf.WriteString(":")
-						switch value.(type) {
-						case map[string]interface{}, []interface{}:
-							buf.WriteString("\n")
-							writeYAML(buf, value, indent+2)
-						default:
-							buf.WriteString(" ")
-							writeYAML(buf, value, indent+2)
-						}
-						first = false
-					} else {
-						buf.WriteString(prefix)
-						buf.WriteString("  ")
-						buf.WriteString(key)
-						buf.WriteString(":")
-						switch value.(type) {
-						case map[string]interface{}, []interface{}:
-							buf.WriteString("\n")
-							writeYAML(buf, value, indent+2)
-						default:
-							buf.WriteString(" ")
-							writeYAML(buf, value, indent+2)
-						}
-					}
-				}
-			default:
+			m, ok := item.(yamlMap)
+			if !ok || len(m) == 0 {
 				writeYAML(buf, item, indent+1)
+				continue
+			}
+			// Inline first key, indent rest.
+			for i, entry := range m {
+				if i > 0 {
+					buf.WriteString(prefix)
+					buf.WriteString("  ")
+				}
+				buf.WriteString(yamlKey(entry.key))
+				buf.WriteString(":")
+				writeYAMLValue(buf, entry.value, indent+2)
 			}
 		}
 
 	case string:
-		// Check if we need quoting.
-		if needsYAMLQuoting(val) {
-			buf.WriteString(fmt.Sprintf("%q", val))
-		} else {
-			buf.WriteString(val)
-		}
-		buf.WriteString("\n")
+		writeYAMLString(buf, val, indent)
 
 	case float64:
 		if val == float64(int64(val)) {
@@ -426,16 +951,116 @@ func writeYAML(buf *strings.Builder, v interface{}, indent int) {
 	}
 }
 
+// writeYAMLValue writes the value of a mapping entry: non-empty collections
+// on the following lines, everything else inline after the key.
+func writeYAMLValue(buf *strings.Builder, v interface{}, indent int) {
+	switch val := v.(type) {
+	case yamlMap:
+		if len(val) > 0 {
+			buf.WriteString("\n")
+			writeYAML(buf, val, indent)
+			return
+		}
+	case []interface{}:
+		if len(val) > 0 {
+			buf.WriteString("\n")
+			writeYAML(buf, val, indent)
+			return
+		}
+	}
+	buf.WriteString(" ")
+	writeYAML(buf, v, indent)
+}
+
+// writeYAMLString writes a scalar string: multi-line text as a literal block
+// scalar so descriptions stay readable, everything else plain or quoted.
+func writeYAMLString(buf *strings.Builder, s string, indent int) {
+	if canUseBlockScalar(s) {
+		body := strings.TrimRight(s, "\n")
+		switch trailing := len(s) - len(body); {
+		case trailing == 0:
+			buf.WriteString("|-\n")
+		case trailing == 1:
+			buf.WriteString("|\n")
+		default:
+			buf.WriteString("|+\n")
+		}
+
+		prefix := strings.Repeat("  ", indent)
+		for _, line := range strings.Split(s[:len(s)-min(len(s)-len(body), 1)], "\n") {
+			if line != "" {
+				buf.WriteString(prefix)
+				buf.WriteString(line)
+			}
+			buf.WriteString("\n")
+		}
+		return
+	}
+
+	if needsYAMLQuoting(s) {
+		buf.WriteString(strconv.Quote(s))
+	} else {
+		buf.WriteString(s)
+	}
+	buf.WriteString("\n")
+}
+
+// canUseBlockScalar reports whether a multi-line string can be written as a
+// literal block scalar without changing its value.
+func canUseBlockScalar(s string) bool {
+	if !strings.Contains(strings.TrimRight(s, "\n"), "\n") {
+		return false
+	}
+	// Leading spaces on the first line would need an indentation indicator.
+	if strings.HasPrefix(s, " ") || strings.HasPrefix(s, "\t") {
+		return false
+	}
+	for _, r := range s {
+		if r == '\r' || r == '\uFEFF' || (r < ' ' && r != '\n' && r != '\t') || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
 // needsYAMLQuoting checks if a string needs to be quoted in YAML.
 func needsYAMLQuoting(s string) bool {
-	if s == "" {
+	if s == "" || s == "~" {
+		return true
+	}
+	// YAML 1.1 loaders read these as booleans or null in any case.
+	switch strings.ToLower(s) {
+	case "true", "false", "null", "yes", "no", "on", "off", "y", "n":
+		return true
+	}
+	if strings.ContainsAny(s, ":#{}[]|>&*!%@`'\"\\,\n\r\t") {
+		return true
+	}
+	if s != strings.TrimSpace(s) || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") || strings.HasPrefix(s, ".") {
 		return true
 	}
-	if s == "true" || s == "false" || s == "null" || s == "yes" || s == "no" {
+	for _, r := range s {
+		if r < ' ' || r == 0x7f || r == '\uFEFF' {
+			return true
+		}
+	}
+	// Numbers, status codes, and dates would otherwise load as non-strings.
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil {
 		return true
 	}
-	if strings.ContainsAny(s, ":#{}[]|>&*!%@`'\"\\,\n") {
+	if len(s) >= 10 && s[4] == '-' && s[7] == '-' && strings.IndexFunc(s[:4], func(r rune) bool { return r < '0' || r > '9' }) < 0 {
 		return true
 	}
 	return false
 }
+
+// yamlKey quotes a mapping key when needed.
+func yamlKey(key string) string {
+	if needsYAMLQuoting(key) {
+		return strconv.Quote(key)
+	}
+	return key
+}
@@ -0,0 +1,283 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpecToYAML_Stable(t *testing.T) {
+	spec := &OpenAPISpec{
+		OpenAPI: "3.1.0",
+		Info:    InfoObject{Title: "Test", Version: "1.0"},
+		Paths:   map[string]*PathItem{},
+	}
+	for _, path := range []string{"/c", "/a", "/b", "/d", "/e"} {
+		spec.Paths[path] = &PathItem{Get: &OperationObject{
+			Responses: map[string]*Response{"200": {Description: "OK"}, "404": {Description: "Missing"}},
+		}}
+	}
+
+	first, err := specToYAML(spec)
+	if err != nil {
+		t.Fatalf("specToYAML failed: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		again, _ := specToYAML(spec)
+		if string(again) != string(first) {
+			t.Fatal("YAML output changed between runs")
+		}
+	}
+
+	out := string(first)
+	if !strings.HasPrefix(out, "openapi: 3.1.0\ninfo:\n") {
+		t.Errorf("expected struct field order to be kept, got:\n%s", out)
+	}
+	if strings.Index(out, "/a:") > strings.Index(out, "/b:") {
+		t.Error("expected paths in sorted order")
+	}
+	if !strings.Contains(out, `"200":`) || !strings.Contains(out, `version: "1.0"`) {
+		t.Errorf("expected numeric-looking strings to be quoted, got:\n%s", out)
+	}
+}
+
+func TestSpecToYAML_Strings(t *testing.T) {
+	spec := &OpenAPISpec{
+		OpenAPI: "3.1.0",
+		Info: InfoObject{
+			Title:       "Yes",
+			Version:     "0x1F",
+			Description: "Line one.\n\n  - indented: item\nLast line.\n",
+		},
+		Paths: map[string]*PathItem{},
+	}
+
+	data, err := specToYAML(spec)
+	if err != nil {
+		t.Fatalf("specToYAML failed: %v", err)
+	}
+	yaml := string(data)
+	for _, want := range []string{
+		`title: "Yes"`,
+		`version: "0x1F"`,
+		"description: |\n    Line one.\n\n      - indented: item\n    Last line.\n",
+	} {
+		if !strings.Contains(yaml, want) {
+			t.Errorf("expected %q in:\n%s", want, yaml)
+		}
+	}
+}
+
+func TestServerVariables(t *testing.T) {
+	server := ServerObject{
+		URL: "https://{tenant}.api.example.com/{version}",
+		Variables: map[string]*ServerVariableObject{
+			"tenant":  {Default: "acme", Enum: []string{"acme", "globex"}},
+			"version": {Default: "v1"},
+		},
+	}
+	if got := server.ResolvedURL(); got != "https://acme.api.example.com/v1" {
+		t.Errorf("ResolvedURL() = %q", got)
+	}
+
+	spec := &OpenAPISpec{
+		Servers: []ServerObject{server},
+		Paths:   map[string]*PathItem{"/users": {Get: &OperationObject{}}},
+	}
+	export := generateInsomniaExport(spec)
+	if got := export.Resources[1].Data["base_url"]; got != "https://acme.api.example.com/v1" {
+		t.Errorf("Insomnia base_url = %q", got)
+	}
+}
+
+func TestPostmanCollection(t *testing.T) {
+	spec := &OpenAPISpec{
+		Info:    InfoObject{Title: "Users API"},
+		Servers: []ServerObject{{URL: "https://api.example.com"}},
+		Paths: map[string]*PathItem{
+			"/users/{id}": {
+				Put: &OperationObject{
+					Tags:     []string{"Users"},
+					Security: []SecurityRequirement{{"bearerAuth": {}}},
+					Parameters: []ParameterObject{
+						{Name: "id", In: "path", Required: true, Description: "User ID", Schema: &SchemaObject{Type: "integer", Example: 42}},
+						{Name: "notify", In: "query", Description: "Send an email", Schema: &SchemaObject{Type: "boolean"}},
+					},
+					RequestBody: &RequestBodyObject{Content: map[string]MediaType{
+						"application/json": {Schema: &SchemaObject{Ref: RefPath("User")}},
+					}},
+				},
+			},
+			"/health": {Get: &OperationObject{}},
+		},
+		Components: &ComponentsObject{
+			Schemas: map[string]*SchemaObject{
+				"User": {Type: "object", Properties: map[string]*SchemaObject{
+					"name": {Type: "string", Example: "Ada"},
+				}},
+			},
+			SecuritySchemes: map[string]*SecuritySchemeObject{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	collection := generatePostmanCollection(spec, "")
+
+	if collection.Auth == nil || collection.Auth.Type != "bearer" || collection.Auth.Bearer[0].Value != "{{token}}" {
+		t.Errorf("collection auth = %+v", collection.Auth)
+	}
+	vars := map[string]string{}
+	for _, v := range collection.Variable {
+		vars[v.Key] = v.Value
+	}
+	if vars["baseUrl"] != "https://api.example.com" {
+		t.Errorf("baseUrl = %q", vars["baseUrl"])
+	}
+	if _, ok := vars["token"]; !ok {
+		t.Error("missing token variable")
+	}
+
+	put := collection.Item[0].Item[0].Request
+	if put.URL.Raw != "{{baseUrl}}/users/:id" {
+		t.Errorf("raw URL = %q", put.URL.Raw)
+	}
+	if got := put.URL.Variable; len(got) != 1 || got[0].Value != "42" || got[0].Description != "User ID" {
+		t.Errorf("path variables = %+v", got)
+	}
+	if got := put.URL.Query; len(got) != 1 || !got[0].Disabled || got[0].Description != "Send an email" {
+		t.Errorf("query = %+v", got)
+	}
+	if put.Auth != nil {
+		t.Errorf("request should inherit the collection auth, got %+v", put.Auth)
+	}
+	if put.Body == nil || !strings.Contains(put.Body.Raw, `"name": "Ada"`) {
+		t.Errorf("body = %+v", put.Body)
+	}
+
+	health := collection.Item[1].Request
+	if health.Auth == nil || health.Auth.Type != "noauth" {
+		t.Errorf("public request auth = %+v", health.Auth)
+	}
+
+	// Config.Auth's scheme wins over the first scheme by name.
+	spec.Components.SecuritySchemes["apiKeyAuth"] = &SecuritySchemeObject{Type: "apiKey", Name: "X-API-Key", In: "header"}
+	if auth := generatePostmanCollection(spec, "").Auth; auth == nil || auth.Type != "apikey" {
+		t.Errorf("expected the first scheme without a preference, got %+v", auth)
+	}
+	collection = generatePostmanCollection(spec, "bearerAuth")
+	if collection.Auth == nil || collection.Auth.Type != "bearer" {
+		t.Errorf("expected the preferred scheme, got %+v", collection.Auth)
+	}
+	if put := collection.Item[0].Item[0].Request; put.Auth != nil {
+		t.Errorf("request should inherit the preferred auth, got %+v", put.Auth)
+	}
+}
+
+func TestPostmanEnvironment(t *testing.T) {
+	spec := &OpenAPISpec{
+		Info: InfoObject{Title: "Users API"},
+		Servers: []ServerObject{
+			{URL: "https://api.example.com", Description: "Production"},
+			{URL: "https://staging.example.com"},
+		},
+		Components: &ComponentsObject{SecuritySchemes: map[string]*SecuritySchemeObject{
+			"apiKeyAuth": {Type: "apiKey", Name: "X-API-Key", In: "header"},
+		}},
+	}
+
+	env, ok := generatePostmanEnvironment(spec, 1, "")
+	if !ok {
+		t.Fatal("expected an environment for server 1")
+	}
+	if env.Name != "Users API - https://staging.example.com" {
+		t.Errorf("name = %q", env.Name)
+	}
+	values := map[string]PostmanEnvValue{}
+	for _, v := range env.Values {
+		values[v.Key] = v
+	}
+	if values["baseUrl"].Value != "https://staging.example.com" {
+		t.Errorf("baseUrl = %+v", values["baseUrl"])
+	}
+	if values["apiKey"].Type != "secret" {
+		t.Errorf("apiKey = %+v", values["apiKey"])
+	}
+
+	if _, ok := generatePostmanEnvironment(spec, 2, ""); ok {
+		t.Error("expected no environment for a missing server")
+	}
+}
+
+func TestInsomniaExport(t *testing.T) {
+	spec := &OpenAPISpec{
+		Info: InfoObject{Title: "Users API"},
+		Servers: []ServerObject{
+			{URL: "https://api.example.com", Description: "Production"},
+			{URL: "https://staging.example.com", Description: "Staging"},
+		},
+		Tags: []TagObject{{Name: "Users"}},
+		Paths: map[string]*PathItem{
+			"/users/{id}": {
+				Put: &OperationObject{
+					Tags:     []string{"Users"},
+					Security: []SecurityRequirement{{"apiKeyAuth": {}}},
+					Parameters: []ParameterObject{
+						{Name: "id", In: "path", Required: true, Schema: &SchemaObject{Type: "integer", Example: 42}},
+					},
+					RequestBody: &RequestBodyObject{Content: map[string]MediaType{
+						"application/json": {Schema: &SchemaObject{Type: "object", Properties: map[string]*SchemaObject{
+							"name": {Type: "string", Example: "Ada"},
+						}}},
+					}},
+				},
+			},
+		},
+		Components: &ComponentsObject{SecuritySchemes: map[string]*SecuritySchemeObject{
+			"apiKeyAuth": {Type: "apiKey", Name: "X-API-Key", In: "query"},
+		}},
+	}
+
+	export := generateInsomniaExport(spec)
+	byID := map[string]InsomniaResource{}
+	for _, r := range export.Resources {
+		byID[r.ID] = r
+	}
+
+	env := byID["env_gindocs"]
+	if env.Data["base_url"] != "https://api.example.com" || env.Data["id"] != "42" {
+		t.Errorf("base environment data = %v", env.Data)
+	}
+	if _, ok := env.Data["api_key"]; !ok {
+		t.Error("missing api_key variable")
+	}
+	if staging := byID["env_gindocs_1"]; staging.ParentID != "env_gindocs" || staging.Data["base_url"] != "https://staging.example.com" {
+		t.Errorf("staging environment = %+v", staging)
+	}
+
+	req := byID["req_1"]
+	if req.URL != "{{ _.base_url }}/users/{{ _.id }}" {
+		t.Errorf("URL = %q", req.URL)
+	}
+	if req.Authentication == nil || req.Authentication.Type != "apikey" || req.Authentication.AddTo != "queryParams" {
+		t.Errorf("authentication = %+v", req.Authentication)
+	}
+	if body, ok := req.Body.(*InsomniaBody); !ok || !strings.Contains(body.Text, `"name": "Ada"`) {
+		t.Errorf("body = %+v", req.Body)
+	}
+
+	data, err := toYAML(generateInsomniaV5(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	yaml := string(data)
+	for _, want := range []string{
+		"type: collection.insomnia.rest/5.0\n",
+		"collection:\n  - name: Users\n",
+		"      - url: \"{{ _.base_url }}/users/{{ _.id }}\"\n",
+		"  subEnvironments:\n",
+	} {
+		if !strings.Contains(yaml, want) {
+			t.Errorf("expected %q in:\n%s", want, yaml)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package gindocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// marshalExtensions appends the x-* entries of ext to the JSON object in data.
+// Keys that don't start with "x-" or that data already contains are skipped.
+func marshalExtensions(data []byte, ext map[string]interface{}) ([]byte, error) {
+	if len(ext) == 0 {
+		return data, nil
+	}
+
+	var existing map[string]json.RawMessage
+	if err := json.Unmarshal(data, &existing); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(ext))
+	for key := range ext {
+		if _, ok := existing[key]; !ok && strings.HasPrefix(key, "x-") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return data, nil
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, key := range keys {
+		value, err := json.Marshal(ext[key])
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 || len(existing) > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// unmarshalExtensions collects the x-* entries of a JSON object that aren't
+// already decoded into a field of t.
+func unmarshalExtensions(data []byte, t reflect.Type) map[string]interface{} {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	known := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		known[name] = true
+	}
+
+	var ext map[string]interface{}
+	for key, value := range raw {
+		if !strings.HasPrefix(key, "x-") || known[key] {
+			continue
+		}
+		if ext == nil {
+			ext = make(map[string]interface{})
+		}
+		ext[key] = value
+	}
+	return ext
+}
+
+// MarshalJSON encodes the spec with its vendor extensions inlined.
+func (s OpenAPISpec) MarshalJSON() ([]byte, error) {
+	type plain OpenAPISpec
+	data, err := json.Marshal(plain(s))
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, s.Extensions)
+}
+
+// UnmarshalJSON decodes the spec, collecting vendor extensions.
+func (s *OpenAPISpec) UnmarshalJSON(data []byte) error {
+	type plain OpenAPISpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	s.Extensions = unmarshalExtensions(data, reflect.TypeOf(plain{}))
+	return nil
+}
+
+// MarshalJSON encodes the info object with its vendor extensions inlined.
+func (i InfoObject) MarshalJSON() ([]byte, error) {
+	type plain InfoObject
+	data, err := json.Marshal(plain(i))
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, i.Extensions)
+}
+
+// UnmarshalJSON decodes the info object, collecting vendor extensions.
+func (i *InfoObject) UnmarshalJSON(data []byte) error {
+	type plain InfoObject
+	if err := json.Unmarshal(data, (*plain)(i)); err != nil {
+		return err
+	}
+	i.Extensions = unmarshalExtensions(data, reflect.TypeOf(plain{}))
+	return nil
+}
+
+// MarshalJSON encodes the operation with its vendor extensions inlined.
+func (o OperationObject) MarshalJSON() ([]byte, error) {
+	type plain OperationObject
+	data, err := json.Marshal(plain(o))
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, o.Extensions)
+}
+
+// UnmarshalJSON decodes the operation, collecting vendor extensions.
+func (o *OperationObject) UnmarshalJSON(data []byte) error {
+	type plain OperationObject
+	if err := json.Unmarshal(data, (*plain)(o)); err != nil {
+		return err
+	}
+	o.Extensions = unmarshalExtensions(data, reflect.TypeOf(plain{}))
+	return nil
+}
+
+// MarshalJSON encodes the parameter with its vendor extensions inlined.
+func (p ParameterObject) MarshalJSON() ([]byte, error) {
+	type plain ParameterObject
+	data, err := json.Marshal(plain(p))
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, p.Extensions)
+}
+
+// UnmarshalJSON decodes the parameter, collecting vendor extensions.
+func (p *ParameterObject) UnmarshalJSON(data []byte) error {
+	type plain ParameterObject
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	p.Extensions = unmarshalExtensions(data, reflect.TypeOf(plain{}))
+	return nil
+}
+
+// MarshalJSON encodes the response with its vendor extensions inlined, or
+// just the $ref of a reference.
+func (r Response) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": r.Ref})
+	}
+	type plain Response
+	data, err := json.Marshal(plain(r))
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, r.Extensions)
+}
+
+// UnmarshalJSON decodes the response, collecting vendor extensions.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type plain Response
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	r.Extensions = unmarshalExtensions(data, reflect.TypeOf(plain{}))
+	return nil
+}
+
+// MarshalJSON encodes the security scheme with its vendor extensions inlined.
+func (s SecuritySchemeObject) MarshalJSON() ([]byte, error) {
+	type plain SecuritySchemeObject
+	data, err := json.Marshal(plain(s))
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, s.Extensions)
+}
+
+// UnmarshalJSON decodes the security scheme, collecting vendor extensions.
+func (s *SecuritySchemeObject) UnmarshalJSON(data []byte) error {
+	type plain SecuritySchemeObject
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	s.Extensions = unmarshalExtensions(data, reflect.TypeOf(plain{}))
+	return nil
+}
+
+// MarshalJSON encodes the tag with its vendor extensions inlined.
+func (t TagObject) MarshalJSON() ([]byte, error) {
+	type plain TagObject
+	data, err := json.Marshal(plain(t))
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, t.Extensions)
+}
+
+// UnmarshalJSON decodes the tag, collecting vendor extensions.
+func (t *TagObject) UnmarshalJSON(data []byte) error {
+	type plain TagObject
+	if err := json.Unmarshal(data, (*plain)(t)); err != nil {
+		return err
+	}
+	t.Extensions = unmarshalExtensions(data, reflect.TypeOf(plain{}))
+	return nil
+}
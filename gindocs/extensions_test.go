@@ -0,0 +1,60 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExtensions_RoundTrip(t *testing.T) {
+	op := OperationObject{
+		Summary:   "List users",
+		Responses: map[string]*Response{"200": {Description: "OK"}},
+		Sunset:    "2030-01-01",
+		Extensions: map[string]interface{}{
+			"x-internal": true,
+			"x-sunset":   "ignored",
+			"notx":       "ignored",
+		},
+	}
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	out := string(data)
+	if !strings.Contains(out, `"x-internal":true`) {
+		t.Errorf("expected x-internal in %s", out)
+	}
+	if strings.Contains(out, "notx") || strings.Count(out, "x-sunset") != 1 {
+		t.Errorf("unexpected extension keys in %s", out)
+	}
+
+	var decoded OperationObject
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Extensions["x-internal"] != true {
+		t.Errorf("expected x-internal to round-trip, got %v", decoded.Extensions)
+	}
+	if _, ok := decoded.Extensions["x-sunset"]; ok {
+		t.Error("x-sunset should decode into the Sunset field, not Extensions")
+	}
+}
+
+func TestExtensions_Schema(t *testing.T) {
+	schema := SchemaObject{Type: "string", Nullable: true, Extensions: map[string]interface{}{"x-order": 1}}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if got, want := string(data), `{"type":["string","null"],"x-order":1}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	empty, _ := json.Marshal(SchemaObject{Extensions: map[string]interface{}{"x-a": "b"}})
+	if got, want := string(empty), `{"x-a":"b"}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
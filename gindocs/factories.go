@@ -0,0 +1,290 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// sampleValue builds an example instance that satisfies the schema's
+// enums, formats, and min/max constraints. Recursive $refs sample as nil.
+func sampleValue(schema *SchemaObject, schemas map[string]*SchemaObject, name string, seen map[string]bool) interface{} {
+	if schema == nil {
+		return nil
+	}
+
+	if schema.Ref != "" {
+		ref := strings.TrimPrefix(schema.Ref, RefPath(""))
+		if seen[ref] {
+			return nil
+		}
+		seen[ref] = true
+		defer delete(seen, ref)
+		return sampleValue(schemas[ref], schemas, name, seen)
+	}
+
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if schema.Default != nil {
+		return schema.Default
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+
+	if len(schema.AllOf) > 0 {
+		merged := map[string]interface{}{}
+		for _, part := range schema.AllOf {
+			if obj, ok := sampleValue(part, schemas, name, seen).(map[string]interface{}); ok {
+				for k, v := range obj {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	for _, variants := range [][]*SchemaObject{schema.OneOf, schema.AnyOf} {
+		for _, variant := range variants {
+			if variant.Type != "null" {
+				return sampleValue(variant, schemas, name, seen)
+			}
+		}
+	}
+
+	switch schema.Type {
+	case "string":
+		return sampleString(schema, name)
+	case "integer", "number":
+		return sampleNumber(schema, name)
+	case "boolean":
+		return true
+	case "array":
+		count := 1
+		if schema.MinItems != nil && *schema.MinItems > count {
+			count = *schema.MinItems
+		}
+		if schema.MaxItems != nil && *schema.MaxItems < count {
+			count = *schema.MaxItems
+		}
+		items := make([]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			items = append(items, sampleValue(schema.Items, schemas, name, seen))
+		}
+		return items
+	case "object", "":
+		obj := map[string]interface{}{}
+		for prop, propSchema := range schema.Properties {
+			obj[prop] = sampleValue(propSchema, schemas, prop, seen)
+		}
+		return obj
+	}
+
+	return nil
+}
+
+// sampleString returns a string example padded or trimmed to the length bounds.
+func sampleString(schema *SchemaObject, name string) string {
+	s, ok := inferExampleValue(name, "string", schema.Format).(string)
+	if !ok {
+		s = "string"
+	}
+
+	if schema.MinLength != nil && len(s) < *schema.MinLength {
+		s += strings.Repeat("x", *schema.MinLength-len(s))
+	}
+	if schema.MaxLength != nil && len(s) > *schema.MaxLength {
+		s = s[:*schema.MaxLength]
+	}
+	return s
+}
+
+// sampleNumber returns a numeric example clamped into the schema's bounds.
+func sampleNumber(schema *SchemaObject, name string) float64 {
+	var n float64 = 1
+	switch v := inferExampleValue(name, schema.Type, schema.Format).(type) {
+	case int:
+		n = float64(v)
+	case float64:
+		n = v
+	}
+
+	step := 1.0
+	if schema.Type == "number" && schema.MultipleOf == nil {
+		step = 0.5
+	}
+
+	if schema.Minimum != nil && n < *schema.Minimum {
+		n = *schema.Minimum
+	}
+	if schema.ExclusiveMinimum != nil && n <= *schema.ExclusiveMinimum {
+		n = *schema.ExclusiveMinimum + step
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		n = *schema.Maximum
+	}
+	if schema.ExclusiveMaximum != nil && n >= *schema.ExclusiveMaximum {
+		n = *schema.ExclusiveMaximum - step
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		n = math.Ceil(n / *schema.MultipleOf) * *schema.MultipleOf
+	}
+	if schema.Type == "integer" {
+		n = math.Ceil(n)
+	}
+	return n
+}
+
+// factorySamples generates a normalized sample for every component schema, keyed by name.
+func factorySamples(spec *OpenAPISpec) ([]string, map[string]interface{}) {
+	samples := make(map[string]interface{})
+	if spec.Components == nil {
+		return nil, samples
+	}
+
+	schemas := spec.Components.Schemas
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+
+		// Round-trip through JSON so literals only contain JSON value types.
+		var normalized interface{}
+		if data, err := json.Marshal(sampleValue(SchemaRef(name), schemas, name, map[string]bool{})); err == nil {
+			_ = json.Unmarshal(data, &normalized)
+		}
+		samples[name] = normalized
+	}
+	sort.Strings(names)
+
+	return names, samples
+}
+
+// generateGoFactories renders Go factory functions for every component schema.
+func generateGoFactories(spec *OpenAPISpec, pkg string) string {
+	names, samples := factorySamples(spec)
+
+	var buf strings.Builder
+	buf.WriteString("// Code generated by gindocs. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n", pkg)
+
+	for _, name := range names {
+		ident := "New" + factoryIdent(name)
+		sample := samples[name]
+
+		buf.WriteString("\n")
+		if obj, ok := sample.(map[string]interface{}); ok {
+			fmt.Fprintf(&buf, "// %s returns a valid example %s. Overrides replace top-level fields.\n", ident, name)
+			fmt.Fprintf(&buf, "func %s(overrides map[string]interface{}) map[string]interface{} {\n", ident)
+			buf.WriteString("\tv := ")
+			writeGoLiteral(&buf, obj, 1)
+			buf.WriteString("\n\tfor k, val := range overrides {\n\t\tv[k] = val\n\t}\n\treturn v\n}\n")
+			continue
+		}
+
+		fmt.Fprintf(&buf, "// %s returns a valid example %s.\n", ident, name)
+		fmt.Fprintf(&buf, "func %s() interface{} {\n\treturn ", ident)
+		writeGoLiteral(&buf, sample, 1)
+		buf.WriteString("\n}\n")
+	}
+
+	// Align literals the way gofmt would; fall back to the raw output.
+	if formatted, err := format.Source([]byte(buf.String())); err == nil {
+		return string(formatted)
+	}
+	return buf.String()
+}
+
+// writeGoLiteral writes a JSON-decoded value as a Go composite literal.
+func writeGoLiteral(buf *strings.Builder, v interface{}, indent int) {
+	pad := strings.Repeat("\t", indent)
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			buf.WriteString("map[string]interface{}{}")
+			return
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf.WriteString("map[string]interface{}{\n")
+		for _, k := range keys {
+			buf.WriteString(pad + "\t" + strconv.Quote(k) + ": ")
+			writeGoLiteral(buf, val[k], indent+1)
+			buf.WriteString(",\n")
+		}
+		buf.WriteString(pad + "}")
+	case []interface{}:
+		buf.WriteString("[]interface{}{")
+		for i, item := range val {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			writeGoLiteral(buf, item, indent)
+		}
+		buf.WriteString("}")
+	case string:
+		buf.WriteString(strconv.Quote(val))
+	case float64:
+		buf.WriteString(strconv.FormatFloat(val, 'f', -1, 64))
+	case bool:
+		buf.WriteString(strconv.FormatBool(val))
+	default:
+		buf.WriteString("nil")
+	}
+}
+
+// generateTSFactories renders TypeScript factory functions for every component schema.
+func generateTSFactories(spec *OpenAPISpec) string {
+	names, samples := factorySamples(spec)
+
+	var buf strings.Builder
+	buf.WriteString("// Code generated by gindocs. DO NOT EDIT.\n")
+
+	for _, name := range names {
+		ident := "make" + factoryIdent(name)
+		data, err := json.MarshalIndent(samples[name], "  ", "  ")
+		if err != nil {
+			continue
+		}
+
+		buf.WriteString("\n")
+		if _, ok := samples[name].(map[string]interface{}); ok {
+			fmt.Fprintf(&buf, "/** Returns a valid example %s. Overrides replace top-level fields. */\n", name)
+			fmt.Fprintf(&buf, "export function %s(overrides: Record<string, unknown> = {}): Record<string, unknown> {\n", ident)
+			fmt.Fprintf(&buf, "  const defaults = %s;\n  return { ...defaults, ...overrides };\n}\n", data)
+			continue
+		}
+
+		fmt.Fprintf(&buf, "/** Returns a valid example %s. */\n", name)
+		fmt.Fprintf(&buf, "export function %s(): unknown {\n  return %s;\n}\n", ident, data)
+	}
+
+	return buf.String()
+}
+
+// factoryIdent converts a schema name into an exported identifier.
+func factoryIdent(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package gindocs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSampleValue_Constraints(t *testing.T) {
+	minLen, five, hundred := 12, 5.0, 100.0
+	schemas := map[string]*SchemaObject{
+		"Node": {
+			Type: "object",
+			Properties: map[string]*SchemaObject{
+				"name":   {Type: "string", MinLength: &minLen},
+				"role":   {Type: "string", Enum: []interface{}{"admin", "member"}},
+				"age":    {Type: "integer", Minimum: &hundred},
+				"score":  {Type: "number", ExclusiveMaximum: &five},
+				"parent": SchemaRef("Node"),
+			},
+		},
+	}
+
+	sample, ok := sampleValue(SchemaRef("Node"), schemas, "Node", map[string]bool{}).(map[string]interface{})
+	if !ok {
+		t.Fatalf("sample is not an object")
+	}
+	if name := sample["name"].(string); len(name) < minLen {
+		t.Errorf("name = %q, want at least %d chars", name, minLen)
+	}
+	if sample["role"] != "admin" {
+		t.Errorf("role = %v, want first enum value", sample["role"])
+	}
+	if age := sample["age"].(float64); age < hundred {
+		t.Errorf("age = %v, want >= %v", age, hundred)
+	}
+	if sample["parent"] != nil {
+		t.Errorf("recursive parent = %v, want nil", sample["parent"])
+	}
+}
+
+func TestGenerateGoFactories(t *testing.T) {
+	spec := &OpenAPISpec{Components: &ComponentsObject{Schemas: map[string]*SchemaObject{
+		"User": {Type: "object", Properties: map[string]*SchemaObject{"id": {Type: "integer"}}},
+	}}}
+
+	code := generateGoFactories(spec, "fixtures")
+	for _, want := range []string{"package fixtures", "func NewUser(overrides map[string]interface{})", `"id": 1`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestFactoriesEndpointFilters(t *testing.T) {
+	type PublicUser struct {
+		ID int `json:"id"`
+	}
+	type AuditEntry struct {
+		Action string `json:"action"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	r.GET("/audit", func(c *gin.Context) {})
+	gd := Mount(r, nil)
+	gd.Route("GET /users").Response(200, PublicUser{}, "Users")
+	gd.Route("GET /audit").Response(200, AuditEntry{}, "Audit log").Audience("internal")
+
+	for _, url := range []string{"/docs/export/factories/go?audience=public", "/docs/export/factories/ts?audience=public"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		if body := w.Body.String(); !strings.Contains(body, "PublicUser") || strings.Contains(body, "AuditEntry") {
+			t.Errorf("%s: expected only the public schema, got %s", url, body)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package gindocs
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// specFilterParams are the query parameters that shape the served spec.
+var specFilterParams = []string{"audience", "tags", "prefix", "resolve"}
+
+// requestSpec returns the spec as seen by the current request: filtered,
+// narrowed to the requested Version, with $refs resolved when
+// Config.InlineSchemas or ?resolve=true is set, and with a server URL derived
+// from the request when Config.Servers is empty.
+func (gd *GinDocs) requestSpec(c *gin.Context) *OpenAPISpec {
+	spec := gd.requestOperations(c, gd.getSpec())
+	if gd.config.InlineSchemas || c.Query("resolve") == "true" {
+		spec = inlineSchemas(spec)
+	}
+	spec = gd.withRequestServer(c, spec)
+	return spec
+}
+
+// requestOperations narrows spec to the operations the current request
+// sees: filtered, then narrowed to the requested Version.
+func (gd *GinDocs) requestOperations(c *gin.Context, spec *OpenAPISpec) *OpenAPISpec {
+	spec = gd.filteredSpec(c, spec)
+	if v := gd.requestVersion(c); v != nil {
+		spec = versionSpec(spec, v)
+	}
+	return spec
+}
+
+// filteredSpec returns spec as seen by the current request, without
+// operations whose feature flag is disabled for it or that belong to
+// another audience. The ?tags= and ?prefix= query parameters select
+// operations by tag or path prefix. Filtered specs only keep the schemas
+// and tags their operations use.
+func (gd *GinDocs) filteredSpec(c *gin.Context, spec *OpenAPISpec) *OpenAPISpec {
+	audience := gd.requestAudience(c)
+
+	tags := make(map[string]bool)
+	for _, tag := range strings.Split(c.Query("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags[tag] = true
+		}
+	}
+	prefix := ginPathToOpenAPI(c.Query("prefix"))
+
+	if gd.config.FeatureFlags == nil && audience == "" && len(tags) == 0 && prefix == "" {
+		return spec
+	}
+
+	enabled := make(map[string]bool)
+	isEnabled := func(flag string) bool {
+		if gd.config.FeatureFlags == nil {
+			return true
+		}
+		on, ok := enabled[flag]
+		if !ok {
+			on = gd.config.FeatureFlags(c, flag)
+			enabled[flag] = on
+		}
+		return on
+	}
+
+	filtered := filterOperations(spec, func(path, method string, op *OperationObject) bool {
+		if op.FeatureFlag != "" && !isEnabled(op.FeatureFlag) {
+			return false
+		}
+		if prefix != "" && !strings.HasPrefix(path, prefix) {
+			return false
+		}
+		if len(tags) > 0 && !hasAnyTag(op, tags) {
+			return false
+		}
+		return audience == "" || inAudience(op, audience)
+	})
+	pruneSchemas(filtered)
+	pruneTags(filtered)
+	return filtered
+}
+
+// hasAnyTag reports whether an operation has one of the tags.
+func hasAnyTag(op *OperationObject, tags map[string]bool) bool {
+	for _, tag := range op.Tags {
+		if tags[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// filterOperations returns a shallow copy of spec with only the operations
+// keep returns true for. Paths left without operations are dropped.
+func filterOperations(spec *OpenAPISpec, keep func(path, method string, op *OperationObject) bool) *OpenAPISpec {
+	filtered := *spec
+	filtered.Paths = make(map[string]*PathItem, len(spec.Paths))
+	for path, pathItem := range spec.Paths {
+		item := &PathItem{}
+		empty := true
+		for _, method := range httpMethods {
+			op := pathItem.GetOperation(method)
+			if op == nil || !keep(path, method, op) {
+				continue
+			}
+			item.SetOperation(method, op)
+			empty = false
+		}
+		if !empty {
+			filtered.Paths[path] = item
+		}
+	}
+
+	return &filtered
+}
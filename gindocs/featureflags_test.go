@@ -0,0 +1,59 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestFeatureFlags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/items", func(c *gin.Context) {})
+	r.GET("/beta", func(c *gin.Context) {})
+	r.POST("/beta", func(c *gin.Context) {})
+
+	var calls int
+	gd := Mount(r, nil, Config{FeatureFlags: func(c *gin.Context, flag string) bool {
+		calls++
+		return flag == "beta" && c.GetHeader("X-Beta") == "on"
+	}})
+	gd.Route("GET /beta").FeatureFlag("beta")
+	gd.Route("POST /beta").FeatureFlag("beta")
+
+	fetch := func(beta string) *OpenAPISpec {
+		req := httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil)
+		req.Header.Set("X-Beta", beta)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var spec OpenAPISpec
+		if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+			t.Fatal(err)
+		}
+		return &spec
+	}
+
+	if spec := fetch("off"); spec.Paths["/beta"] != nil || spec.Paths["/items"] == nil {
+		t.Errorf("expected /beta hidden with the flag off, got %v", sortedKeys(spec.Paths))
+	}
+	if calls != 1 {
+		t.Errorf("provider called %d times per request, want once per flag", calls)
+	}
+
+	spec := fetch("on")
+	if beta := spec.Paths["/beta"]; beta == nil || beta.Get == nil || beta.Post == nil || beta.Get.FeatureFlag != "beta" {
+		t.Errorf("expected /beta with x-feature-flag when the flag is on, got %+v", beta)
+	}
+
+	// Without a provider, flagged operations are always documented.
+	r = gin.New()
+	r.GET("/beta", func(c *gin.Context) {})
+	gd = Mount(r, nil)
+	gd.Route("GET /beta").FeatureFlag("beta")
+	if gd.Spec().Paths["/beta"] == nil {
+		t.Error("expected /beta without a FeatureFlags provider")
+	}
+}
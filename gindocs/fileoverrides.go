@@ -0,0 +1,221 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// overridesFile is the content of Config.OverridesFile:
+//
+//	routes:
+//	  GET /users/:id:
+//	    summary: Get a user
+//	    description: Returns the user with the given ID.
+//	    parameters:
+//	      id: The user's numeric ID
+//	    responses:
+//	      200:
+//	        description: The user
+//	        example: {id: 42, name: Ada}
+//	tags:
+//	  Users: Manage user accounts
+type overridesFile struct {
+	Routes map[string]fileRouteOverride `json:"routes"`
+
+	// Tags maps tag names to descriptions.
+	Tags map[string]string `json:"tags"`
+}
+
+// fileRouteOverride holds the overrides of one "METHOD /path" route.
+type fileRouteOverride struct {
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	OperationID string   `json:"operationId"`
+	Tags        []string `json:"tags"`
+	Deprecated  *bool    `json:"deprecated"`
+	Hidden      bool     `json:"hidden"`
+
+	// Parameters maps parameter names to descriptions.
+	Parameters map[string]string `json:"parameters"`
+
+	RequestExample interface{}                     `json:"requestExample"`
+	Responses      map[string]fileResponseOverride `json:"responses"`
+}
+
+// fileResponseOverride overrides the description and example of a response.
+type fileResponseOverride struct {
+	Description string      `json:"description"`
+	Example     interface{} `json:"example"`
+}
+
+// loadOverridesFile reads Config.OverridesFile, which is YAML or JSON.
+// Read errors are reported by Warnings.
+func (gd *GinDocs) loadOverridesFile() {
+	if gd.config.OverridesFile == "" {
+		return
+	}
+	data, err := os.ReadFile(gd.config.OverridesFile)
+	if err != nil {
+		gd.overridesErr = err
+		return
+	}
+	// Decode through JSON: go-yaml maps integer keys such as status codes
+	// to runes when decoding into string-keyed maps.
+	if data, err = yaml.YAMLToJSON(data); err != nil {
+		gd.overridesErr = err
+		return
+	}
+	var file overridesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		gd.overridesErr = err
+		return
+	}
+	gd.fileOverrides = &file
+
+	for key, route := range file.Routes {
+		if route.Hidden {
+			method, path := splitRouteKey(key)
+			gd.Hide(method + " " + openAPIPathToGin(path))
+		}
+	}
+}
+
+// applyFileOverrides applies the route overrides of the overrides file to
+// spec and adds the tags they introduce to tagSet. They take precedence over
+// overrides made in code. Routes and parameters that don't exist are
+// returned as warnings.
+func (gd *GinDocs) applyFileOverrides(spec *OpenAPISpec, tagSet map[string]bool) []string {
+	if gd.overridesErr != nil {
+		return []string{fmt.Sprintf("OverridesFile: %v", gd.overridesErr)}
+	}
+	if gd.fileOverrides == nil {
+		return nil
+	}
+
+	var warnings []string
+	for _, key := range sortedKeys(gd.fileOverrides.Routes) {
+		route := gd.fileOverrides.Routes[key]
+		if route.Hidden {
+			continue
+		}
+		method, path := splitRouteKey(key)
+		path = ginPathToOpenAPI(path)
+		var op *OperationObject
+		if item, ok := spec.Paths[path]; ok {
+			op = item.GetOperation(method)
+		}
+		if op == nil {
+			warnings = append(warnings, fmt.Sprintf("OverridesFile: %q: no such route", key))
+			continue
+		}
+
+		if route.Summary != "" {
+			op.Summary = route.Summary
+		}
+		if route.Description != "" {
+			op.Description = route.Description
+		}
+		if route.OperationID != "" {
+			op.OperationID = route.OperationID
+		}
+		if len(route.Tags) > 0 {
+			op.Tags = route.Tags
+			for _, tag := range route.Tags {
+				tagSet[tag] = true
+			}
+		}
+		if route.Deprecated != nil {
+			op.Deprecated = *route.Deprecated
+		}
+
+		for _, name := range sortedKeys(route.Parameters) {
+			found := false
+			for i := range op.Parameters {
+				if op.Parameters[i].Name == name {
+					op.Parameters[i].Description = route.Parameters[name]
+					found = true
+				}
+			}
+			if !found {
+				warnings = append(warnings, fmt.Sprintf("OverridesFile: %q: no parameter %q", key, name))
+			}
+		}
+
+		if route.RequestExample != nil {
+			if op.RequestBody == nil {
+				warnings = append(warnings, fmt.Sprintf("OverridesFile: %q: requestExample for a route without a request body", key))
+			} else {
+				for mediaType, media := range op.RequestBody.Content {
+					media.Example = route.RequestExample
+					op.RequestBody.Content[mediaType] = media
+				}
+			}
+		}
+
+		for _, code := range sortedKeys(route.Responses) {
+			if _, err := strconv.Atoi(code); err != nil && code != "default" {
+				warnings = append(warnings, fmt.Sprintf("OverridesFile: %q: invalid response status %q", key, code))
+				continue
+			}
+			applyFileResponse(op, code, route.Responses[code])
+		}
+	}
+	return warnings
+}
+
+// applyFileResponse overrides a response, documenting it if the operation
+// doesn't have it yet. Examples for responses without a body also document
+// the body, with a schema inferred from the example.
+func applyFileResponse(op *OperationObject, code string, override fileResponseOverride) {
+	if op.Responses == nil {
+		op.Responses = make(map[string]*Response)
+	}
+	resp, ok := op.Responses[code]
+	if !ok {
+		resp = &Response{Description: override.Description}
+		op.Responses[code] = resp
+	}
+	if override.Description != "" {
+		resp.Description = override.Description
+	}
+	if override.Example == nil {
+		return
+	}
+
+	example := override.Example
+	if len(resp.Content) == 0 {
+		data, _ := json.Marshal(example)
+		schema, _, _ := parseJSONSample(string(data))
+		resp.Content = map[string]MediaType{"application/json": {Schema: schema}}
+	}
+	for mediaType, media := range resp.Content {
+		media.Example = example
+		resp.Content[mediaType] = media
+	}
+}
+
+// describeTags sets the tag descriptions of the overrides file.
+func (gd *GinDocs) describeTags(spec *OpenAPISpec) {
+	if gd.fileOverrides == nil {
+		return
+	}
+	for i, tag := range spec.Tags {
+		if description, ok := gd.fileOverrides.Tags[tag.Name]; ok {
+			spec.Tags[i].Description = description
+		}
+	}
+}
+
+// splitRouteKey splits a "METHOD /path" key; a bare path means GET.
+func splitRouteKey(key string) (method, path string) {
+	parts := strings.SplitN(strings.TrimSpace(key), " ", 2)
+	if len(parts) == 2 {
+		return strings.ToUpper(parts[0]), strings.TrimSpace(parts[1])
+	}
+	return "GET", parts[0]
+}
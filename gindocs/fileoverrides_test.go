@@ -0,0 +1,78 @@
+package gindocs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOverridesFile(t *testing.T) {
+	file := `routes:
+  GET /users/:id:
+    summary: Get a user
+    tags: [Users]
+    parameters:
+      id: The user's numeric ID
+      missing: Nope
+    responses:
+      200:
+        description: The user
+        example:
+          id: 42
+          name: Ada
+  DELETE /users/{id}:
+    hidden: true
+  GET /nowhere:
+    summary: Ghost
+tags:
+  Users: Manage user accounts
+`
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	if err := os.WriteFile(path, []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users/:id", func(c *gin.Context) {})
+	r.DELETE("/users/:id", func(c *gin.Context) {})
+	gd := Mount(r, nil, Config{OverridesFile: path})
+	// Code overrides registered after Mount don't replace the file's.
+	gd.Route("GET /users/:id").Summary("From code")
+
+	spec := gd.getSpec()
+	op := spec.Paths["/users/{id}"].Get
+	if op.Summary != "Get a user" {
+		t.Errorf("summary = %q", op.Summary)
+	}
+	if len(op.Parameters) == 0 || op.Parameters[0].Description != "The user's numeric ID" {
+		t.Errorf("parameters = %+v", op.Parameters)
+	}
+	resp := op.Responses["200"]
+	if resp == nil || resp.Description != "The user" {
+		t.Fatalf("200 response = %+v", resp)
+	}
+	media := resp.Content["application/json"]
+	if example, ok := media.Example.(map[string]interface{}); !ok || example["name"] != "Ada" {
+		t.Errorf("example = %#v", media.Example)
+	}
+	if media.Schema == nil || media.Schema.Properties["name"] == nil {
+		t.Errorf("schema should be inferred from the example, got %+v", media.Schema)
+	}
+	if spec.Paths["/users/{id}"].Delete != nil {
+		t.Error("hidden route is documented")
+	}
+	if len(spec.Tags) != 1 || spec.Tags[0].Description != "Manage user accounts" {
+		t.Errorf("tags = %+v", spec.Tags)
+	}
+
+	warnings := strings.Join(gd.Warnings(), "\n")
+	for _, want := range []string{`"GET /nowhere": no such route`, `no parameter "missing"`} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings missing %q:\n%s", want, warnings)
+		}
+	}
+}
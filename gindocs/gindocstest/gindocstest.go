@@ -0,0 +1,27 @@
+// Package gindocstest provides helpers for checking handler responses
+// against the documented OpenAPI schemas in tests.
+//
+//	w := httptest.NewRecorder()
+//	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/users/1", nil))
+//	gindocstest.AssertResponseMatchesSpec(t, docs, "GET", "/api/users/1", w.Code, w.Body.Bytes())
+package gindocstest
+
+import (
+	"testing"
+
+	"github.com/MUKE-coder/gin-docs/gindocs"
+)
+
+// AssertResponseMatchesSpec reports a test error if body doesn't match the
+// schema documented for the operation and status code. path may be the route
+// ("/api/users/:id") or the request path ("/api/users/1"). It returns whether
+// the response matched.
+func AssertResponseMatchesSpec(t testing.TB, gd *gindocs.GinDocs, method, path string, status int, body []byte) bool {
+	t.Helper()
+
+	if err := gd.ValidateResponse(method, path, status, body); err != nil {
+		t.Errorf("%s %s -> %d does not match the documented response: %v", method, path, status, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package gindocstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MUKE-coder/gin-docs/gindocs"
+	"github.com/gin-gonic/gin"
+)
+
+type user struct {
+	ID    int    `json:"id" binding:"required"`
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"oneof=admin member"`
+}
+
+func TestAssertResponseMatchesSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": 1, "email": "a@example.com", "role": "admin"})
+	})
+	docs := gindocs.Mount(r, nil)
+	docs.Route("GET /users/:id").Response(200, user{}, "User")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/users/1", nil))
+	AssertResponseMatchesSpec(t, docs, "GET", "/users/1", w.Code, w.Body.Bytes())
+
+	drifted := []string{
+		`{"id": "1", "email": "a@example.com", "role": "admin"}`,
+		`{"email": "a@example.com", "role": "admin"}`,
+		`{"id": 1, "email": "a@example.com", "role": "owner"}`,
+	}
+	for _, body := range drifted {
+		if err := docs.ValidateResponse("GET", "/users/:id", 200, []byte(body)); err == nil {
+			t.Errorf("expected %s to be rejected", body)
+		}
+	}
+	if err := docs.ValidateResponse("GET", "/users/1", 500, nil); err == nil {
+		t.Error("expected an undocumented status to be rejected")
+	}
+}
+
+func TestCompareSnapshot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users/:id", func(c *gin.Context) {})
+	docs := gindocs.Mount(r, nil)
+
+	path := filepath.Join(t.TempDir(), "openapi.golden.json")
+	if err := docs.WriteSnapshot(path); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+	CompareSnapshot(t, docs, path)
+
+	if diff := lineDiff("a\nb\nc", "a\nx\nc"); !strings.Contains(diff, "line 2:") || strings.Contains(diff, "line 1:") {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+}
@@ -0,0 +1,76 @@
+package gindocstest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/MUKE-coder/gin-docs/gindocs"
+)
+
+// UpdateSnapshotsEnv is the environment variable that makes CompareSnapshot
+// rewrite golden files instead of comparing against them.
+const UpdateSnapshotsEnv = "GINDOCS_UPDATE_SNAPSHOTS"
+
+// maxDiffLines caps the differing lines reported by CompareSnapshot.
+const maxDiffLines = 20
+
+// CompareSnapshot reports a test error if the spec differs from the golden
+// file at path. Run with GINDOCS_UPDATE_SNAPSHOTS=1 to create or update it.
+func CompareSnapshot(t testing.TB, gd *gindocs.GinDocs, path string) bool {
+	t.Helper()
+
+	got, err := gd.Snapshot()
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	if os.Getenv(UpdateSnapshotsEnv) != "" {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write snapshot: %v", err)
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("read snapshot %s: %v (run with %s=1 to create it)", path, err, UpdateSnapshotsEnv)
+		return false
+	}
+	if bytes.Equal(got, want) {
+		return true
+	}
+
+	t.Errorf("spec differs from snapshot %s (run with %s=1 to update it):\n%s", path, UpdateSnapshotsEnv, lineDiff(string(want), string(got)))
+	return false
+}
+
+// lineDiff lists the lines that differ between two texts, by line number.
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	var b strings.Builder
+	reported := 0
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w == g {
+			continue
+		}
+		if reported == maxDiffLines {
+			b.WriteString("...\n")
+			break
+		}
+		fmt.Fprintf(&b, "line %d:\n  - %s\n  + %s\n", i+1, w, g)
+		reported++
+	}
+	return b.String()
+}
@@ -0,0 +1,573 @@
+package gindocs
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// goInitialisms are words written in upper case in Go identifiers.
+var goInitialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "ip": true, "json": true,
+	"sql": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// goClientReserved are the identifiers of the client runtime. Schemas with
+// these names get a "Model" suffix.
+var goClientReserved = map[string]bool{"Client": true, "New": true, "APIError": true}
+
+// GoClient returns the source of a typed Go client for the API in package pkg:
+// a struct per component schema and a Client method per operation.
+func (gd *GinDocs) GoClient(pkg string) string {
+	return GenerateGoClient(gd.getSpec(), pkg)
+}
+
+// WriteGoClient writes the Go client source to path, e.g. from a program run
+// by go generate.
+func (gd *GinDocs) WriteGoClient(path, pkg string) error {
+	return os.WriteFile(path, []byte(gd.GoClient(pkg)), 0o644)
+}
+
+// goClientGen renders a Go client for a spec.
+type goClientGen struct {
+	spec    *OpenAPISpec
+	imports map[string]bool
+}
+
+// GenerateGoClient renders a typed Go client in package pkg for any OpenAPI
+// spec, e.g. one loaded with LoadSpec.
+func GenerateGoClient(spec *OpenAPISpec, pkg string) string {
+	g := &goClientGen{spec: spec, imports: map[string]bool{
+		"bytes": true, "context": true, "encoding/json": true, "fmt": true,
+		"io": true, "net/http": true, "net/url": true, "strings": true,
+	}}
+
+	var body strings.Builder
+	body.WriteString(goClientRuntime)
+	if spec.Components != nil {
+		for _, name := range sortedKeys(spec.Components.Schemas) {
+			g.writeSchema(&body, name, spec.Components.Schemas[name])
+		}
+	}
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			if op := spec.Paths[path].GetOperation(method); op != nil {
+				g.writeOperation(&body, method, path, op)
+			}
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString("// Code generated by gindocs. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "// Package %s is a client for %s.\n", pkg, spec.Info.Title)
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", pkg)
+	for _, imp := range sortedKeys(g.imports) {
+		fmt.Fprintf(&buf, "\t%q\n", imp)
+	}
+	buf.WriteString(")\n")
+	buf.WriteString(body.String())
+
+	// Align the output the way gofmt would; fall back to the raw output.
+	if formatted, err := format.Source([]byte(buf.String())); err == nil {
+		return string(formatted)
+	}
+	return buf.String()
+}
+
+// goClientRuntime is the hand-written part of every generated client.
+const goClientRuntime = `
+// Client calls the API.
+type Client struct {
+	// BaseURL is the server URL, e.g. "https://api.example.com".
+	BaseURL string
+
+	// HTTPClient sends the requests. nil uses http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Header is added to every request, e.g. Authorization.
+	Header http.Header
+}
+
+// New returns a client for the API served at baseURL.
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), Header: http.Header{}}
+}
+
+// APIError is returned for responses outside the 2xx range.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// do sends a request and decodes a JSON response into out, if not nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body io.Reader, contentType string, out interface{}) error {
+	u := c.BaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	for name, values := range c.Header {
+		req.Header[name] = values
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(resp.Body)
+		return &APIError{StatusCode: resp.StatusCode, Body: data}
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// escapeCatchAll escapes each segment of a catch-all path parameter.
+func escapeCatchAll(value string) string {
+	segments := strings.Split(strings.TrimPrefix(value, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// jsonBody encodes v as a JSON request body.
+func jsonBody(v interface{}) (io.Reader, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+`
+
+// writeSchema writes the Go type of a component schema.
+func (g *goClientGen) writeSchema(buf *strings.Builder, name string, schema *SchemaObject) {
+	ident := schemaIdent(name)
+	buf.WriteString("\n")
+	writeGoDoc(buf, ident, schema.Description, "")
+
+	if isPlainObject(schema) {
+		fmt.Fprintf(buf, "type %s %s\n", ident, g.structType(schema, ""))
+		return
+	}
+
+	goType := g.goType(schema)
+	if strings.HasPrefix(goType, "*") {
+		goType = goType[1:]
+	}
+	if goType == "json.RawMessage" || goType == "interface{}" {
+		fmt.Fprintf(buf, "type %s = %s\n", ident, goType)
+		return
+	}
+	fmt.Fprintf(buf, "type %s %s\n", ident, goType)
+
+	// Name the values of enums.
+	if len(schema.Enum) > 0 && (goType == "string" || goType == "int64") {
+		buf.WriteString("\nconst (\n")
+		for _, v := range schema.Enum {
+			literal := fmt.Sprintf("%v", v)
+			if goType == "string" {
+				literal = fmt.Sprintf("%q", v)
+			}
+			fmt.Fprintf(buf, "\t%s%s %s = %s\n", ident, goIdent(fmt.Sprint(v)), ident, literal)
+		}
+		buf.WriteString(")\n")
+	}
+}
+
+// structType renders an object schema as a struct type. Optional fields are
+// omitted when empty.
+func (g *goClientGen) structType(schema *SchemaObject, indent string) string {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, name := range sortedKeys(schema.Properties) {
+		prop := schema.Properties[name]
+		tag := name
+		if !required[name] {
+			tag += ",omitempty"
+		}
+		fieldType := g.goType(prop)
+		if prop != nil && len(prop.Properties) > 0 && prop.Ref == "" {
+			fieldType = g.structType(prop, indent+"\t")
+		}
+		if prop != nil && prop.Description != "" {
+			writeGoDoc(&b, "", prop.Description, indent+"\t")
+		}
+		fmt.Fprintf(&b, "%s\t%s %s `json:\"%s\"`\n", indent, goIdent(name), fieldType, tag)
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// goType returns the Go type of a schema. References to object schemas and
+// nullable values are pointers.
+func (g *goClientGen) goType(schema *SchemaObject) string {
+	if schema == nil {
+		return "interface{}"
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, RefPath(""))
+		if g.spec.Components != nil {
+			if target := g.spec.Components.Schemas[name]; target != nil && isPlainObject(target) {
+				return "*" + schemaIdent(name)
+			}
+		}
+		return schemaIdent(name)
+	}
+
+	// anyOf [T, null] is a nullable T.
+	for _, variants := range [][]*SchemaObject{schema.AnyOf, schema.OneOf} {
+		if len(variants) == 2 {
+			for i, v := range variants {
+				if v != nil && v.Type == "null" {
+					return nullable(g.goType(variants[1-i]))
+				}
+			}
+		}
+	}
+	if len(schema.AllOf) == 1 {
+		return g.goType(schema.AllOf[0])
+	}
+	if len(schema.AnyOf) > 0 || len(schema.OneOf) > 0 || len(schema.AllOf) > 0 {
+		return "json.RawMessage"
+	}
+
+	var t string
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			g.imports["time"] = true
+			t = "time.Time"
+		case "binary":
+			t = "[]byte"
+		default:
+			t = "string"
+		}
+	case "integer":
+		t = "int64"
+		if schema.Format == "int32" {
+			t = "int32"
+		}
+	case "number":
+		t = "float64"
+		if schema.Format == "float" {
+			t = "float32"
+		}
+	case "boolean":
+		t = "bool"
+	case "array":
+		return "[]" + g.goType(schema.Items)
+	case "object":
+		if schema.AdditionalProperties != nil {
+			return "map[string]" + g.goType(schema.AdditionalProperties)
+		}
+		if len(schema.Properties) > 0 {
+			t = g.structType(schema, "")
+		} else {
+			return "map[string]interface{}"
+		}
+	default:
+		return "interface{}"
+	}
+	if schema.Nullable {
+		return nullable(t)
+	}
+	return t
+}
+
+// nullable returns a type that can hold null.
+func nullable(t string) string {
+	if strings.HasPrefix(t, "*") || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") ||
+		t == "interface{}" || t == "json.RawMessage" {
+		return t
+	}
+	return "*" + t
+}
+
+// writeOperation writes the params struct and Client method of an operation.
+func (g *goClientGen) writeOperation(buf *strings.Builder, method, path string, op *OperationObject) {
+	id := op.OperationID
+	if id == "" {
+		id = generateOperationID(method, path)
+	}
+	name := goIdent(id)
+
+	args := []string{"ctx context.Context"}
+	if len(op.Parameters) > 0 {
+		buf.WriteString("\n")
+		fmt.Fprintf(buf, "// %sParams holds the parameters of %s.\n", name, name)
+		fmt.Fprintf(buf, "type %sParams struct {\n", name)
+		for _, param := range op.Parameters {
+			if param.Description != "" {
+				writeGoDoc(buf, "", param.Description, "\t")
+			}
+			fmt.Fprintf(buf, "\t%s %s\n", goIdent(param.Name), g.paramType(param))
+		}
+		buf.WriteString("}\n")
+		args = append(args, "params "+name+"Params")
+	}
+
+	bodyType, contentType := "", ""
+	if op.RequestBody != nil {
+		contentTypes := sortedKeys(op.RequestBody.Content)
+		if _, ok := op.RequestBody.Content["application/json"]; ok {
+			contentType = "application/json"
+			bodyType = g.goType(op.RequestBody.Content[contentType].Schema)
+		} else if len(contentTypes) > 0 {
+			contentType = contentTypes[0]
+			bodyType = "io.Reader"
+		}
+		if bodyType != "" {
+			args = append(args, "body "+bodyType)
+		}
+	}
+
+	resultType := g.successType(op)
+	returns := "error"
+	if resultType != "" {
+		returns = "(" + resultType + ", error)"
+	}
+
+	buf.WriteString("\n")
+	doc := fmt.Sprintf("calls %s %s", method, path)
+	if op.Summary != "" {
+		doc += ": " + op.Summary
+	}
+	if op.Deprecated {
+		doc += ".\n\nDeprecated: the operation is deprecated"
+	}
+	writeGoDoc(buf, name, doc+".", "")
+	fmt.Fprintf(buf, "func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), returns)
+
+	errReturn := "return err"
+	if resultType != "" {
+		errReturn = "return " + zeroValue(resultType) + ", err"
+	}
+
+	fmt.Fprintf(buf, "\tpath := %q\n", path)
+	buf.WriteString("\tquery := url.Values{}\n\theader := http.Header{}\n")
+	for _, param := range op.Parameters {
+		field := "params." + goIdent(param.Name)
+		g.writeParam(buf, param, field)
+	}
+
+	bodyArg := "nil"
+	if bodyType == "io.Reader" {
+		bodyArg = "body"
+	} else if bodyType != "" {
+		buf.WriteString("\treqBody, err := jsonBody(body)\n\tif err != nil {\n\t\t" + errReturn + "\n\t}\n")
+		bodyArg = "reqBody"
+	}
+
+	if resultType == "" {
+		fmt.Fprintf(buf, "\treturn c.do(ctx, %q, path, query, header, %s, %q, nil)\n}\n", method, bodyArg, contentType)
+		return
+	}
+
+	target := "&out"
+	if strings.HasPrefix(resultType, "*") {
+		fmt.Fprintf(buf, "\tout := new(%s)\n", resultType[1:])
+		target = "out"
+	} else {
+		fmt.Fprintf(buf, "\tvar out %s\n", resultType)
+	}
+	fmt.Fprintf(buf, "\tif err := c.do(ctx, %q, path, query, header, %s, %q, %s); err != nil {\n\t\t%s\n\t}\n\treturn out, nil\n}\n",
+		method, bodyArg, contentType, target, errReturn)
+}
+
+// paramType returns the Go type of a parameter field. Optional scalars are
+// pointers so unset values are left out of the request.
+func (g *goClientGen) paramType(param ParameterObject) string {
+	t := g.goType(param.Schema)
+	if strings.HasPrefix(t, "*") {
+		t = t[1:]
+	}
+	if strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "interface{}" {
+		return t
+	}
+	if !param.Required {
+		return "*" + t
+	}
+	return t
+}
+
+// writeParam writes the code that adds a parameter to the request.
+func (g *goClientGen) writeParam(buf *strings.Builder, param ParameterObject, field string) {
+	t := g.paramType(param)
+	value := field
+	indent := "\t"
+	switch {
+	case strings.HasPrefix(t, "[]"):
+		fmt.Fprintf(buf, "\tfor _, v := range %s {\n", field)
+		value, indent = "v", "\t\t"
+	case strings.HasPrefix(t, "*"):
+		fmt.Fprintf(buf, "\tif %s != nil {\n", field)
+		value, indent = "*"+field, "\t\t"
+	}
+
+	switch param.In {
+	case "path":
+		// Catch-all parameters span several segments, so their slashes stay.
+		escape := "url.PathEscape"
+		if catchAll, _ := param.Extensions["x-catch-all"].(bool); catchAll {
+			escape = "escapeCatchAll"
+		}
+		fmt.Fprintf(buf, "%spath = strings.ReplaceAll(path, %q, %s(fmt.Sprint(%s)))\n", indent, "{"+param.Name+"}", escape, value)
+	case "query":
+		fmt.Fprintf(buf, "%squery.Add(%q, fmt.Sprint(%s))\n", indent, param.Name, value)
+	case "header":
+		fmt.Fprintf(buf, "%sheader.Add(%q, fmt.Sprint(%s))\n", indent, param.Name, value)
+	case "cookie":
+		fmt.Fprintf(buf, "%sheader.Add(\"Cookie\", (&http.Cookie{Name: %q, Value: fmt.Sprint(%s)}).String())\n", indent, param.Name, value)
+	}
+
+	if indent == "\t\t" {
+		buf.WriteString("\t}\n")
+	}
+}
+
+// successType returns the Go type of the lowest 2xx JSON response body, or ""
+// when the operation returns no body.
+func (g *goClientGen) successType(op *OperationObject) string {
+	for _, code := range sortedKeys(op.Responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		media, ok := op.Responses[code].Content["application/json"]
+		if !ok || media.Schema == nil {
+			return ""
+		}
+		t := g.goType(media.Schema)
+		if strings.HasPrefix(t, "struct") {
+			return "*" + t
+		}
+		return t
+	}
+	return ""
+}
+
+// zeroValue returns the zero value literal of a Go type.
+func zeroValue(t string) string {
+	switch {
+	case strings.HasPrefix(t, "*"), strings.HasPrefix(t, "[]"), strings.HasPrefix(t, "map["),
+		t == "interface{}", t == "json.RawMessage":
+		return "nil"
+	case t == "string":
+		return `""`
+	case t == "bool":
+		return "false"
+	case strings.HasPrefix(t, "int"), strings.HasPrefix(t, "float"):
+		return "0"
+	}
+	return t + "{}"
+}
+
+// goIdent converts a name into an exported Go identifier, writing common
+// initialisms in upper case ("user_id" becomes "UserID").
+func goIdent(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		// Split camelCase words so "userId" also becomes "UserID".
+		start := 0
+		runes := []rune(word)
+		for i := 1; i <= len(runes); i++ {
+			if i == len(runes) || unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+				part := string(runes[start:i])
+				if goInitialisms[strings.ToLower(part)] {
+					b.WriteString(strings.ToUpper(part))
+				} else {
+					r := []rune(part)
+					r[0] = unicode.ToUpper(r[0])
+					b.WriteString(string(r))
+				}
+				start = i
+			}
+		}
+	}
+
+	ident := b.String()
+	if ident == "" || unicode.IsDigit([]rune(ident)[0]) {
+		ident = "X" + ident
+	}
+	return ident
+}
+
+// schemaIdent returns the Go type name of a component schema, renamed when
+// it would clash with the client runtime.
+func schemaIdent(name string) string {
+	ident := goIdent(name)
+	if goClientReserved[ident] {
+		ident += "Model"
+	}
+	return ident
+}
+
+// writeGoDoc writes text as a Go doc comment starting with ident.
+func writeGoDoc(buf *strings.Builder, ident, text, indent string) {
+	text = strings.TrimSpace(text)
+	if ident != "" {
+		if text == "" {
+			return
+		}
+		if !strings.HasPrefix(text, ident+" ") {
+			text = ident + " " + lowerFirst(text)
+		}
+	}
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " ")
+		if line == "" {
+			fmt.Fprintf(buf, "%s//\n", indent)
+			continue
+		}
+		fmt.Fprintf(buf, "%s// %s\n", indent, line)
+	}
+}
+
+// lowerFirst lower-cases the first letter of text unless it starts an
+// acronym.
+func lowerFirst(text string) string {
+	r := []rune(text)
+	if len(r) > 1 && unicode.IsUpper(r[1]) {
+		return text
+	}
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
@@ -0,0 +1,124 @@
+package gindocs
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func TestGenerateGoClient(t *testing.T) {
+	spec := &OpenAPISpec{
+		Info: InfoObject{Title: "Users API"},
+		Paths: map[string]*PathItem{
+			"/users/{id}": {
+				Get: &OperationObject{
+					OperationID: "getUser",
+					Parameters: []ParameterObject{
+						{Name: "id", In: "path", Required: true, Schema: &SchemaObject{Type: "integer"}},
+						{Name: "expand", In: "query", Schema: &SchemaObject{Type: "string"}},
+					},
+					Responses: map[string]*Response{
+						"200": {Content: map[string]MediaType{
+							"application/json": {Schema: &SchemaObject{Ref: RefPath("User")}},
+						}},
+					},
+				},
+			},
+		},
+		Components: &ComponentsObject{Schemas: map[string]*SchemaObject{
+			"User": {
+				Type:     "object",
+				Required: []string{"id"},
+				Properties: map[string]*SchemaObject{
+					"id":         {Type: "integer"},
+					"created_at": {Type: "string", Format: "date-time"},
+				},
+			},
+		}},
+	}
+
+	code := GenerateGoClient(spec, "users")
+	if _, err := parser.ParseFile(token.NewFileSet(), "client.go", code, 0); err != nil {
+		t.Fatalf("generated client does not parse: %v\n%s", err, code)
+	}
+	for _, want := range []string{
+		"package users",
+		`"time"`,
+		"CreatedAt time.Time `json:\"created_at,omitempty\"`",
+		"ID        int64     `json:\"id\"`",
+		"type GetUserParams struct {",
+		"Expand *string",
+		"func (c *Client) GetUser(ctx context.Context, params GetUserParams) (*User, error) {",
+		`path = strings.ReplaceAll(path, "{id}", url.PathEscape(fmt.Sprint(params.ID)))`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in:\n%s", want, code)
+		}
+	}
+}
+
+func TestGoIdent(t *testing.T) {
+	tests := map[string]string{
+		"user_id":      "UserID",
+		"userId":       "UserID",
+		"billing.User": "BillingUser",
+		"api-key":      "APIKey",
+		"2fa":          "X2fa",
+	}
+	for in, want := range tests {
+		if got := goIdent(in); got != want {
+			t.Errorf("goIdent(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateGoClientReservedNames(t *testing.T) {
+	object := func() *SchemaObject {
+		return &SchemaObject{Type: "object", Properties: map[string]*SchemaObject{"name": {Type: "string"}}}
+	}
+	spec := &OpenAPISpec{
+		Paths: map[string]*PathItem{
+			"/files/{filepath}": {
+				Get: &OperationObject{
+					OperationID: "getFile",
+					Parameters: []ParameterObject{{
+						Name: "filepath", In: "path", Required: true, Schema: &SchemaObject{Type: "string"},
+						Extensions: map[string]interface{}{"x-catch-all": true},
+					}},
+					Responses: map[string]*Response{
+						"200": {Content: map[string]MediaType{
+							"application/json": {Schema: &SchemaObject{Ref: RefPath("Client")}},
+						}},
+					},
+				},
+			},
+		},
+		Components: &ComponentsObject{Schemas: map[string]*SchemaObject{
+			"Client": object(), "APIError": object(), "New": object(),
+		}},
+	}
+
+	code := GenerateGoClient(spec, "api")
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "client.go", code, 0)
+	if err != nil {
+		t.Fatalf("generated client does not parse: %v\n%s", err, code)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("api", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("generated client does not compile: %v\n%s", err, code)
+	}
+	for _, want := range []string{
+		"type ClientModel struct {",
+		"func (c *Client) GetFile(ctx context.Context, params GetFileParams) (*ClientModel, error) {",
+		`path = strings.ReplaceAll(path, "{filepath}", escapeCatchAll(fmt.Sprint(params.Filepath)))`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in:\n%s", want, code)
+		}
+	}
+}
@@ -8,11 +8,7 @@ import (
 
 // registerGORMModels processes registered GORM models and creates schema variants.
 func (gd *GinDocs) registerGORMModels() {
-	if len(gd.config.Models) == 0 {
-		return
-	}
-
-	for _, model := range gd.config.Models {
+	for _, model := range gd.models() {
 		t := reflect.TypeOf(model)
 		for t.Kind() == reflect.Ptr {
 			t = t.Elem()
@@ -22,21 +18,59 @@ func (gd *GinDocs) registerGORMModels() {
 			continue
 		}
 
-		name := t.Name()
-		if name == "" {
+		if t.Name() == "" {
 			continue
 		}
+		name := gd.registry.nameFor(t)
 
-		// Generate full model schema (for responses).
+		// Generate full model schema (for responses). Models are also request
+		// models through their variants, so sensitive fields are writeOnly.
 		typeToSchema(t, gd.registry)
+		if schema, ok := gd.registry.Get(name); ok {
+			markSensitive(schema, gd.config.SensitiveFieldNames)
+			// With SchemaViews, the Response view drops writeOnly fields instead.
+			if !gd.config.SchemaViews {
+				stripWriteOnly(schema)
+			}
+		}
 
 		// Generate Create variant (without auto-fields).
 		createSchema := generateCreateVariant(t, gd.registry)
-		gd.registry.Register("Create"+name, createSchema)
+		markSensitive(createSchema, gd.config.SensitiveFieldNames)
+		gd.registry.Register(variantName("Create", name), createSchema)
 
 		// Generate Update variant (all fields optional).
 		updateSchema := generateUpdateVariant(t, gd.registry)
-		gd.registry.Register("Update"+name, updateSchema)
+		markSensitive(updateSchema, gd.config.SensitiveFieldNames)
+		gd.registry.Register(variantName("Update", name), updateSchema)
+	}
+}
+
+// variantName prefixes the type name of a component name, keeping any
+// package qualifier in front: "gindocs.User" becomes "gindocs.CreateUser".
+func variantName(prefix, name string) string {
+	qualified := name
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		// Dots inside type arguments don't qualify the type.
+		qualified = name[:i]
+	}
+	i := strings.LastIndexByte(qualified, '.') + 1
+	return name[:i] + prefix + name[i:]
+}
+
+// stripWriteOnly removes writeOnly properties from a response schema.
+func stripWriteOnly(schema *SchemaObject) {
+	for prop, propSchema := range schema.Properties {
+		if !propSchema.WriteOnly {
+			continue
+		}
+		delete(schema.Properties, prop)
+		for i, r := range schema.Required {
+			if r == prop {
+				schema.Required = append(schema.Required[:i], schema.Required[i+1:]...)
+				break
+			}
+		}
 	}
 }
 
@@ -71,12 +105,7 @@ func generateCreateVariant(t reflect.Type, registry *TypeRegistry) *SchemaObject
 			continue
 		}
 
-		tagInfo := mergeTags(
-			field.Tag.Get("json"),
-			field.Tag.Get("binding"),
-			field.Tag.Get("gorm"),
-			field.Tag.Get("docs"),
-		)
+		tagInfo := registry.fieldTags(field)
 
 		if tagInfo.JSONSkip || tagInfo.GORMSkip || tagInfo.Hidden {
 			continue
@@ -121,12 +150,7 @@ func processCreateFields(t reflect.Type, schema *SchemaObject, registry *TypeReg
 			continue
 		}
 
-		tagInfo := mergeTags(
-			field.Tag.Get("json"),
-			field.Tag.Get("binding"),
-			field.Tag.Get("gorm"),
-			field.Tag.Get("docs"),
-		)
+		tagInfo := registry.fieldTags(field)
 
 		if tagInfo.JSONSkip || tagInfo.GORMSkip || tagInfo.Hidden {
 			continue
@@ -213,12 +237,7 @@ func generateUpdateVariant(t reflect.Type, registry *TypeRegistry) *SchemaObject
 			continue
 		}
 
-		tagInfo := mergeTags(
-			field.Tag.Get("json"),
-			field.Tag.Get("binding"),
-			field.Tag.Get("gorm"),
-			field.Tag.Get("docs"),
-		)
+		tagInfo := registry.fieldTags(field)
 
 		if tagInfo.JSONSkip || tagInfo.GORMSkip || tagInfo.Hidden {
 			continue
@@ -264,12 +283,7 @@ func processUpdateFields(t reflect.Type, schema *SchemaObject, registry *TypeReg
 			continue
 		}
 
-		tagInfo := mergeTags(
-			field.Tag.Get("json"),
-			field.Tag.Get("binding"),
-			field.Tag.Get("gorm"),
-			field.Tag.Get("docs"),
-		)
+		tagInfo := registry.fieldTags(field)
 
 		if tagInfo.JSONSkip || tagInfo.GORMSkip || tagInfo.Hidden {
 			continue
@@ -0,0 +1,84 @@
+package gindocs
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// parsePattern splits an optional method prefix off the group pattern and
+// compiles regular expression patterns.
+func (g *GroupOverride) parsePattern() {
+	g.pathPattern = g.pattern
+	if methods, rest, ok := strings.Cut(g.pattern, " "); ok && !strings.HasPrefix(methods, "/") && !strings.HasPrefix(methods, "^") {
+		for _, m := range strings.Split(methods, ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				g.methods = append(g.methods, strings.ToUpper(m))
+			}
+		}
+		g.pathPattern = strings.TrimSpace(rest)
+	}
+
+	if strings.HasPrefix(g.pathPattern, "^") {
+		re, err := regexp.Compile(g.pathPattern)
+		if err != nil {
+			g.problems = append(g.problems, "invalid regular expression: "+err.Error())
+			return
+		}
+		g.regexp = re
+	}
+}
+
+// matchGroupPattern checks if a path matches a group pattern. "*" matches
+// one path segment (or part of one, as in "user*"), "**" matches any number
+// of segments, and a trailing "*" matches everything below its prefix.
+func matchGroupPattern(routePath, pattern string) bool {
+	if pattern == routePath {
+		return true
+	}
+	if !strings.Contains(pattern, "*") {
+		return false
+	}
+
+	// Historical behaviour: "/api/admin/*" and "/api/admin*" are prefix matches.
+	if strings.HasSuffix(pattern, "*") && !strings.HasSuffix(pattern, "**") &&
+		!strings.Contains(strings.TrimSuffix(pattern, "*"), "*") {
+		return strings.HasPrefix(routePath, strings.TrimSuffix(strings.TrimSuffix(pattern, "*"), "/"))
+	}
+
+	if strings.HasSuffix(pattern, "/*") {
+		pattern += "*"
+	}
+	return matchSegments(splitPath(routePath), splitPath(pattern))
+}
+
+// matchSegments matches path segments against glob segments.
+func matchSegments(segments, patterns []string) bool {
+	if len(patterns) == 0 {
+		return len(segments) == 0
+	}
+	if patterns[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(segments[i:], patterns[1:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, err := path.Match(patterns[0], segments[0]); err != nil || !ok {
+		return false
+	}
+	return matchSegments(segments[1:], patterns[1:])
+}
+
+// splitPath splits a route path into its segments.
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
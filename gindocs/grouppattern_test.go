@@ -0,0 +1,48 @@
+package gindocs
+
+import "testing"
+
+func TestMatchGroupPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/api/admin/*", "/api/admin/users/:id", true},
+		{"/api/admin/*", "/api/users", false},
+		{"/api/admin*", "/api/admins", true},
+		{"/api/users", "/api/users", true},
+		{"/api/*/admin/**", "/api/v1/admin/users/:id", true},
+		{"/api/*/admin/**", "/api/v1/admin", true},
+		{"/api/*/admin/**", "/api/v1/v2/admin/users", false},
+		{"/api/**/comments", "/api/posts/:id/comments", true},
+		{"/api/**/comments", "/api/posts/:id/likes", false},
+		{"/api/user*/:id", "/api/users/:id", true},
+		{"/api/*/admin/*", "/api/v1/admin/users/:id", true},
+	}
+	for _, tt := range tests {
+		if got := matchGroupPattern(tt.path, tt.pattern); got != tt.want {
+			t.Errorf("matchGroupPattern(%q, %q) = %v, want %v", tt.path, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestGroupPatternMethodsAndRegex(t *testing.T) {
+	g := &GroupOverride{pattern: "POST,put /api/posts/**"}
+	g.parsePattern()
+	if !g.matches("PUT", "/api/posts/:id") || g.matches("GET", "/api/posts/:id") || g.matches("POST", "/api/users") {
+		t.Errorf("method-prefixed pattern matched incorrectly: %+v", g)
+	}
+
+	g = &GroupOverride{pattern: `^/api/v[0-9]+/users`}
+	g.parsePattern()
+	if !g.matches("GET", "/api/v2/users/:id") || g.matches("GET", "/api/beta/users") {
+		t.Errorf("regex pattern matched incorrectly")
+	}
+
+	g = &GroupOverride{pattern: "^/api/(users"}
+	g.parsePattern()
+	if len(g.problems) != 1 || g.matches("GET", "/api/users") {
+		t.Errorf("expected invalid regex to be reported and match nothing, got %v", g.problems)
+	}
+}
@@ -0,0 +1,190 @@
+package gindocs
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// guide is a markdown page of the guides directory.
+type guide struct {
+	// slug is the file path without the .md extension, e.g.
+	// "advanced/webhooks".
+	slug  string
+	title string
+}
+
+// guidesFS returns the guides file system: Config.Guides, else
+// Config.GuidesDir, else nil.
+func (gd *GinDocs) guidesFS() fs.FS {
+	if gd.config.Guides != nil {
+		return gd.config.Guides
+	}
+	if gd.config.GuidesDir != "" {
+		return os.DirFS(gd.config.GuidesDir)
+	}
+	return nil
+}
+
+// listGuides returns the markdown files of fsys, index pages first within
+// their directory and the rest by path. Hidden files are skipped.
+func listGuides(fsys fs.FS) ([]guide, error) {
+	var guides []guide
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name != "." && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !strings.HasSuffix(name, ".md") {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		slug := strings.TrimSuffix(name, ".md")
+		guides = append(guides, guide{slug: slug, title: guideTitle(slug, string(data))})
+		return nil
+	})
+
+	sort.SliceStable(guides, func(i, j int) bool {
+		a, b := guides[i].slug, guides[j].slug
+		if path.Dir(a) == path.Dir(b) && (path.Base(a) == "index") != (path.Base(b) == "index") {
+			return path.Base(a) == "index"
+		}
+		return a < b
+	})
+	return guides, err
+}
+
+// guideTitle returns the first top-level heading of a guide, or its file
+// name with dashes and underscores turned into spaces.
+func guideTitle(slug, content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if title, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok {
+			return strings.TrimSpace(title)
+		}
+	}
+	name := strings.NewReplacer("-", " ", "_", " ").Replace(path.Base(slug))
+	return capitalize(name)
+}
+
+// hiddenPath reports whether any segment of a slash-separated path starts
+// with a dot. listGuides skips such files, so handleGuide must too.
+func hiddenPath(name string) bool {
+	for _, segment := range strings.Split(name, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// handleGuide serves a guide rendered as HTML, with links to every guide.
+// Other files of the guides directory, such as images, are served as-is.
+func (gd *GinDocs) handleGuide(c *gin.Context) {
+	fsys := gd.guidesFS()
+	name := strings.Trim(c.Param("path"), "/")
+	if name == "" {
+		name = "index"
+	}
+	// Hidden files, such as .env or .drafts/, are never served.
+	if !fs.ValidPath(name) || hiddenPath(name) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "guide not found"})
+		return
+	}
+
+	if !strings.HasSuffix(name, ".md") {
+		if info, err := fs.Stat(fsys, name); err == nil && !info.IsDir() {
+			c.FileFromFS(name, http.FS(fsys))
+			return
+		}
+	}
+
+	guides, err := listGuides(fsys)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read guides: " + err.Error()})
+		return
+	}
+	slug := strings.TrimSuffix(name, ".md")
+	content, err := fs.ReadFile(fsys, slug+".md")
+	if err != nil {
+		if slug == "index" && len(guides) > 0 {
+			// Without an index page, the guides start at the first one.
+			c.Redirect(http.StatusFound, gd.docsURL(c)+"/guides/"+guides[0].slug)
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "guide not found"})
+		return
+	}
+
+	title := gd.config.Title
+	if title == "" {
+		title = "API Documentation"
+	}
+	html := renderGuideHTML(title, gd.docsURL(c), guides, guide{slug: slug, title: guideTitle(slug, string(content))}, string(content))
+	gd.writeHTML(c, html)
+}
+
+// renderGuideHTML renders a guide page with a navigation sidebar.
+func renderGuideHTML(title, docsURL string, guides []guide, current guide, content string) string {
+	var nav strings.Builder
+	for _, g := range guides {
+		class := ""
+		if g.slug == current.slug {
+			class = ` class="current"`
+		}
+		fmt.Fprintf(&nav, `<a href="%s"%s>%s</a>`,
+			template.HTMLEscapeString(docsURL+"/guides/"+g.slug), class, template.HTMLEscapeString(g.title))
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>%s — %s</title>
+    <base href="%s">
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 0; display: flex; min-height: 100vh; color: #1a1a2e; }
+        nav { width: 240px; flex-shrink: 0; background: #f5f5fa; border-right: 1px solid #e2e2ea; padding: 24px 16px; box-sizing: border-box; }
+        nav .api { display: block; font-weight: 600; margin-bottom: 16px; color: #1a1a2e; text-decoration: none; }
+        nav a { display: block; padding: 6px 10px; border-radius: 4px; color: #4a4a6a; text-decoration: none; font-size: 14px; }
+        nav a:hover { background: #e8e8f2; }
+        nav a.current { background: #6c63ff; color: #fff; }
+        main { flex: 1; max-width: 860px; padding: 24px 48px; line-height: 1.7; }
+        %s
+    </style>
+</head>
+<body>
+    <nav>
+        <a class="api" href="%s">← %s</a>
+        %s
+    </nav>
+    <main class="gd-markdown">
+%s
+    </main>
+</body>
+</html>`,
+		template.HTMLEscapeString(current.title),
+		template.HTMLEscapeString(title),
+		template.HTMLEscapeString(docsURL+"/guides/"+current.slug),
+		markdownCSS,
+		template.HTMLEscapeString(docsURL),
+		template.HTMLEscapeString(title),
+		nav.String(),
+		markdownToHTML(content, 0),
+	)
+}
@@ -0,0 +1,69 @@
+package gindocs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGuides(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	Mount(r, nil, Config{Guides: fstest.MapFS{
+		"getting-started.md": {Data: []byte("# Getting Started\n\nRead [auth](advanced/auth.md).")},
+		"advanced/auth.md":   {Data: []byte("Send a **token**.")},
+		"images/diagram.svg": {Data: []byte("<svg></svg>")},
+		".drafts/secret.md":  {Data: []byte("# Secret")},
+		".env":               {Data: []byte("DB_PASSWORD=hunter2")},
+	}})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	// Without an index.md, the guides start at the first one.
+	if w := get("/docs/guides"); w.Code != http.StatusFound || w.Header().Get("Location") != "/docs/guides/advanced/auth" {
+		t.Errorf("GET /docs/guides = %d %q", w.Code, w.Header().Get("Location"))
+	}
+
+	w := get("/docs/guides/getting-started")
+	body := w.Body.String()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	for _, want := range []string{
+		"<title>Getting Started — API Documentation</title>",
+		`<h1>Getting Started</h1>`,
+		`<a href="advanced/auth.md"`,
+		`<a href="/docs/guides/advanced/auth">Auth</a>`,
+		`<a href="/docs/guides/getting-started" class="current">Getting Started</a>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected guide page to contain %q", want)
+		}
+	}
+	if strings.Contains(body, "Secret") {
+		t.Error("expected hidden guides to be skipped")
+	}
+
+	if w := get("/docs/guides/advanced/auth.md"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "<strong>token</strong>") {
+		t.Errorf("GET auth.md = %d", w.Code)
+	}
+	if w := get("/docs/guides/images/diagram.svg"); w.Code != http.StatusOK || w.Body.String() != "<svg></svg>" {
+		t.Errorf("GET diagram.svg = %d %q", w.Code, w.Body.String())
+	}
+	for _, hidden := range []string{"/docs/guides/.env", "/docs/guides/.drafts/secret", "/docs/guides/.drafts/secret.md"} {
+		if w := get(hidden); w.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", hidden, w.Code)
+		}
+	}
+	if w := get("/docs/guides/missing"); w.Code != http.StatusNotFound {
+		t.Errorf("GET missing guide = %d, want 404", w.Code)
+	}
+}
@@ -1,10 +1,16 @@
 package gindocs
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/schema"
 )
 
 // registerHandlers sets up all documentation-related HTTP handlers on the router.
@@ -15,12 +21,63 @@ func (gd *GinDocs) registerHandlers() {
 	gd.router.GET(prefix+"/", gd.handleUI)
 	gd.router.GET(prefix+"/openapi.json", gd.handleSpecJSON)
 	gd.router.GET(prefix+"/openapi.yaml", gd.handleSpecYAML)
+	gd.router.GET(prefix+"/openapi", gd.handleSpec)
 	gd.router.GET(prefix+"/export/postman", gd.handleExportPostman)
+	gd.router.GET(prefix+"/export/postman/environment", gd.handleExportPostmanEnvironment)
 	gd.router.GET(prefix+"/export/insomnia", gd.handleExportInsomnia)
+	gd.router.GET(prefix+"/export/factories/go", gd.handleExportGoFactories)
+	gd.router.GET(prefix+"/export/factories/ts", gd.handleExportTSFactories)
+	gd.router.GET(prefix+"/export/typescript", gd.handleExportTypeScript)
+	gd.router.GET(prefix+"/export/go-client", gd.handleExportGoClient)
+	gd.router.GET(prefix+"/export/zod", gd.handleExportZod)
+	gd.router.GET(prefix+"/export/http", gd.handleExportHTTPFile)
+	gd.router.GET(prefix+"/export/k6", gd.handleExportK6)
+	gd.router.GET(prefix+"/export/markdown", gd.handleExportMarkdown)
+	gd.router.GET(prefix+"/export/html", gd.handleExportHTML)
+	gd.router.GET(prefix+"/export/sql", gd.handleExportSQL)
+	gd.router.GET(prefix+"/export/inventory.csv", gd.handleExportInventory)
+	gd.router.GET(prefix+"/export/manifest.json", gd.handleExportManifest)
+	gd.router.GET(prefix+"/diff", gd.handleDiff)
+	gd.router.GET(prefix+"/usage", gd.handleUsage)
+	gd.router.GET(prefix+"/coverage", gd.handleCoverage)
+	gd.router.GET(prefix+"/lifecycle", gd.handleLifecycle)
+	gd.router.GET(prefix+"/lifecycle.json", gd.handleLifecycleJSON)
+	gd.router.GET(prefix+"/op/:operationId", gd.handleOperationLink)
+
+	if gd.config.LandingPage != nil {
+		gd.router.GET(prefix+"/reference", gd.handleUI)
+	}
+
+	if gd.guidesFS() != nil {
+		gd.router.GET(prefix+"/guides", gd.handleGuide)
+		gd.router.GET(prefix+"/guides/*path", gd.handleGuide)
+	}
+
+	if gd.config.DevMode {
+		gd.router.GET(prefix+"/edit", gd.handleEditor)
+		gd.router.GET(prefix+"/warnings", gd.handleWarnings)
+	}
+
+	if gd.config.MockServer {
+		gd.router.Any(prefix+"/mock/*path", gd.handleMock)
+	}
+
+	// The seed endpoint writes to the database, so it needs a token unless
+	// in DevMode.
+	if gd.config.SandboxSeed && (gd.config.DevMode || gd.config.SandboxSeedToken != "") {
+		gd.router.POST(prefix+"/sandbox/seed", gd.handleSandboxSeed)
+	}
 }
 
-// handleUI serves the documentation UI page.
+// handleUI serves the documentation UI page, or the landing page when one
+// is configured and no UI is requested.
 func (gd *GinDocs) handleUI(c *gin.Context) {
+	if gd.config.LandingPage != nil && c.GetString(versionContextKey) == "" &&
+		c.Query("ui") == "" && c.FullPath() != gd.config.Prefix+"/reference" {
+		gd.handleLanding(c)
+		return
+	}
+
 	uiType := gd.config.UI
 	if q := c.Query("ui"); q != "" {
 		switch q {
@@ -31,26 +88,68 @@ func (gd *GinDocs) handleUI(c *gin.Context) {
 		}
 	}
 
-	specURL := gd.config.Prefix + "/openapi.json"
+	docsURL := gd.docsURL(c)
+	version := c.GetString(versionContextKey)
+	specURL := docsURL + "/openapi.json"
+	if version != "" {
+		specURL = docsURL + "/" + version + "/openapi.json"
+	}
+	filters := url.Values{}
+	for _, param := range specFilterParams {
+		if v := c.Query(param); v != "" {
+			filters.Set(param, v)
+		}
+	}
+	if len(filters) > 0 {
+		specURL += "?" + filters.Encode()
+	}
 	title := gd.config.Title
 	if title == "" {
 		title = "API Documentation"
 	}
 
+	cfg := gd.config
+	cfg.CustomSections = gd.uiSections()
+
 	var html string
 	switch uiType {
 	case UIScalar:
-		html = renderScalarHTML(title, specURL, gd.config)
+		html = renderScalarHTML(title, uiSource{specURL: specURL}, gd.versionSwitcherHTML(docsURL, version), cfg)
 	default:
-		html = renderSwaggerHTML(title, specURL, gd.config)
+		html = renderSwaggerHTML(title, uiSource{specURL: specURL}, gd.versionSwitcherHTML(docsURL, version), cfg)
 	}
 
-	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+	gd.writeHTML(c, html)
+}
+
+// handleSpec serves the OpenAPI specification as JSON or YAML, chosen by
+// ?format= or else the Accept header, so tools can share one URL.
+func (gd *GinDocs) handleSpec(c *gin.Context) {
+	c.Header("Vary", "Accept")
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		// The first of yaml and json listed in Accept wins.
+		format = "json"
+		accept := strings.ToLower(c.GetHeader("Accept"))
+		if y, j := strings.Index(accept, "yaml"), strings.Index(accept, "json"); y >= 0 && (j < 0 || y < j) {
+			format = "yaml"
+		}
+	}
+
+	switch format {
+	case "json":
+		gd.handleSpecJSON(c)
+	case "yaml", "yml":
+		gd.handleSpecYAML(c)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported format " + format + "; use json or yaml"})
+	}
 }
 
 // handleSpecJSON serves the OpenAPI specification as JSON.
 func (gd *GinDocs) handleSpecJSON(c *gin.Context) {
-	spec := gd.getSpec()
+	spec := gd.requestSpec(c)
 
 	data, err := json.MarshalIndent(spec, "", "  ")
 	if err != nil {
@@ -64,7 +163,7 @@ func (gd *GinDocs) handleSpecJSON(c *gin.Context) {
 
 // handleSpecYAML serves the OpenAPI specification as YAML.
 func (gd *GinDocs) handleSpecYAML(c *gin.Context) {
-	spec := gd.getSpec()
+	spec := gd.requestSpec(c)
 
 	data, err := specToYAML(spec)
 	if err != nil {
@@ -78,8 +177,8 @@ func (gd *GinDocs) handleSpecYAML(c *gin.Context) {
 
 // handleExportPostman exports the API as a Postman v2.1 collection.
 func (gd *GinDocs) handleExportPostman(c *gin.Context) {
-	spec := gd.getSpec()
-	collection := generatePostmanCollection(spec)
+	spec := gd.requestSpec(c)
+	collection := generatePostmanCollection(spec, gd.authSchemeName())
 
 	data, err := json.MarshalIndent(collection, "", "  ")
 	if err != nil {
@@ -91,9 +190,46 @@ func (gd *GinDocs) handleExportPostman(c *gin.Context) {
 	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
 }
 
-// handleExportInsomnia exports the API as an Insomnia v4 export.
+// handleExportPostmanEnvironment exports a Postman environment for the
+// server chosen by ?server= (an index into the spec's servers, default 0).
+func (gd *GinDocs) handleExportPostmanEnvironment(c *gin.Context) {
+	index, err := strconv.Atoi(c.DefaultQuery("server", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "server must be a server index"})
+		return
+	}
+	env, ok := generatePostmanEnvironment(gd.requestSpec(c), index, gd.authSchemeName())
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no server at index " + strconv.Itoa(index)})
+		return
+	}
+
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate Postman environment"})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\"postman_environment.json\"")
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// handleExportInsomnia exports the API as an Insomnia v4 export, or as an
+// Insomnia v5 insomnia.yaml when ?format=yaml.
 func (gd *GinDocs) handleExportInsomnia(c *gin.Context) {
-	spec := gd.getSpec()
+	spec := gd.requestSpec(c)
+
+	if c.Query("format") == "yaml" {
+		data, err := toYAML(generateInsomniaV5(spec))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate Insomnia export"})
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=\"insomnia.yaml\"")
+		c.Data(http.StatusOK, "application/x-yaml; charset=utf-8", data)
+		return
+	}
+
 	export := generateInsomniaExport(spec)
 
 	data, err := json.MarshalIndent(export, "", "  ")
@@ -105,3 +241,168 @@ func (gd *GinDocs) handleExportInsomnia(c *gin.Context) {
 	c.Header("Content-Disposition", "attachment; filename=\"insomnia_export.json\"")
 	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
 }
+
+// handleExportGoFactories exports Go factory functions for every schema.
+func (gd *GinDocs) handleExportGoFactories(c *gin.Context) {
+	pkg := c.DefaultQuery("package", "factories")
+	code := generateGoFactories(gd.requestSpec(c), pkg)
+
+	c.Header("Content-Disposition", "attachment; filename=\"factories.go\"")
+	c.Data(http.StatusOK, "text/x-go; charset=utf-8", []byte(code))
+}
+
+// handleExportTSFactories exports TypeScript factory functions for every schema.
+func (gd *GinDocs) handleExportTSFactories(c *gin.Context) {
+	code := generateTSFactories(gd.requestSpec(c))
+
+	c.Header("Content-Disposition", "attachment; filename=\"factories.ts\"")
+	c.Data(http.StatusOK, "application/typescript; charset=utf-8", []byte(code))
+}
+
+// handleExportTypeScript exports TypeScript types for the schemas and
+// operations.
+func (gd *GinDocs) handleExportTypeScript(c *gin.Context) {
+	code := generateTypeScript(gd.requestSpec(c))
+
+	c.Header("Content-Disposition", "attachment; filename=\"api.ts\"")
+	c.Data(http.StatusOK, "application/typescript; charset=utf-8", []byte(code))
+}
+
+// handleExportHTTPFile exports a .http file with a request per operation.
+func (gd *GinDocs) handleExportHTTPFile(c *gin.Context) {
+	data := generateHTTPFile(gd.requestSpec(c))
+
+	c.Header("Content-Disposition", "attachment; filename=\"api.http\"")
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(data))
+}
+
+// handleExportK6 exports a k6 load-test script.
+func (gd *GinDocs) handleExportK6(c *gin.Context) {
+	script := generateK6(gd.requestSpec(c))
+
+	c.Header("Content-Disposition", "attachment; filename=\"k6-script.js\"")
+	c.Data(http.StatusOK, "application/javascript; charset=utf-8", []byte(script))
+}
+
+// handleExportMarkdown exports the documentation as a Markdown document.
+func (gd *GinDocs) handleExportMarkdown(c *gin.Context) {
+	data := generateMarkdown(gd.requestSpec(c), gd.uiSections())
+
+	c.Header("Content-Disposition", "attachment; filename=\"api.md\"")
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(data))
+}
+
+// handleExportHTML exports the documentation as an offline HTML page, or as
+// a zip with the page and the spec when ?format=zip.
+func (gd *GinDocs) handleExportHTML(c *gin.Context) {
+	ui := gd.config.UI
+	switch c.Query("ui") {
+	case "scalar":
+		ui = UIScalar
+	case "swagger":
+		ui = UISwagger
+	}
+	spec := gd.requestSpec(c)
+
+	if c.Query("format") == "zip" {
+		var buf bytes.Buffer
+		if err := gd.writeHTMLZip(&buf, spec, ui); err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=\"api-docs.zip\"")
+		c.Data(http.StatusOK, "application/zip", buf.Bytes())
+		return
+	}
+
+	html, err := gd.staticHTML(spec, ui)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=\"api-docs.html\"")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+}
+
+// handleExportZod exports Zod schemas for every schema.
+func (gd *GinDocs) handleExportZod(c *gin.Context) {
+	code := generateZod(gd.requestSpec(c))
+
+	c.Header("Content-Disposition", "attachment; filename=\"schemas.ts\"")
+	c.Data(http.StatusOK, "application/typescript; charset=utf-8", []byte(code))
+}
+
+// handleExportGoClient exports a typed Go client for the API.
+func (gd *GinDocs) handleExportGoClient(c *gin.Context) {
+	pkg := c.DefaultQuery("package", "client")
+	code := GenerateGoClient(gd.requestSpec(c), pkg)
+
+	c.Header("Content-Disposition", "attachment; filename=\"client.go\"")
+	c.Data(http.StatusOK, "text/x-go; charset=utf-8", []byte(code))
+}
+
+// handleExportSQL exports CREATE TABLE statements for the registered models.
+func (gd *GinDocs) handleExportSQL(c *gin.Context) {
+	var namer schema.Namer
+	if gd.db != nil {
+		namer = gd.db.NamingStrategy
+	}
+	ddl := generateSQLDDL(gd.models(), namer)
+
+	c.Header("Content-Disposition", "attachment; filename=\"schema.sql\"")
+	c.Data(http.StatusOK, "application/sql; charset=utf-8", []byte(ddl))
+}
+
+// handleExportInventory exports the endpoint inventory as CSV.
+func (gd *GinDocs) handleExportInventory(c *gin.Context) {
+	data, err := generateInventoryCSV(gd.requestSpec(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate inventory"})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\"inventory.csv\"")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+// handleExportManifest exports the flat operations manifest as JSON.
+func (gd *GinDocs) handleExportManifest(c *gin.Context) {
+	c.JSON(http.StatusOK, generateManifest(gd.requestSpec(c)))
+}
+
+// handleDiff reports changes against the baseline spec and a suggested
+// version bump. Both sides are narrowed to the operations the request sees.
+func (gd *GinDocs) handleDiff(c *gin.Context) {
+	if gd.config.BaselineSpec == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no baseline spec configured"})
+		return
+	}
+
+	diff, err := gd.diffAgainst(gd.requestSpec(c), func(baseline *OpenAPISpec) *OpenAPISpec {
+		return gd.requestOperations(c, baseline)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.JSON(http.StatusOK, diff)
+}
+
+// handleLifecycle serves the deprecation and sunset dashboard page.
+func (gd *GinDocs) handleLifecycle(c *gin.Context) {
+	title := gd.config.Title
+	if title == "" {
+		title = "API Documentation"
+	}
+
+	html := renderLifecycleHTML(title, lifecycleEntries(gd.requestSpec(c), time.Now()))
+	gd.writeHTML(c, html)
+}
+
+// handleLifecycleJSON serves the deprecation and sunset report as JSON.
+func (gd *GinDocs) handleLifecycleJSON(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache")
+	c.JSON(http.StatusOK, gin.H{"deprecated": lifecycleEntries(gd.requestSpec(c), time.Now())})
+}
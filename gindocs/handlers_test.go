@@ -0,0 +1,260 @@
+package gindocs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandleSpecNegotiation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", func(c *gin.Context) {})
+	Mount(r, nil)
+
+	tests := []struct {
+		url, accept, wantType string
+		wantStatus            int
+	}{
+		{"/docs/openapi", "", "application/json", http.StatusOK},
+		{"/docs/openapi", "application/yaml", "application/x-yaml", http.StatusOK},
+		{"/docs/openapi", "application/json, application/yaml", "application/json", http.StatusOK},
+		{"/docs/openapi?format=yaml", "application/json", "application/x-yaml", http.StatusOK},
+		{"/docs/openapi?format=xml", "", "application/json", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tt.wantStatus || !strings.HasPrefix(w.Header().Get("Content-Type"), tt.wantType) {
+			t.Errorf("%s (Accept %q): got %d %s", tt.url, tt.accept, w.Code, w.Header().Get("Content-Type"))
+		}
+	}
+}
+
+func TestVersionSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/users", func(c *gin.Context) {})
+	r.GET("/api/v2/users", func(c *gin.Context) {})
+	docs := Mount(r, nil)
+	docs.Version("v1", PrefixFilter("/api/v1"))
+	docs.Version("v2", PrefixFilter("/api/v2"))
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/v1/openapi.json", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	body := w.Body.String()
+	if w.Code != http.StatusOK || !strings.Contains(body, "/api/v1/users") || strings.Contains(body, "/api/v2/users") {
+		t.Errorf("v1 spec: got %d %s", w.Code, body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/docs/v2", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	body = w.Body.String()
+	if !strings.Contains(body, "/docs/v2/openapi.json") || !strings.Contains(body, `<option value="/docs/v2" selected>v2</option>`) {
+		t.Errorf("v2 UI missing spec URL or selected version: %s", body)
+	}
+
+	// Taken names are reported instead of panicking on the duplicate route.
+	docs.Version("v1", PrefixFilter("/api/v2"))
+	docs.Version("diff")
+	if len(docs.versions) != 2 || docs.versions[0].filters == nil {
+		t.Errorf("versions = %+v", docs.versions)
+	}
+	warnings := strings.Join(docs.Warnings(), "\n")
+	if !strings.Contains(warnings, `Version("v1"): GET /docs/v1 is already registered`) {
+		t.Errorf("expected a warning for the duplicate version, got:\n%s", warnings)
+	}
+	if !strings.Contains(warnings, `Version("diff"): GET /docs/diff is already registered`) {
+		t.Errorf("expected a warning for the docs route, got:\n%s", warnings)
+	}
+}
+
+func TestMultipleMounts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	r.GET("/admin/stats", func(c *gin.Context) {})
+	public := Mount(r, nil, Config{ExcludePrefixes: []string{"/admin"}})
+	admin := Mount(r, nil, Config{Prefix: "/internal/docs", IncludePrefixes: []string{"/admin"}})
+	admin.Version("v1")
+	public.Route("GET /users").Summary("Public users")
+	admin.Route("GET /admin/stats").Summary("Admin stats")
+
+	publicSpec := public.getSpec()
+	if len(publicSpec.Paths) != 1 || publicSpec.Paths["/users"] == nil {
+		t.Errorf("public paths = %v, want only /users", sortedKeys(publicSpec.Paths))
+	}
+	adminSpec := admin.getSpec()
+	if len(adminSpec.Paths) != 1 || adminSpec.Paths["/admin/stats"] == nil {
+		t.Fatalf("admin paths = %v, want only /admin/stats", sortedKeys(adminSpec.Paths))
+	}
+	if got := adminSpec.Paths["/admin/stats"].Get.Summary; got != "Admin stats" {
+		t.Errorf("admin summary = %q", got)
+	}
+}
+
+func TestRequestServerURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", func(c *gin.Context) {})
+	Mount(r, nil, Config{TrustedProxies: []string{"10.0.0.0/8"}})
+
+	tests := []struct {
+		remoteAddr string
+		want       string
+	}{
+		{"10.1.2.3:4000", `"https://api.example.com"`},
+		{"203.0.113.9:4000", `"http://internal:8080"`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "http://internal:8080/docs/openapi.json", nil)
+		req.RemoteAddr = tt.remoteAddr
+		req.Header.Set("X-Forwarded-Proto", "https")
+		req.Header.Set("X-Forwarded-Host", "api.example.com, proxy.local")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("from %s: want %s in %s", tt.remoteAddr, tt.want, w.Body.String())
+		}
+	}
+}
+
+func TestBasePath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", func(c *gin.Context) {})
+	Mount(r, nil, Config{BasePath: "/myapp/", TrustedProxies: []string{"10.0.0.1"}})
+
+	tests := []struct {
+		remoteAddr, forwardedPrefix, want string
+	}{
+		{"192.0.2.1:4000", "", "/myapp/docs/openapi.json"},
+		{"10.0.0.1:4000", "/gateway", "/gateway/docs/openapi.json"},
+		{"192.0.2.1:4000", "/gateway", "/myapp/docs/openapi.json"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+		req.RemoteAddr = tt.remoteAddr
+		if tt.forwardedPrefix != "" {
+			req.Header.Set("X-Forwarded-Prefix", tt.forwardedPrefix)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("from %s with prefix %q: spec URL %s not in page", tt.remoteAddr, tt.forwardedPrefix, tt.want)
+		}
+	}
+}
+
+func TestDisabledMount(t *testing.T) {
+	defer gin.SetMode(gin.TestMode)
+	tests := []struct {
+		mode   string
+		config Config
+		want   int
+	}{
+		{gin.TestMode, Config{}, http.StatusOK},
+		{gin.TestMode, Config{Disabled: true}, http.StatusNotFound},
+		{gin.ReleaseMode, Config{}, http.StatusNotFound},
+		{gin.ReleaseMode, Config{EnabledEnvironments: []string{gin.ReleaseMode}}, http.StatusOK},
+		{gin.DebugMode, Config{EnabledEnvironments: []string{gin.ReleaseMode}}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		gin.SetMode(tt.mode)
+		r := gin.New()
+		r.GET("/ping", func(c *gin.Context) {})
+		docs := Mount(r, nil, tt.config)
+		docs.Version("v1", PrefixFilter("/ping"))
+
+		for _, url := range []string{"/docs/openapi.json", "/docs/v1/openapi.json"} {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+			if w.Code != tt.want {
+				t.Errorf("%s %+v %s: got %d, want %d", tt.mode, tt.config, url, w.Code, tt.want)
+			}
+		}
+	}
+}
+
+func TestDisabledMountOverridesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	if err := os.WriteFile(path, []byte("routes:\n  GET /ping:\n    summary: Health check\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", func(c *gin.Context) {})
+	docs := Mount(r, nil, Config{Disabled: true, OverridesFile: path})
+	if got := docs.Spec().Paths["/ping"].Get.Summary; got != "Health check" {
+		t.Errorf("summary = %q, want the overrides file's", got)
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	docs := Mount(r, nil)
+	if spec := docs.getSpec(); spec.Paths["/plugins"] != nil {
+		t.Fatal("unexpected /plugins before it is registered")
+	}
+
+	// Routes registered after the first build are picked up once the cache
+	// is invalidated or the spec rebuilt.
+	r.GET("/plugins", func(c *gin.Context) {})
+	if spec := docs.getSpec(); spec.Paths["/plugins"] != nil {
+		t.Fatal("expected the cached spec to be served")
+	}
+	docs.InvalidateCache()
+	if spec := docs.getSpec(); spec.Paths["/plugins"] == nil {
+		t.Error("expected /plugins after InvalidateCache")
+	}
+
+	r.GET("/flags", func(c *gin.Context) {})
+	docs.Rebuild()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	if !strings.Contains(w.Body.String(), `"/flags"`) {
+		t.Error("expected /flags after Rebuild")
+	}
+}
+
+// TestRebuildConcurrent is meant for go test -race: rebuilds must not race
+// requests reading the spec.
+func TestRebuildConcurrent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	docs := Mount(r, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			docs.Rebuild()
+		}()
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+			if w.Code != http.StatusOK {
+				t.Errorf("got %d", w.Code)
+			}
+		}()
+	}
+	wg.Wait()
+}
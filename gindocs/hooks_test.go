@@ -0,0 +1,53 @@
+package gindocs
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOperationHook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	r.GET("/internal/stats", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{OperationHook: func(route RouteMetadata, op *OperationObject) {
+		if route.Path == "/internal/stats" {
+			op.Summary = "Internal: " + op.Summary
+			op.Security = []SecurityRequirement{}
+		}
+	}})
+	gd.Route("GET /internal/stats").Summary("Usage stats")
+
+	paths := gd.Spec().Paths
+	stats := paths["/internal/stats"].Get
+	if stats.Summary != "Internal: Usage stats" || stats.Security == nil {
+		t.Errorf("expected the hook to run after overrides, got %+v", stats)
+	}
+	if users := paths["/users"].Get; users.Security != nil {
+		t.Errorf("hook changed another route: %+v", users)
+	}
+}
+
+func TestSpecHook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+
+	var calls int
+	gd := Mount(r, nil, Config{SpecHook: func(spec *OpenAPISpec) {
+		calls++
+		spec.Info.Title = "Patched"
+		delete(spec.Paths, "/users")
+	}})
+
+	spec := gd.Spec()
+	if spec.Info.Title != "Patched" || spec.Paths["/users"] != nil {
+		t.Errorf("expected the hook's changes in the spec, got %q %v", spec.Info.Title, sortedKeys(spec.Paths))
+	}
+	gd.Spec()
+	if calls != 1 {
+		t.Errorf("hook called %d times, want once per build", calls)
+	}
+}
@@ -0,0 +1,136 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// generateHTTPFile renders a .http file (VS Code REST Client, JetBrains HTTP
+// Client) with one request per operation. The base URL, credentials and path
+// parameters are file variables; request bodies are example JSON built from
+// the schemas.
+func generateHTTPFile(spec *OpenAPISpec) string {
+	var schemas map[string]*SchemaObject
+	var schemes map[string]*SecuritySchemeObject
+	if spec.Components != nil {
+		schemas = spec.Components.Schemas
+		schemes = spec.Components.SecuritySchemes
+	}
+
+	baseURL := "http://localhost:8080"
+	if len(spec.Servers) > 0 {
+		baseURL = spec.Servers[0].ResolvedURL()
+	}
+
+	vars := map[string]string{}
+	var requests strings.Builder
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+			security := op.Security
+			if security == nil {
+				security = spec.Security
+			}
+			writeHTTPRequest(&requests, method, path, op, security, schemes, schemas, vars)
+		}
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "# %s\n# Code generated by gindocs. DO NOT EDIT.\n\n", spec.Info.Title)
+	fmt.Fprintf(&buf, "@baseUrl = %s\n", baseURL)
+	for _, name := range sortedKeys(vars) {
+		fmt.Fprintf(&buf, "@%s = %s\n", name, vars[name])
+	}
+	buf.WriteString(requests.String())
+	return buf.String()
+}
+
+// writeHTTPRequest writes one request, recording the file variables it uses
+// in vars.
+func writeHTTPRequest(buf *strings.Builder, method, path string, op *OperationObject, security []SecurityRequirement,
+	schemes map[string]*SecuritySchemeObject, schemas map[string]*SchemaObject, vars map[string]string) {
+	title := op.Summary
+	if title == "" {
+		title = method + " " + path
+	}
+	fmt.Fprintf(buf, "\n### %s\n", title)
+	if op.OperationID != "" {
+		fmt.Fprintf(buf, "# @name %s\n", op.OperationID)
+	}
+
+	sample := func(schema *SchemaObject, name string) string {
+		v := sampleValue(schema, schemas, name, map[string]bool{})
+		if s, ok := v.(string); ok {
+			return s
+		}
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+
+	target := path
+	query := url.Values{}
+	var headers []string
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			target = strings.ReplaceAll(target, "{"+param.Name+"}", "{{"+param.Name+"}}")
+			if _, ok := vars[param.Name]; !ok {
+				vars[param.Name] = sample(param.Schema, param.Name)
+			}
+		case "query":
+			if param.Required {
+				query.Set(param.Name, sample(param.Schema, param.Name))
+			}
+		case "header":
+			if param.Required {
+				headers = append(headers, param.Name+": "+sample(param.Schema, param.Name))
+			}
+		}
+	}
+
+	for _, cred := range securityCredentials(security, schemes) {
+		vars[cred.Var] = ""
+		value := cred.Prefix + "{{" + cred.Var + "}}"
+		switch cred.In {
+		case "header":
+			headers = append(headers, cred.Name+": "+value)
+		case "query":
+			query.Set(cred.Name, value)
+		case "cookie":
+			headers = append(headers, "Cookie: "+cred.Name+"="+value)
+		}
+	}
+
+	requestLine := "{{baseUrl}}" + target
+	if len(query) > 0 {
+		// Keep {{variables}} readable instead of percent-encoding them.
+		requestLine += "?" + strings.NewReplacer("%7B%7B", "{{", "%7D%7D", "}}").Replace(query.Encode())
+	}
+	fmt.Fprintf(buf, "%s %s\n", method, requestLine)
+	buf.WriteString("Accept: application/json\n")
+
+	var body string
+	if op.RequestBody != nil {
+		mediaTypes := sortedKeys(op.RequestBody.Content)
+		if media, ok := op.RequestBody.Content["application/json"]; ok {
+			data, err := json.MarshalIndent(sampleValue(media.Schema, schemas, "", map[string]bool{}), "", "  ")
+			if err == nil {
+				body = string(data)
+			}
+			headers = append(headers, "Content-Type: application/json")
+		} else if len(mediaTypes) > 0 {
+			headers = append(headers, "Content-Type: "+mediaTypes[0])
+		}
+	}
+	for _, header := range headers {
+		buf.WriteString(header + "\n")
+	}
+	if body != "" {
+		buf.WriteString("\n" + body + "\n")
+	}
+}
@@ -0,0 +1,50 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateHTTPFile(t *testing.T) {
+	spec := &OpenAPISpec{
+		Info:     InfoObject{Title: "Users API"},
+		Servers:  []ServerObject{{URL: "https://api.example.com"}},
+		Security: []SecurityRequirement{{"bearerAuth": {}}},
+		Paths: map[string]*PathItem{
+			"/users/{id}": {
+				Put: &OperationObject{
+					OperationID: "updateUser",
+					Summary:     "Update a user",
+					Parameters: []ParameterObject{
+						{Name: "id", In: "path", Required: true, Schema: &SchemaObject{Type: "integer", Example: 42}},
+						{Name: "notify", In: "query", Required: true, Schema: &SchemaObject{Type: "boolean"}},
+					},
+					RequestBody: &RequestBodyObject{Content: map[string]MediaType{
+						"application/json": {Schema: &SchemaObject{Ref: RefPath("User")}},
+					}},
+				},
+			},
+		},
+		Components: &ComponentsObject{
+			Schemas: map[string]*SchemaObject{
+				"User": {Type: "object", Properties: map[string]*SchemaObject{
+					"name": {Type: "string", Example: "Ada"},
+				}},
+			},
+			SecuritySchemes: map[string]*SecuritySchemeObject{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	got := generateHTTPFile(spec)
+	for _, want := range []string{
+		"@baseUrl = https://api.example.com\n@id = 42\n@token = \n",
+		"### Update a user\n# @name updateUser\nPUT {{baseUrl}}/users/{{id}}?notify=true\n",
+		"Authorization: Bearer {{token}}\nContent-Type: application/json\n\n{\n  \"name\": \"Ada\"\n}\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+}
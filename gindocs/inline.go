@@ -0,0 +1,200 @@
+package gindocs
+
+import "strings"
+
+// inlineSchemas returns a copy of spec with every $ref to a component schema
+// replaced by a copy of the schema. Recursive references stay $refs and
+// their components are kept; all other component schemas are dropped.
+func inlineSchemas(spec *OpenAPISpec) *OpenAPISpec {
+	if spec.Components == nil || len(spec.Components.Schemas) == 0 {
+		return spec
+	}
+
+	in := &inliner{schemas: spec.Components.Schemas, recursive: make(map[string]bool)}
+
+	resolved := *spec
+	resolved.Paths = make(map[string]*PathItem, len(spec.Paths))
+	for path, pathItem := range spec.Paths {
+		item := &PathItem{}
+		for _, method := range httpMethods {
+			if op := pathItem.GetOperation(method); op != nil {
+				item.SetOperation(method, in.operation(op))
+			}
+		}
+		resolved.Paths[path] = item
+	}
+
+	// Parameter, request body and response components (e.g. from
+	// MergeSpecs) may reference schemas too.
+	components := *spec.Components
+	if spec.Components.Parameters != nil {
+		components.Parameters = make(map[string]*ParameterObject, len(spec.Components.Parameters))
+		for name, param := range spec.Components.Parameters {
+			in.stack = make(map[string]bool)
+			p := *param
+			p.Schema = in.schema(param.Schema)
+			components.Parameters[name] = &p
+		}
+	}
+	if spec.Components.RequestBodies != nil {
+		components.RequestBodies = make(map[string]*RequestBodyObject, len(spec.Components.RequestBodies))
+		for name, body := range spec.Components.RequestBodies {
+			in.stack = make(map[string]bool)
+			b := *body
+			b.Content = in.content(body.Content)
+			components.RequestBodies[name] = &b
+		}
+	}
+	if spec.Components.Responses != nil {
+		components.Responses = make(map[string]*Response, len(spec.Components.Responses))
+		for name, resp := range spec.Components.Responses {
+			in.stack = make(map[string]bool)
+			components.Responses[name] = in.response(resp)
+		}
+	}
+
+	// Recursive components are kept, with their other references inlined.
+	components.Schemas = make(map[string]*SchemaObject)
+	for added := true; added; {
+		added = false
+		for name := range in.recursive {
+			if _, ok := components.Schemas[name]; ok {
+				continue
+			}
+			in.stack = map[string]bool{name: true}
+			components.Schemas[name] = in.schema(in.schemas[name])
+			added = true
+		}
+	}
+	resolved.Components = &components
+
+	return &resolved
+}
+
+// inliner resolves $refs against a set of component schemas.
+type inliner struct {
+	schemas map[string]*SchemaObject
+
+	// stack holds the components being inlined, to detect recursion.
+	stack map[string]bool
+
+	// recursive collects components referenced from inside themselves.
+	recursive map[string]bool
+}
+
+// operation returns a copy of op with its schemas inlined.
+func (in *inliner) operation(op *OperationObject) *OperationObject {
+	in.stack = make(map[string]bool)
+
+	resolved := *op
+	resolved.Parameters = make([]ParameterObject, len(op.Parameters))
+	for i, param := range op.Parameters {
+		param.Schema = in.schema(param.Schema)
+		resolved.Parameters[i] = param
+	}
+	if op.RequestBody != nil {
+		body := *op.RequestBody
+		body.Content = in.content(op.RequestBody.Content)
+		resolved.RequestBody = &body
+	}
+	resolved.Responses = make(map[string]*Response, len(op.Responses))
+	for code, resp := range op.Responses {
+		resolved.Responses[code] = in.response(resp)
+	}
+	resolved.WebSocket = in.stream(op.WebSocket)
+	resolved.SSE = in.stream(op.SSE)
+	return &resolved
+}
+
+// response returns a copy of resp with its schemas inlined.
+func (in *inliner) response(resp *Response) *Response {
+	r := *resp
+	r.Content = in.content(resp.Content)
+	if resp.Headers != nil {
+		r.Headers = make(map[string]*Header, len(resp.Headers))
+		for name, header := range resp.Headers {
+			h := *header
+			h.Schema = in.schema(header.Schema)
+			r.Headers[name] = &h
+		}
+	}
+	return &r
+}
+
+// stream returns a copy of a WebSocket or SSE stream with its schemas inlined.
+func (in *inliner) stream(stream *StreamObject) *StreamObject {
+	if stream == nil {
+		return nil
+	}
+	resolved := &StreamObject{Messages: make([]*SchemaObject, len(stream.Messages))}
+	for i, message := range stream.Messages {
+		resolved.Messages[i] = in.schema(message)
+	}
+	return resolved
+}
+
+// content inlines the schemas of a media type map.
+func (in *inliner) content(content map[string]MediaType) map[string]MediaType {
+	if content == nil {
+		return nil
+	}
+	resolved := make(map[string]MediaType, len(content))
+	for mediaType, media := range content {
+		media.Schema = in.schema(media.Schema)
+		resolved[mediaType] = media
+	}
+	return resolved
+}
+
+// schema returns a copy of s with component references inlined.
+func (in *inliner) schema(s *SchemaObject) *SchemaObject {
+	if s == nil {
+		return nil
+	}
+
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, RefPath(""))
+		target, ok := in.schemas[name]
+		if !ok || in.stack[name] {
+			if ok {
+				in.recursive[name] = true
+			}
+			ref := *s
+			return &ref
+		}
+		in.stack[name] = true
+		defer delete(in.stack, name)
+		return in.schema(target)
+	}
+
+	resolved := *s
+	if s.Properties != nil {
+		resolved.Properties = make(map[string]*SchemaObject, len(s.Properties))
+		for name, prop := range s.Properties {
+			resolved.Properties[name] = in.schema(prop)
+		}
+	}
+	resolved.Items = in.schema(s.Items)
+	resolved.AdditionalProperties = in.schema(s.AdditionalProperties)
+	resolved.AllOf = in.schemaList(s.AllOf)
+	resolved.OneOf = in.schemaList(s.OneOf)
+	resolved.AnyOf = in.schemaList(s.AnyOf)
+
+	// Mappings point at components, which are gone once inlined.
+	if s.Discriminator != nil {
+		resolved.Discriminator = &DiscriminatorObject{PropertyName: s.Discriminator.PropertyName}
+	}
+	return &resolved
+}
+
+// schemaList inlines each schema in a list.
+func (in *inliner) schemaList(list []*SchemaObject) []*SchemaObject {
+	if list == nil {
+		return nil
+	}
+	resolved := make([]*SchemaObject, len(list))
+	for i, s := range list {
+		resolved[i] = in.schema(s)
+	}
+	return resolved
+}
@@ -0,0 +1,55 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestInlineSchemas(t *testing.T) {
+	type Author struct {
+		Name string `json:"name"`
+	}
+	type Comment struct {
+		Body    string     `json:"body"`
+		Replies []*Comment `json:"replies"`
+	}
+	type Article struct {
+		Author   Author    `json:"author"`
+		Comments []Comment `json:"comments"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/articles/:id", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Route("GET /articles/:id").Response(200, Article{}, "Article")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json?resolve=true", nil))
+	var spec OpenAPISpec
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := spec.Paths["/articles/{id}"].Get.Responses["200"].Content["application/json"].Schema
+	if schema.Ref != "" || schema.Properties["author"].Properties["name"] == nil {
+		t.Fatalf("expected Article and Author inline, got %+v", schema)
+	}
+	comment := schema.Properties["comments"].Items
+	if comment.Ref != "" || comment.Properties["replies"].Items.Ref != RefPath("Comment") {
+		t.Errorf("expected Comment inline with a recursive $ref, got %+v", comment)
+	}
+	if names := sortedKeys(spec.Components.Schemas); strings.Join(names, ",") != "Comment" {
+		t.Errorf("expected only the recursive Comment component, got %v", names)
+	}
+
+	if gd.Spec().Paths["/articles/{id}"].Get.Responses["200"].Content["application/json"].Schema.Ref == "" {
+		t.Error("resolving must not modify the cached spec")
+	}
+}
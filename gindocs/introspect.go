@@ -2,7 +2,10 @@ package gindocs
 
 import (
 	"path"
+	"reflect"
 	"strings"
+
+	"github.com/gin-gonic/gin"
 )
 
 // RouteMetadata holds parsed information about a single route.
@@ -19,21 +22,32 @@ type RouteMetadata struct {
 	// HandlerName is the fully qualified handler function name.
 	HandlerName string
 
+	// Handlers lists the fully qualified names of the route's whole handler
+	// chain, middleware first and HandlerName last.
+	Handlers []string
+
 	// PathParams lists path parameter names extracted from the route.
 	PathParams []string
 
 	// Tags are auto-detected operation tags (from route groups).
 	Tags []string
+
+	// SourceFile is the file defining the handler, if it could be resolved.
+	SourceFile string
+
+	// SourceLine is the line of the handler definition in SourceFile.
+	SourceLine int
 }
 
 // introspect reads all routes from the Gin router and builds RouteMetadata entries.
 func (gd *GinDocs) introspect() []RouteMetadata {
 	routes := gd.router.Routes()
+	chains := handlerChains(gd.router)
 	result := make([]RouteMetadata, 0, len(routes))
 
 	for _, r := range routes {
 		// Skip documentation routes themselves.
-		if gd.isDocRoute(r.Path) {
+		if gd.isDocRoute(r) {
 			continue
 		}
 
@@ -42,21 +56,53 @@ func (gd *GinDocs) introspect() []RouteMetadata {
 			continue
 		}
 
+		// Skip routes hidden through Hide or Hidden.
+		if gd.isHidden(r.Method, r.Path, r.Handler) {
+			continue
+		}
+
 		meta := RouteMetadata{
 			Method:      r.Method,
 			Path:        r.Path,
 			OpenAPIPath: ginPathToOpenAPI(r.Path),
 			HandlerName: r.Handler,
+			Handlers:    chains[r.Method+" "+r.Path],
 			PathParams:  extractPathParams(r.Path),
 			Tags:        inferTags(r.Path),
 		}
+		meta.SourceFile, meta.SourceLine = funcSourceLocation(r.HandlerFunc)
 
 		result = append(result, meta)
 	}
 
+	// Add routes documented with AddRoute that the router doesn't serve.
+	for _, key := range sortedKeys(gd.manualRoutes) {
+		method, routePath, _ := strings.Cut(key, " ")
+		if served(routes, method, routePath) || gd.isExcluded(routePath) || gd.isHidden(method, routePath, "") {
+			continue
+		}
+		result = append(result, RouteMetadata{
+			Method:      method,
+			Path:        routePath,
+			OpenAPIPath: ginPathToOpenAPI(routePath),
+			PathParams:  extractPathParams(routePath),
+			Tags:        inferTags(routePath),
+		})
+	}
+
 	return result
 }
 
+// served reports whether the router serves method and path.
+func served(routes gin.RoutesInfo, method, routePath string) bool {
+	for _, r := range routes {
+		if r.Method == method && r.Path == routePath {
+			return true
+		}
+	}
+	return false
+}
+
 // ginPathToOpenAPI converts Gin's :param and *param syntax to OpenAPI {param}.
 func ginPathToOpenAPI(ginPath string) string {
 	segments := strings.Split(ginPath, "/")
@@ -70,6 +116,17 @@ func ginPathToOpenAPI(ginPath string) string {
 	return strings.Join(segments, "/")
 }
 
+// openAPIPathToGin converts OpenAPI {param} segments back to Gin's :param.
+func openAPIPathToGin(path string) string {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			segments[i] = ":" + seg[1:len(seg)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
 // extractPathParams returns the names of all path parameters in a Gin route.
 func extractPathParams(ginPath string) []string {
 	var params []string
@@ -137,9 +194,19 @@ func capitalizeTag(s string) string {
 	return strings.Join(words, " ")
 }
 
-// isDocRoute checks if a path belongs to the documentation routes.
-func (gd *GinDocs) isDocRoute(routePath string) bool {
-	prefix := gd.config.Prefix
+// docsHandlerPrefix starts the names of the handlers GinDocs registers, e.g.
+// "github.com/MUKE-coder/gin-docs/gindocs.(*GinDocs).handleUI-fm".
+var docsHandlerPrefix = reflect.TypeOf((*GinDocs)(nil)).Elem().PkgPath() + ".(*GinDocs)."
+
+// isDocRoute checks if a route belongs to the documentation routes of this
+// or any other instance mounted on the same router. Other instances' routes
+// are recognized by their handlers, so no instance needs to know the others.
+func (gd *GinDocs) isDocRoute(r gin.RouteInfo) bool {
+	return isUnderPrefix(r.Path, gd.config.Prefix) || strings.HasPrefix(r.Handler, docsHandlerPrefix)
+}
+
+// isUnderPrefix reports whether routePath is prefix or below it.
+func isUnderPrefix(routePath, prefix string) bool {
 	return routePath == prefix ||
 		routePath == prefix+"/" ||
 		strings.HasPrefix(routePath, prefix+"/")
@@ -147,6 +214,20 @@ func (gd *GinDocs) isDocRoute(routePath string) bool {
 
 // isExcluded checks if a route should be excluded from documentation.
 func (gd *GinDocs) isExcluded(routePath string) bool {
+	// Check prefix inclusions.
+	if len(gd.config.IncludePrefixes) > 0 {
+		included := false
+		for _, prefix := range gd.config.IncludePrefixes {
+			if strings.HasPrefix(routePath, prefix) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return true
+		}
+	}
+
 	// Check prefix exclusions.
 	for _, prefix := range gd.config.ExcludePrefixes {
 		if strings.HasPrefix(routePath, prefix) {
@@ -163,3 +244,18 @@ func (gd *GinDocs) isExcluded(routePath string) bool {
 
 	return false
 }
+
+// isHidden checks if a route was hidden with Hide or an override's Hidden.
+func (gd *GinDocs) isHidden(method, routePath, handlerName string) bool {
+	key := method + " " + routePath
+	if gd.hidden[key] {
+		return true
+	}
+	if override, ok := gd.routeOverrides[key]; ok && override.hidden {
+		return true
+	}
+	if override, ok := gd.handlerOverrides[handlerName]; ok && override.hidden {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package gindocs
+
+import (
+	"bytes"
+	"encoding/csv"
+	"sort"
+	"strings"
+)
+
+// inventoryHeader lists the columns of the endpoint inventory export.
+var inventoryHeader = []string{
+	"method", "path", "operation_id", "summary", "tags", "auth",
+	"request_type", "response_type", "deprecated", "sunset",
+}
+
+// generateInventoryCSV renders one CSV row per operation, sorted by path and method.
+func generateInventoryCSV(spec *OpenAPISpec) ([]byte, error) {
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(inventoryHeader); err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+
+			security := op.Security
+			if security == nil {
+				security = spec.Security
+			}
+
+			deprecated := "no"
+			if op.Deprecated {
+				deprecated = "yes"
+			}
+
+			var requestType string
+			if op.RequestBody != nil {
+				requestType = contentTypeName(op.RequestBody.Content)
+			}
+
+			row := []string{
+				method,
+				path,
+				op.OperationID,
+				op.Summary,
+				strings.Join(op.Tags, "; "),
+				securityNames(security),
+				requestType,
+				successResponseType(op.Responses),
+				deprecated,
+				op.Sunset,
+			}
+			if err := w.Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// securityNames lists the scheme names of a security requirement set.
+func securityNames(security []SecurityRequirement) string {
+	var names []string
+	for _, req := range security {
+		for name := range req {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return strings.Join(names, "; ")
+}
+
+// successResponseType describes the body of the first 2xx response.
+func successResponseType(responses map[string]*Response) string {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		if name := contentTypeName(responses[code].Content); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// contentTypeName names the schema of a content map, preferring JSON.
+func contentTypeName(content map[string]MediaType) string {
+	media, ok := content["application/json"]
+	if !ok {
+		for _, m := range content {
+			media = m
+			break
+		}
+	}
+	return schemaTypeName(media.Schema)
+}
+
+// schemaTypeName renders a schema as a short type name, e.g. "User" or "[]User".
+func schemaTypeName(schema *SchemaObject) string {
+	switch {
+	case schema == nil:
+		return ""
+	case schema.Ref != "":
+		return strings.TrimPrefix(schema.Ref, RefPath(""))
+	case schema.Type == "array":
+		return "[]" + schemaTypeName(schema.Items)
+	case len(schema.AllOf) == 1:
+		return schemaTypeName(schema.AllOf[0])
+	}
+	return schema.Type
+}
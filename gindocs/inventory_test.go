@@ -0,0 +1,64 @@
+package gindocs
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestGenerateInventoryCSV(t *testing.T) {
+	jsonBody := func(schema *SchemaObject) map[string]MediaType {
+		return map[string]MediaType{"application/json": {Schema: schema}}
+	}
+	spec := &OpenAPISpec{
+		Security: []SecurityRequirement{{"BearerAuth": {}}},
+		Paths: map[string]*PathItem{
+			"/users": {
+				Get: &OperationObject{
+					OperationID: "listUsers",
+					Summary:     "List users",
+					Tags:        []string{"Users", "Admin"},
+					Responses: map[string]*Response{
+						"200": {Content: jsonBody(&SchemaObject{Type: "array", Items: SchemaRef("User")})},
+					},
+				},
+				Post: &OperationObject{
+					OperationID: "createUser",
+					Summary:     "Create a user, then notify",
+					Security:    []SecurityRequirement{},
+					Deprecated:  true,
+					Sunset:      "2027-01-01",
+					RequestBody: &RequestBodyObject{Content: jsonBody(SchemaRef("CreateUser"))},
+					Responses: map[string]*Response{
+						"400": {Content: jsonBody(SchemaRef("Error"))},
+						"201": {Content: jsonBody(SchemaRef("User"))},
+					},
+				},
+			},
+		},
+	}
+
+	data, err := generateInventoryCSV(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		inventoryHeader,
+		{"GET", "/users", "listUsers", "List users", "Users; Admin", "BearerAuth", "", "[]User", "no", ""},
+		{"POST", "/users", "createUser", "Create a user, then notify", "", "none", "CreateUser", "User", "yes", "2027-01-01"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %v", rows)
+	}
+	for i := range want {
+		if strings.Join(rows[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %q, want %q", i, rows[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package gindocs
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// k6EnvVars maps credential placeholders to the environment variables the
+// k6 script reads them from.
+var k6EnvVars = map[string]string{"token": "TOKEN", "apiKey": "API_KEY"}
+
+// generateK6 renders a k6 load-test script that calls every operation once
+// per iteration, grouped by tag, with example payloads and the credentials
+// of its security scheme. BASE_URL and the credentials come from
+// environment variables.
+func generateK6(spec *OpenAPISpec) string {
+	var schemas map[string]*SchemaObject
+	var schemes map[string]*SecuritySchemeObject
+	if spec.Components != nil {
+		schemas = spec.Components.Schemas
+		schemes = spec.Components.SecuritySchemes
+	}
+
+	baseURL := "http://localhost:8080"
+	if len(spec.Servers) > 0 {
+		baseURL = spec.Servers[0].ResolvedURL()
+	}
+
+	// Group operations by their first tag, keeping path order.
+	groups := make(map[string][]specOperation)
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+			tag := "default"
+			if len(op.Tags) > 0 {
+				tag = op.Tags[0]
+			}
+			groups[tag] = append(groups[tag], specOperation{method, path, op})
+		}
+	}
+
+	usesBasic := false
+	env := map[string]bool{}
+	var body strings.Builder
+	for _, tag := range sortedKeys(groups) {
+		fmt.Fprintf(&body, "\n  group(%s, () => {\n", strconv.Quote(tag))
+		for _, o := range groups[tag] {
+			security := o.op.Security
+			if security == nil {
+				security = spec.Security
+			}
+			creds := securityCredentials(security, schemes)
+			for _, cred := range creds {
+				if cred.Var == "basicAuth" {
+					usesBasic = true
+				} else {
+					env[cred.Var] = true
+				}
+			}
+			writeK6Request(&body, o, creds, schemas)
+		}
+		body.WriteString("  });\n")
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "// %s\n// Code generated by gindocs. DO NOT EDIT.\n", spec.Info.Title)
+	buf.WriteString("//\n// Run with: k6 run -e BASE_URL=... script.js\n\n")
+	buf.WriteString("import http from \"k6/http\";\n")
+	if usesBasic {
+		buf.WriteString("import encoding from \"k6/encoding\";\n")
+	}
+	buf.WriteString("import { check, group } from \"k6\";\n\n")
+	buf.WriteString("export const options = {\n  vus: 1,\n  duration: \"30s\",\n  thresholds: {\n    http_req_failed: [\"rate<0.01\"],\n  },\n};\n\n")
+	fmt.Fprintf(&buf, "const BASE_URL = __ENV.BASE_URL || %s;\n", strconv.Quote(baseURL))
+	for _, name := range sortedKeys(env) {
+		fmt.Fprintf(&buf, "const %s = __ENV.%s || \"\";\n", k6EnvVars[name], k6EnvVars[name])
+	}
+	if usesBasic {
+		buf.WriteString("const BASIC_AUTH = encoding.b64encode(`${__ENV.USERNAME || \"\"}:${__ENV.PASSWORD || \"\"}`);\n")
+	}
+	buf.WriteString("\nexport default function () {")
+	buf.WriteString(body.String())
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// writeK6Request writes the request and status check of one operation.
+func writeK6Request(buf *strings.Builder, o specOperation, creds []credential, schemas map[string]*SchemaObject) {
+	op := o.op
+	target := o.path
+	var query []string
+	headers := []string{`"Accept": "application/json"`}
+	for _, param := range op.Parameters {
+		value := exampleString(param.Schema, schemas, param.Name)
+		switch {
+		case param.In == "path":
+			target = strings.ReplaceAll(target, "{"+param.Name+"}", url.PathEscape(value))
+		case param.In == "query" && param.Required:
+			query = append(query, url.QueryEscape(param.Name)+"="+url.QueryEscape(value))
+		case param.In == "header" && param.Required:
+			headers = append(headers, strconv.Quote(param.Name)+": "+strconv.Quote(value))
+		}
+	}
+	target = k6Template(target)
+	for i := range query {
+		query[i] = k6Template(query[i])
+	}
+
+	for _, cred := range creds {
+		expr := k6EnvVars[cred.Var]
+		if cred.Var == "basicAuth" {
+			expr = "BASIC_AUTH"
+		}
+		switch cred.In {
+		case "header":
+			headers = append(headers, strconv.Quote(cred.Name)+": `"+k6Template(cred.Prefix)+"${"+expr+"}`")
+		case "query":
+			query = append(query, k6Template(url.QueryEscape(cred.Name))+"=${encodeURIComponent("+expr+")}")
+		case "cookie":
+			headers = append(headers, `"Cookie": `+"`"+k6Template(cred.Name)+"=${"+expr+"}`")
+		}
+	}
+
+	requestBody := "null"
+	if op.RequestBody != nil {
+		if media, ok := op.RequestBody.Content["application/json"]; ok {
+			requestBody = "JSON.stringify(" + strings.ReplaceAll(exampleJSON(media.Schema, schemas), "\n", "\n      ") + ")"
+			headers = append(headers, `"Content-Type": "application/json"`)
+		}
+	}
+
+	requestURL := "`${BASE_URL}" + target
+	if len(query) > 0 {
+		requestURL += "?" + strings.Join(query, "&")
+	}
+	requestURL += "`"
+
+	name := o.method + " " + o.path
+	buf.WriteString("    {\n")
+	if op.Summary != "" {
+		fmt.Fprintf(buf, "      // %s\n", strings.ReplaceAll(op.Summary, "\n", " "))
+	}
+	fmt.Fprintf(buf, "      const res = http.request(%q, %s, %s, {\n", o.method, requestURL, requestBody)
+	fmt.Fprintf(buf, "        headers: { %s },\n", strings.Join(headers, ", "))
+	fmt.Fprintf(buf, "        tags: { name: %s },\n", strconv.Quote(name))
+	buf.WriteString("      });\n")
+	fmt.Fprintf(buf, "      check(res, { %s: (r) => %s });\n", strconv.Quote(name+" "+k6StatusLabel(op)), k6StatusCheck(op))
+	buf.WriteString("    }\n")
+}
+
+// k6StatusCheck returns the JavaScript condition a response's status must
+// meet: the first documented success status, or any 2xx.
+func k6StatusCheck(op *OperationObject) string {
+	if code := k6SuccessStatus(op); code != "" {
+		return "r.status === " + code
+	}
+	return "r.status >= 200 && r.status < 300"
+}
+
+// k6StatusLabel describes the expected status in a check name.
+func k6StatusLabel(op *OperationObject) string {
+	if code := k6SuccessStatus(op); code != "" {
+		return "is " + code
+	}
+	return "is 2xx"
+}
+
+// k6SuccessStatus returns the first documented 2xx status code, or "".
+func k6SuccessStatus(op *OperationObject) string {
+	for _, code := range sortedKeys(op.Responses) {
+		if len(code) == 3 && code[0] == '2' && code[1] >= '0' && code[1] <= '9' && code[2] >= '0' && code[2] <= '9' {
+			return code
+		}
+	}
+	return ""
+}
+
+// k6Template escapes text for a JavaScript template literal.
+func k6Template(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "`", "\\`", "${", "\\${").Replace(s)
+}
@@ -0,0 +1,55 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateK6(t *testing.T) {
+	spec := &OpenAPISpec{
+		Info:    InfoObject{Title: "Users API"},
+		Servers: []ServerObject{{URL: "https://api.example.com"}},
+		Paths: map[string]*PathItem{
+			"/users/{id}": {
+				Put: &OperationObject{
+					Tags:     []string{"Users"},
+					Summary:  "Update a user",
+					Security: []SecurityRequirement{{"bearerAuth": {}}},
+					Parameters: []ParameterObject{
+						{Name: "id", In: "path", Required: true, Schema: &SchemaObject{Type: "integer", Example: 42}},
+					},
+					RequestBody: &RequestBodyObject{Content: map[string]MediaType{
+						"application/json": {Schema: &SchemaObject{Type: "object", Properties: map[string]*SchemaObject{
+							"name": {Type: "string", Example: "Ada"},
+						}}},
+					}},
+					Responses: map[string]*Response{"200": {Description: "OK"}, "404": {Description: "Not found"}},
+				},
+			},
+			"/health": {Get: &OperationObject{}},
+		},
+		Components: &ComponentsObject{SecuritySchemes: map[string]*SecuritySchemeObject{
+			"bearerAuth": {Type: "http", Scheme: "bearer"},
+		}},
+	}
+
+	got := generateK6(spec)
+	for _, want := range []string{
+		`const BASE_URL = __ENV.BASE_URL || "https://api.example.com";`,
+		`const TOKEN = __ENV.TOKEN || "";`,
+		`group("Users", () => {`,
+		"http.request(\"PUT\", `${BASE_URL}/users/42`, JSON.stringify({",
+		`"name": "Ada"`,
+		"\"Authorization\": `Bearer ${TOKEN}`",
+		`tags: { name: "PUT /users/{id}" }`,
+		`check(res, { "PUT /users/{id} is 200": (r) => r.status === 200 });`,
+		`check(res, { "GET /health is 2xx": (r) => r.status >= 200 && r.status < 300 });`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("script missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "k6/encoding") {
+		t.Error("encoding is only needed for basic auth")
+	}
+}
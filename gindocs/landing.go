@@ -0,0 +1,203 @@
+package gindocs
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LandingPage configures the page served at the docs prefix in place of the
+// UI, which moves to {Prefix}/reference. The page links to the API
+// reference, its versions, guides, exports and custom links.
+type LandingPage struct {
+	// Description is markdown shown below the title (default:
+	// Config.Description).
+	Description string
+
+	// AccentColor is the CSS color of headings and buttons (default:
+	// "#6c63ff"). The logo and title come from Config.
+	AccentColor string
+
+	// Links are extra links, such as a changelog or support page.
+	Links []LandingLink
+
+	// StatusURL links to the API's status page.
+	StatusURL string
+
+	// HideExports leaves the export downloads off the page.
+	HideExports bool
+
+	// Template replaces the built-in page. It is executed with a
+	// LandingData.
+	Template *template.Template
+}
+
+// LandingLink is a link on the landing page.
+type LandingLink struct {
+	Title       string
+	URL         string
+	Description string
+}
+
+// LandingData is the data a LandingPage template is executed with.
+type LandingData struct {
+	Title       string
+	Version     string
+	Description template.HTML
+	Logo        string
+	AccentColor string
+
+	// ReferenceURL is the URL of the API reference UI.
+	ReferenceURL string
+
+	Versions []LandingLink
+	Guides   []LandingLink
+	Exports  []LandingLink
+	Links    []LandingLink
+
+	StatusURL  string
+	Operations int
+	Deprecated int
+}
+
+// landingExports are the exports listed on the landing page, by path below
+// the docs prefix.
+var landingExports = []LandingLink{
+	{"OpenAPI (JSON)", "/openapi.json", "The OpenAPI 3.1 document"},
+	{"OpenAPI (YAML)", "/openapi.yaml", "The OpenAPI 3.1 document as YAML"},
+	{"Postman", "/export/postman", "Postman v2.1 collection"},
+	{"Insomnia", "/export/insomnia", "Insomnia export with environments"},
+	{"HTTP file", "/export/http", "Requests for VS Code and JetBrains HTTP clients"},
+	{"Markdown", "/export/markdown", "The reference as a single Markdown document"},
+	{"TypeScript", "/export/typescript", "TypeScript types and API interface"},
+	{"Go client", "/export/go-client", "Typed Go client"},
+	{"k6", "/export/k6", "k6 load-test script"},
+}
+
+// landingTemplate is the built-in landing page.
+var landingTemplate = template.Must(template.New("landing").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 0; color: #1a1a2e; background: #fafafc; }
+        header { padding: 48px 32px 32px; max-width: 960px; margin: 0 auto; }
+        header img { max-height: 48px; margin-bottom: 16px; }
+        h1 { margin: 0 0 8px; font-size: 2rem; }
+        h2 { color: {{.AccentColor}}; font-size: 1.1rem; margin: 0 0 12px; }
+        .version { color: #888; font-size: 0.9rem; font-weight: normal; margin-left: 8px; }
+        .button { display: inline-block; margin-top: 16px; padding: 10px 20px; border-radius: 6px; background: {{.AccentColor}}; color: #fff; text-decoration: none; font-weight: 600; }
+        main { max-width: 960px; margin: 0 auto; padding: 0 32px 48px; display: grid; grid-template-columns: repeat(auto-fill, minmax(280px, 1fr)); gap: 24px; }
+        section { background: #fff; border: 1px solid #e2e2ea; border-radius: 8px; padding: 20px; }
+        ul { list-style: none; margin: 0; padding: 0; }
+        li { margin-bottom: 10px; }
+        li a { color: #1a1a2e; font-weight: 600; text-decoration: none; }
+        li a:hover { color: {{.AccentColor}}; }
+        li span { display: block; color: #6a6a8a; font-size: 0.85rem; }
+        .gd-markdown { color: #4a4a6a; line-height: 1.7; }
+        ` + markdownCSS + `
+    </style>
+</head>
+<body>
+    <header>
+        {{if .Logo}}<img src="{{.Logo}}" alt="{{.Title}}">{{end}}
+        <h1>{{.Title}}{{if .Version}}<span class="version">v{{.Version}}</span>{{end}}</h1>
+        {{if .Description}}<div class="gd-markdown">{{.Description}}</div>{{end}}
+        <a class="button" href="{{.ReferenceURL}}">API Reference</a>
+    </header>
+    <main>
+        {{if .Versions}}<section><h2>Versions</h2><ul>{{range .Versions}}<li><a href="{{.URL}}">{{.Title}}</a>{{if .Description}}<span>{{.Description}}</span>{{end}}</li>{{end}}</ul></section>{{end}}
+        {{if .Guides}}<section><h2>Guides</h2><ul>{{range .Guides}}<li><a href="{{.URL}}">{{.Title}}</a></li>{{end}}</ul></section>{{end}}
+        {{if .Exports}}<section><h2>Downloads</h2><ul>{{range .Exports}}<li><a href="{{.URL}}">{{.Title}}</a><span>{{.Description}}</span></li>{{end}}</ul></section>{{end}}
+        {{if .Links}}<section><h2>Links</h2><ul>{{range .Links}}<li><a href="{{.URL}}">{{.Title}}</a>{{if .Description}}<span>{{.Description}}</span>{{end}}</li>{{end}}</ul></section>{{end}}
+        <section><h2>Status</h2><ul>
+            <li>{{.Operations}} operation{{if ne .Operations 1}}s{{end}}{{if .Deprecated}}<span>{{.Deprecated}} deprecated</span>{{end}}</li>
+            {{if .StatusURL}}<li><a href="{{.StatusURL}}">Status page</a><span>Uptime and incidents</span></li>{{end}}
+        </ul></section>
+    </main>
+</body>
+</html>`))
+
+// referenceURL returns the URL of the UI: the docs prefix, or
+// {Prefix}/reference when a landing page is configured.
+func (gd *GinDocs) referenceURL(c *gin.Context) string {
+	if gd.config.LandingPage != nil {
+		return gd.docsURL(c) + "/reference"
+	}
+	return gd.docsURL(c)
+}
+
+// handleLanding serves the landing page.
+func (gd *GinDocs) handleLanding(c *gin.Context) {
+	data := gd.landingData(c)
+	tmpl := landingTemplate
+	if gd.config.LandingPage.Template != nil {
+		tmpl = gd.config.LandingPage.Template
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to render landing page: " + err.Error()})
+		return
+	}
+	gd.writeHTML(c, buf.String())
+}
+
+// landingData collects the landing page data for a request.
+func (gd *GinDocs) landingData(c *gin.Context) LandingData {
+	landing := gd.config.LandingPage
+	docsURL := gd.docsURL(c)
+	spec := gd.requestSpec(c)
+
+	data := LandingData{
+		Title:        spec.Info.Title,
+		Version:      spec.Info.Version,
+		Logo:         gd.config.Logo,
+		AccentColor:  landing.AccentColor,
+		ReferenceURL: gd.referenceURL(c),
+		Links:        landing.Links,
+		StatusURL:    landing.StatusURL,
+	}
+	if data.AccentColor == "" {
+		data.AccentColor = "#6c63ff"
+	}
+	description := landing.Description
+	if description == "" {
+		description = gd.config.Description
+	}
+	if description != "" {
+		data.Description = template.HTML(markdownToHTML(description, 1))
+	}
+
+	for _, v := range gd.versions {
+		data.Versions = append(data.Versions, LandingLink{Title: v.name, URL: docsURL + "/" + v.name})
+	}
+	if fsys := gd.guidesFS(); fsys != nil {
+		guides, _ := listGuides(fsys)
+		for _, g := range guides {
+			data.Guides = append(data.Guides, LandingLink{Title: g.title, URL: docsURL + "/guides/" + g.slug})
+		}
+	}
+	if !landing.HideExports {
+		for _, export := range landingExports {
+			export.URL = docsURL + export.URL
+			data.Exports = append(data.Exports, export)
+		}
+	}
+
+	for _, item := range spec.Paths {
+		for _, method := range httpMethods {
+			if op := item.GetOperation(method); op != nil {
+				data.Operations++
+				if op.Deprecated {
+					data.Deprecated++
+				}
+			}
+		}
+	}
+	return data
+}
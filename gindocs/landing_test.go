@@ -0,0 +1,79 @@
+package gindocs
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLandingPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/users", func(c *gin.Context) {})
+	gd := Mount(r, nil, Config{
+		Title:       "Acme API",
+		Description: "The **Acme** API.",
+		Guides:      fstest.MapFS{"intro.md": {Data: []byte("# Introduction")}},
+		LandingPage: &LandingPage{
+			AccentColor: "#ff6600",
+			StatusURL:   "https://status.acme.test",
+			Links:       []LandingLink{{Title: "Changelog", URL: "https://acme.test/changelog"}},
+		},
+	})
+	gd.Version("v1", PrefixFilter("/api/v1"))
+
+	get := func(path string) string {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s = %d", path, w.Code)
+		}
+		return w.Body.String()
+	}
+
+	body := get("/docs")
+	for _, want := range []string{
+		"<title>Acme API</title>",
+		"<p>The <strong>Acme</strong> API.</p>",
+		`href="/docs/reference">API Reference`,
+		`href="/docs/v1">v1`,
+		`href="/docs/guides/intro">Introduction`,
+		`href="/docs/export/postman">Postman`,
+		`href="https://acme.test/changelog">Changelog`,
+		`href="https://status.acme.test"`,
+		"1 operation<",
+		"background: #ff6600",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected landing page to contain %q", want)
+		}
+	}
+
+	if body := get("/docs/reference"); !strings.Contains(body, "/docs/openapi.json") {
+		t.Error("expected the UI at /docs/reference")
+	}
+	if body := get("/docs?ui=swagger"); !strings.Contains(body, "swagger-ui") {
+		t.Error("expected ?ui= to serve the UI")
+	}
+	if body := get("/docs/v1"); !strings.Contains(body, "/docs/v1/openapi.json") {
+		t.Error("expected version pages to serve the UI")
+	}
+}
+
+func TestLandingPageTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	tmpl := template.Must(template.New("custom").Parse(`<h1>{{.Title}}</h1><a href="{{.ReferenceURL}}">Docs</a>`))
+	Mount(r, nil, Config{Title: "Acme API", LandingPage: &LandingPage{Template: tmpl}})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if got := w.Body.String(); got != `<h1>Acme API</h1><a href="/docs/reference">Docs</a>` {
+		t.Errorf("custom template rendered %q", got)
+	}
+}
@@ -0,0 +1,149 @@
+package gindocs
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LifecycleEntry describes a deprecated operation and its removal plan.
+type LifecycleEntry struct {
+	// Method is the HTTP method.
+	Method string `json:"method"`
+
+	// Path is the OpenAPI path.
+	Path string `json:"path"`
+
+	// OperationID is the operation's ID.
+	OperationID string `json:"operationId,omitempty"`
+
+	// Summary is the operation summary.
+	Summary string `json:"summary,omitempty"`
+
+	// Sunset is the planned removal date (YYYY-MM-DD), if set.
+	Sunset string `json:"sunset,omitempty"`
+
+	// DaysRemaining is the number of days until the sunset date.
+	// Negative once the date has passed.
+	DaysRemaining *int `json:"daysRemaining,omitempty"`
+
+	// ReplacedBy names the superseding operation, if any.
+	ReplacedBy string `json:"replacedBy,omitempty"`
+}
+
+// Lifecycle returns all deprecated operations, soonest sunset first.
+func (gd *GinDocs) Lifecycle() []LifecycleEntry {
+	return lifecycleEntries(gd.getSpec(), time.Now())
+}
+
+// lifecycleEntries collects deprecated operations from a spec relative to now.
+func lifecycleEntries(spec *OpenAPISpec, now time.Time) []LifecycleEntry {
+	// Sunset dates are calendar days, so count from the start of now's day.
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	entries := []LifecycleEntry{}
+
+	for path, pathItem := range spec.Paths {
+		for _, method := range httpMethods {
+			op := pathItem.GetOperation(method)
+			if op == nil || !op.Deprecated {
+				continue
+			}
+
+			entry := LifecycleEntry{
+				Method:      method,
+				Path:        path,
+				OperationID: op.OperationID,
+				Summary:     op.Summary,
+				Sunset:      op.Sunset,
+				ReplacedBy:  op.ReplacedBy,
+			}
+			if sunset, err := time.Parse("2006-01-02", op.Sunset); err == nil {
+				days := int(sunset.Sub(today).Hours() / 24)
+				entry.DaysRemaining = &days
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	// Dated entries first by date, then undated ones by path.
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.Sunset == "") != (b.Sunset == "") {
+			return a.Sunset != ""
+		}
+		if a.Sunset != b.Sunset {
+			return a.Sunset < b.Sunset
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+
+	return entries
+}
+
+// renderLifecycleHTML renders the lifecycle report as a standalone page.
+func renderLifecycleHTML(title string, entries []LifecycleEntry) string {
+	var rows strings.Builder
+	for _, e := range entries {
+		sunset := "—"
+		if e.Sunset != "" {
+			sunset = template.HTMLEscapeString(e.Sunset)
+			if e.DaysRemaining != nil {
+				if *e.DaysRemaining < 0 {
+					sunset += ` <span style="color:#c0392b;">(passed)</span>`
+				} else {
+					sunset += fmt.Sprintf(` (%d days)`, *e.DaysRemaining)
+				}
+			}
+		}
+
+		replacement := "—"
+		if e.ReplacedBy != "" {
+			replacement = "<code>" + template.HTMLEscapeString(e.ReplacedBy) + "</code>"
+		}
+
+		rows.WriteString(fmt.Sprintf(
+			`<tr><td><code>%s %s</code></td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+			template.HTMLEscapeString(e.Method),
+			template.HTMLEscapeString(e.Path),
+			template.HTMLEscapeString(e.Summary),
+			sunset,
+			replacement,
+		))
+	}
+
+	body := `<p>No deprecated operations.</p>`
+	if len(entries) > 0 {
+		body = `<table><thead><tr><th>Operation</th><th>Summary</th><th>Sunset</th><th>Replaced by</th></tr></thead><tbody>` +
+			rows.String() + `</tbody></table>`
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>%s — Lifecycle</title>
+    <style>
+        body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 0; padding: 24px 32px; color: #1a1a2e; }
+        table { border-collapse: collapse; width: 100%%; }
+        th, td { text-align: left; padding: 8px 12px; border-bottom: 1px solid #e2e2ea; }
+        th { background: #f5f5fa; }
+    </style>
+</head>
+<body>
+    <h1>%s — Deprecations</h1>
+    %s
+</body>
+</html>`,
+		template.HTMLEscapeString(title),
+		template.HTMLEscapeString(title),
+		body,
+	)
+}
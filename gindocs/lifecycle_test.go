@@ -0,0 +1,64 @@
+package gindocs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLifecycleEntries(t *testing.T) {
+	spec := &OpenAPISpec{Paths: map[string]*PathItem{
+		"/v1/users": {Get: &OperationObject{Deprecated: true, Sunset: "2026-03-10", ReplacedBy: "GET /v2/users"}},
+		"/v1/teams": {Get: &OperationObject{Deprecated: true, Sunset: "2026-03-01"}},
+		"/v1/old":   {Get: &OperationObject{Deprecated: true}},
+		"/v2/users": {Get: &OperationObject{}},
+	}}
+
+	// Late in the day, and in a zone where it is already tomorrow in UTC.
+	now := time.Date(2026, 3, 5, 23, 30, 0, 0, time.FixedZone("EST", -5*3600))
+	entries := lifecycleEntries(spec, now)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 deprecated operations, got %d", len(entries))
+	}
+
+	var order []string
+	for _, e := range entries {
+		order = append(order, e.Path)
+	}
+	if got := strings.Join(order, " "); got != "/v1/teams /v1/users /v1/old" {
+		t.Errorf("order = %s", got)
+	}
+	if days := entries[0].DaysRemaining; days == nil || *days != -4 {
+		t.Errorf("teams days = %v, want -4", days)
+	}
+	if days := entries[1].DaysRemaining; days == nil || *days != 5 {
+		t.Errorf("users days = %v, want 5", days)
+	}
+	if entries[2].DaysRemaining != nil {
+		t.Error("expected no countdown without a sunset date")
+	}
+}
+
+func TestLifecycleEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/old", func(c *gin.Context) {})
+	r.GET("/internal/old", func(c *gin.Context) {})
+	gd := Mount(r, nil, Config{CSPNonce: func(c *gin.Context) string { return "n0nce" }})
+	gd.Route("GET /old").Deprecated(true)
+	gd.Route("GET /internal/old").Deprecated(true).Audience("internal")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/lifecycle?audience=public", nil))
+	body := w.Body.String()
+	if !strings.Contains(body, "GET /old") || strings.Contains(body, "/internal/old") {
+		t.Errorf("expected only the public operation, got %s", body)
+	}
+	if !strings.Contains(body, `<style nonce="n0nce">`) {
+		t.Error("expected the page style to carry the CSP nonce")
+	}
+}
@@ -0,0 +1,119 @@
+package gindocs
+
+import (
+	"sort"
+	"strings"
+)
+
+// ListConventions names the filtering, sorting, and search query parameters
+// accepted by list endpoints. Empty names are not documented.
+type ListConventions struct {
+	// SortParam is the sort parameter (e.g., "sort"). Its allowed values are
+	// the model's fields, optionally prefixed with "-" for descending order.
+	SortParam string
+
+	// FilterParam is the filter parameter (e.g., "filter"), documented as
+	// filter[field]=value for each of the model's scalar fields.
+	FilterParam string
+
+	// SearchParam is the free-text search parameter (e.g., "q").
+	SearchParam string
+}
+
+// addListParams documents the list conventions on a list operation. Field
+// enums come from the response item schema or, failing that, the schema named
+// after the last path segment (e.g., /users → User).
+func (gd *GinDocs) addListParams(op *OperationObject, path string, lc *ListConventions) {
+	schema := gd.listModelSchema(op, path)
+	fields := listFields(schema)
+
+	if lc.SearchParam != "" {
+		setParameter(op, ParameterObject{
+			Name:        lc.SearchParam,
+			In:          "query",
+			Description: "Search query string",
+			Schema:      &SchemaObject{Type: "string"},
+		})
+	}
+
+	if lc.SortParam != "" {
+		values := &SchemaObject{Type: "string"}
+		for _, name := range fields {
+			values.Enum = append(values.Enum, name, "-"+name)
+		}
+		setParameter(op, ParameterObject{
+			Name:        lc.SortParam,
+			In:          "query",
+			Description: "Sort field; prefix with - for descending order",
+			Schema:      values,
+		})
+	}
+
+	if lc.FilterParam != "" && len(fields) > 0 {
+		filter := &SchemaObject{Type: "object", Properties: make(map[string]*SchemaObject)}
+		for _, name := range fields {
+			prop := schema.Properties[name]
+			filter.Properties[name] = &SchemaObject{Type: prop.Type, Format: prop.Format, Enum: prop.Enum}
+		}
+		explode := true
+		setParameter(op, ParameterObject{
+			Name:        lc.FilterParam,
+			In:          "query",
+			Description: "Filter by field, e.g. " + lc.FilterParam + "[" + fields[0] + "]=value",
+			Style:       "deepObject",
+			Explode:     &explode,
+			Schema:      filter,
+		})
+	}
+}
+
+// listModelSchema resolves the item schema of a list operation.
+func (gd *GinDocs) listModelSchema(op *OperationObject, path string) *SchemaObject {
+	for _, code := range sortedKeys(op.Responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		schema := op.Responses[code].Content["application/json"].Schema
+		if schema != nil && schema.Type == "array" && schema.Items != nil && schema.Items.Ref != "" {
+			if item, ok := gd.registry.Get(strings.TrimPrefix(schema.Items.Ref, RefPath(""))); ok {
+				return item
+			}
+		}
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if item, ok := gd.registry.Get(capitalize(singularize(segments[len(segments)-1]))); ok {
+		return item
+	}
+	return nil
+}
+
+// listFields returns the sorted scalar, readable property names of a schema.
+func listFields(schema *SchemaObject) []string {
+	if schema == nil {
+		return nil
+	}
+	var names []string
+	for name, prop := range schema.Properties {
+		switch prop.Type {
+		case "string", "integer", "number", "boolean":
+			if !prop.WriteOnly {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setParameter adds a parameter to an operation, replacing any parameter
+// with the same name and location.
+func setParameter(op *OperationObject, param ParameterObject) {
+	for i, existing := range op.Parameters {
+		if existing.Name == param.Name && existing.In == param.In {
+			op.Parameters[i] = param
+			return
+		}
+	}
+	op.Parameters = append(op.Parameters, param)
+}
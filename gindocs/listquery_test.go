@@ -0,0 +1,58 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TestListItem struct {
+	ID     int      `json:"id"`
+	Status string   `json:"status" binding:"oneof=open closed"`
+	Secret string   `json:"secret" docs:"writeonly"`
+	Tags   []string `json:"tags"`
+}
+
+func TestListConventions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/items", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{
+		Pagination:      &PaginationConfig{},
+		ListConventions: &ListConventions{SortParam: "sort", FilterParam: "filter", SearchParam: "q"},
+	})
+	gd.Route("GET /items").Response(200, []TestListItem{}, "Items")
+
+	params := make(map[string]ParameterObject)
+	for _, p := range gd.Spec().Paths["/items"].Get.Parameters {
+		if _, dup := params[p.Name]; dup {
+			t.Errorf("parameter %q documented twice", p.Name)
+		}
+		params[p.Name] = p
+	}
+
+	if q := params["q"]; q.In != "query" || q.Schema.Type != "string" {
+		t.Errorf("q = %+v", q)
+	}
+	if sort := strings.Join(enumStrings(params["sort"].Schema.Enum), ","); sort != "id,-id,status,-status" {
+		t.Errorf("expected the sort enum to replace the pagination sort, got %q", sort)
+	}
+
+	filter := params["filter"]
+	if filter.Style != "deepObject" || filter.Explode == nil || !*filter.Explode {
+		t.Errorf("filter = %+v", filter)
+	}
+	if len(filter.Schema.Properties) != 2 || len(filter.Schema.Properties["status"].Enum) != 2 {
+		t.Errorf("expected filters on the readable scalar fields, got %+v", filter.Schema.Properties)
+	}
+}
+
+func enumStrings(values []interface{}) []string {
+	var out []string
+	for _, v := range values {
+		out = append(out, v.(string))
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package gindocs
+
+import "sort"
+
+// ManifestEntry describes one operation in the operations manifest.
+type ManifestEntry struct {
+	OperationID        string              `json:"operationId"`
+	Method             string              `json:"method"`
+	Path               string              `json:"path"`
+	Auth               []string            `json:"auth"`
+	RequestSchemaRefs  []string            `json:"requestSchemaRefs,omitempty"`
+	ResponseSchemaRefs map[string][]string `json:"responseSchemaRefs,omitempty"`
+}
+
+// generateManifest returns a flat list of operations, sorted by path and method.
+// Bodies are reported by the component $refs they use, including those
+// inside arrays and inline wrappers; fully inline bodies are omitted.
+func generateManifest(spec *OpenAPISpec) []ManifestEntry {
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	entries := []ManifestEntry{}
+	for _, path := range paths {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+
+			security := op.Security
+			if security == nil {
+				security = spec.Security
+			}
+
+			entry := ManifestEntry{
+				OperationID: op.OperationID,
+				Method:      method,
+				Path:        path,
+				Auth:        securitySchemeNames(security),
+			}
+			if op.RequestBody != nil {
+				entry.RequestSchemaRefs = contentSchemaRefs(op.RequestBody.Content)
+			}
+			for code, resp := range op.Responses {
+				if refs := contentSchemaRefs(resp.Content); len(refs) > 0 {
+					if entry.ResponseSchemaRefs == nil {
+						entry.ResponseSchemaRefs = make(map[string][]string)
+					}
+					entry.ResponseSchemaRefs[code] = refs
+				}
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// securitySchemeNames lists the sorted scheme names of a security requirement set.
+func securitySchemeNames(security []SecurityRequirement) []string {
+	names := []string{}
+	for _, req := range security {
+		for name := range req {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// contentSchemaRefs returns the component $refs used by a content map's
+// schema, preferring JSON.
+func contentSchemaRefs(content map[string]MediaType) []string {
+	mediaTypes := sortedKeys(content)
+	if _, ok := content["application/json"]; ok {
+		mediaTypes = []string{"application/json"}
+	}
+
+	for _, mediaType := range mediaTypes {
+		if names := schemaRefs(content[mediaType].Schema); len(names) > 0 {
+			refs := make([]string, len(names))
+			for i, name := range names {
+				refs[i] = RefPath(name)
+			}
+			return refs
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestExportManifest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	r.POST("/users", func(c *gin.Context) {})
+	r.DELETE("/users/:id", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Route("GET /users").Response(200, []TestLoginResponse{}, "Users")
+	gd.Route("POST /users").RequestBody(TestLoginRequest{}).Response(201, TestLoginResponse{}, "Created")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/export/manifest.json", nil))
+	var entries []ManifestEntry
+	if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	list, create, del := entries[0], entries[1], entries[2]
+	if list.Method != "GET" || create.Method != "POST" || del.Path != "/users/{id}" {
+		t.Errorf("expected entries sorted by path and method, got %+v", entries)
+	}
+	user := RefPath("TestLoginResponse")
+	if refs := list.ResponseSchemaRefs["200"]; len(refs) != 1 || refs[0] != user {
+		t.Errorf("expected the array item ref, got %v", refs)
+	}
+	if len(create.RequestSchemaRefs) != 1 || create.RequestSchemaRefs[0] != RefPath("TestLoginRequest") {
+		t.Errorf("request refs = %v", create.RequestSchemaRefs)
+	}
+	if del.RequestSchemaRefs != nil || del.Auth == nil {
+		t.Errorf("expected no request refs and an empty auth list, got %+v", del)
+	}
+
+	wrapper := map[string]MediaType{"application/json": {Schema: &SchemaObject{
+		Type: "object",
+		Properties: map[string]*SchemaObject{
+			"items": {Type: "array", Items: SchemaRef("User")},
+			"page":  SchemaRef("Page"),
+		},
+	}}}
+	if refs := strings.Join(contentSchemaRefs(wrapper), ","); refs != RefPath("Page")+","+RefPath("User") {
+		t.Errorf("expected the refs inside the inline wrapper, got %v", refs)
+	}
+}
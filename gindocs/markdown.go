@@ -0,0 +1,281 @@
+package gindocs
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ExportMarkdown returns the documentation as a single Markdown document:
+// API info, custom sections, operations grouped by tag, and schema tables.
+func (gd *GinDocs) ExportMarkdown() string {
+	return generateMarkdown(gd.getSpec(), gd.uiSections())
+}
+
+// generateMarkdown renders spec and sections as Markdown.
+func generateMarkdown(spec *OpenAPISpec, sections []Section) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", spec.Info.Title)
+	if spec.Info.Version != "" {
+		fmt.Fprintf(&b, "Version: `%s`\n\n", spec.Info.Version)
+	}
+	if spec.Info.Description != "" {
+		b.WriteString(strings.TrimSpace(spec.Info.Description) + "\n\n")
+	}
+	if len(spec.Servers) > 0 {
+		b.WriteString("Servers:\n\n")
+		for _, server := range spec.Servers {
+			line := "- `" + server.URL + "`"
+			if server.Description != "" {
+				line += " — " + server.Description
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if spec.ExternalDocs != nil {
+		text := spec.ExternalDocs.Description
+		if text == "" {
+			text = spec.ExternalDocs.URL
+		}
+		fmt.Fprintf(&b, "See also: [%s](%s)\n\n", text, spec.ExternalDocs.URL)
+	}
+
+	for _, section := range sections {
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", section.Title, strings.TrimSpace(section.Content))
+	}
+
+	writeMarkdownOperations(&b, spec)
+
+	if spec.Components != nil && len(spec.Components.Schemas) > 0 {
+		b.WriteString("## Schemas\n\n")
+		for _, name := range sortedKeys(spec.Components.Schemas) {
+			writeMarkdownSchema(&b, name, spec.Components.Schemas[name])
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// specOperation is an operation with its method and path.
+type specOperation struct {
+	method, path string
+	op           *OperationObject
+}
+
+// writeMarkdownOperations writes an operation table and operation details
+// per tag, in the spec's tag order.
+func writeMarkdownOperations(b *strings.Builder, spec *OpenAPISpec) {
+	byTag := make(map[string][]specOperation)
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+			tag := "Other"
+			if len(op.Tags) > 0 {
+				tag = op.Tags[0]
+			}
+			byTag[tag] = append(byTag[tag], specOperation{method, path, op})
+		}
+	}
+	if len(byTag) == 0 {
+		return
+	}
+
+	descriptions := make(map[string]string)
+	var tags []string
+	for _, tag := range spec.Tags {
+		descriptions[tag.Name] = tag.Description
+		if _, ok := byTag[tag.Name]; ok {
+			tags = append(tags, tag.Name)
+		}
+	}
+	for _, tag := range sortedKeys(byTag) {
+		if _, ok := descriptions[tag]; !ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	b.WriteString("## Operations\n\n")
+	for _, tag := range tags {
+		fmt.Fprintf(b, "### %s\n\n", tag)
+		if descriptions[tag] != "" {
+			b.WriteString(strings.TrimSpace(descriptions[tag]) + "\n\n")
+		}
+
+		b.WriteString("| Method | Path | Summary |\n|--------|------|---------|\n")
+		for _, o := range byTag[tag] {
+			summary := o.op.Summary
+			if o.op.Deprecated {
+				summary += " (deprecated)"
+			}
+			fmt.Fprintf(b, "| %s | [`%s`](#%s) | %s |\n", o.method, o.path,
+				markdownAnchor(o.method+" "+o.path), markdownCell(summary))
+		}
+		b.WriteString("\n")
+
+		for _, o := range byTag[tag] {
+			writeMarkdownOperation(b, o)
+		}
+	}
+}
+
+// writeMarkdownOperation writes the parameters, request body and responses
+// of an operation.
+func writeMarkdownOperation(b *strings.Builder, o specOperation) {
+	op := o.op
+	fmt.Fprintf(b, "#### %s %s\n\n", o.method, o.path)
+	if op.Summary != "" {
+		b.WriteString("**" + op.Summary + "**\n\n")
+	}
+	if op.Deprecated {
+		b.WriteString("> Deprecated.")
+		if op.Sunset != "" {
+			b.WriteString(" Removal planned for " + op.Sunset + ".")
+		}
+		if op.ReplacedBy != "" {
+			b.WriteString(" Use `" + op.ReplacedBy + "` instead.")
+		}
+		b.WriteString("\n\n")
+	}
+	if op.Description != "" {
+		b.WriteString(strings.TrimSpace(op.Description) + "\n\n")
+	}
+
+	if len(op.Parameters) > 0 {
+		b.WriteString("| Parameter | In | Type | Required | Description |\n|-----------|----|------|----------|-------------|\n")
+		for _, p := range op.Parameters {
+			fmt.Fprintf(b, "| `%s` | %s | %s | %s | %s |\n", p.Name, p.In, markdownType(p.Schema),
+				markdownYesNo(p.Required), markdownCell(p.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	if op.RequestBody != nil {
+		for _, mediaType := range sortedKeys(op.RequestBody.Content) {
+			fmt.Fprintf(b, "Request body (`%s`): %s\n\n", mediaType, markdownType(op.RequestBody.Content[mediaType].Schema))
+		}
+	}
+
+	if len(op.Responses) > 0 {
+		b.WriteString("| Status | Description | Body |\n|--------|-------------|------|\n")
+		for _, code := range sortedKeys(op.Responses) {
+			resp := op.Responses[code]
+			var bodies []string
+			for _, mediaType := range sortedKeys(resp.Content) {
+				bodies = append(bodies, markdownType(resp.Content[mediaType].Schema))
+			}
+			fmt.Fprintf(b, "| %s | %s | %s |\n", code, markdownCell(resp.Description), strings.Join(bodies, ", "))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// writeMarkdownSchema writes a schema's description and field table.
+func writeMarkdownSchema(b *strings.Builder, name string, schema *SchemaObject) {
+	fmt.Fprintf(b, "### %s\n\n", name)
+	if schema.Description != "" {
+		b.WriteString(strings.TrimSpace(schema.Description) + "\n\n")
+	}
+	if len(schema.Properties) == 0 {
+		fmt.Fprintf(b, "Type: %s\n\n", markdownType(schema))
+		return
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, field := range schema.Required {
+		required[field] = true
+	}
+	b.WriteString("| Field | Type | Required | Description |\n|-------|------|----------|-------------|\n")
+	for _, field := range sortedKeys(schema.Properties) {
+		prop := schema.Properties[field]
+		description := ""
+		if prop != nil {
+			description = prop.Description
+			if prop.ReadOnly {
+				description = strings.TrimSpace("Read-only. " + description)
+			}
+		}
+		fmt.Fprintf(b, "| `%s` | %s | %s | %s |\n", field, markdownType(prop), markdownYesNo(required[field]), markdownCell(description))
+	}
+	b.WriteString("\n")
+}
+
+// markdownType describes a schema's type in a table cell, linking
+// referenced schemas.
+func markdownType(schema *SchemaObject) string {
+	if schema == nil {
+		return "any"
+	}
+	var t string
+	switch {
+	case schema.Ref != "":
+		name := strings.TrimPrefix(schema.Ref, RefPath(""))
+		t = "[" + name + "](#" + markdownAnchor(name) + ")"
+	case len(schema.Enum) > 0:
+		values := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			values = append(values, fmt.Sprintf("`%v`", v))
+		}
+		t = strings.Join(values, " \\| ")
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0:
+		variants := append(append([]*SchemaObject{}, schema.OneOf...), schema.AnyOf...)
+		types := make([]string, 0, len(variants))
+		for _, v := range variants {
+			types = append(types, markdownType(v))
+		}
+		t = strings.Join(types, " or ")
+	case len(schema.AllOf) > 0:
+		types := make([]string, 0, len(schema.AllOf))
+		for _, v := range schema.AllOf {
+			types = append(types, markdownType(v))
+		}
+		t = strings.Join(types, " and ")
+	case schema.Type == "array":
+		t = markdownType(schema.Items) + "[]"
+	case schema.Type == "object" && schema.AdditionalProperties != nil:
+		t = "map of " + markdownType(schema.AdditionalProperties)
+	case schema.Type != "":
+		t = schema.Type
+		if schema.Format != "" {
+			t += " (" + schema.Format + ")"
+		}
+	default:
+		t = "any"
+	}
+	if schema.Nullable {
+		t += ", nullable"
+	}
+	return t
+}
+
+// markdownCell makes text safe for a table cell.
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "|", "\\|")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// markdownYesNo renders a boolean table cell.
+func markdownYesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+// markdownAnchor returns the GitHub-style anchor of a heading.
+func markdownAnchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateMarkdown(t *testing.T) {
+	spec := &OpenAPISpec{
+		Info:    InfoObject{Title: "Users API", Version: "1.2.0"},
+		Servers: []ServerObject{{URL: "https://api.example.com"}},
+		Tags:    []TagObject{{Name: "Users", Description: "Manage users"}},
+		Paths: map[string]*PathItem{
+			"/users/{id}": {
+				Get: &OperationObject{
+					Tags:    []string{"Users"},
+					Summary: "Get a user | by ID",
+					Parameters: []ParameterObject{
+						{Name: "id", In: "path", Required: true, Schema: &SchemaObject{Type: "integer"}},
+					},
+					Responses: map[string]*Response{
+						"200": {Description: "OK", Content: map[string]MediaType{
+							"application/json": {Schema: &SchemaObject{Ref: RefPath("User")}},
+						}},
+					},
+				},
+			},
+			"/health": {Get: &OperationObject{Summary: "Health check"}},
+		},
+		Components: &ComponentsObject{
+			Schemas: map[string]*SchemaObject{
+				"User": {Type: "object", Required: []string{"name"}, Properties: map[string]*SchemaObject{
+					"name": {Type: "string", Description: "Full name"},
+					"tags": {Type: "array", Items: &SchemaObject{Type: "string"}},
+				}},
+			},
+		},
+	}
+
+	got := generateMarkdown(spec, []Section{{Title: "Getting Started", Content: "Call the API."}})
+
+	for _, want := range []string{
+		"# Users API\n",
+		"Version: `1.2.0`",
+		"- `https://api.example.com`",
+		"## Getting Started\n\nCall the API.",
+		"### Users\n\nManage users",
+		"| GET | [`/users/{id}`](#get-usersid) | Get a user \\| by ID |",
+		"### Other",
+		"| `id` | path | integer | yes |  |",
+		"| 200 | OK | [User](#user) |",
+		"| `name` | string | yes | Full name |",
+		"| `tags` | string[] | no |  |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "### Users") > strings.Index(got, "### Other") {
+		t.Error("declared tags should come before untagged operations")
+	}
+}
@@ -0,0 +1,359 @@
+package gindocs
+
+import (
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// markdownToHTML renders markdown as HTML. It supports the common subset:
+// headings, paragraphs, bullet and numbered lists, blockquotes, fenced code
+// blocks, horizontal rules, and inline code, emphasis, links and images. Raw
+// HTML is escaped, and links are limited to http, https, mailto and relative
+// URLs. Headings are demoted by shift levels, so that with a shift of 2 "#"
+// renders as h3 below a section's h2 title.
+func markdownToHTML(src string, shift int) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	renderMarkdownBlocks(&b, lines, shift)
+	return b.String()
+}
+
+// markdownCSS styles rendered markdown in the UI pages.
+const markdownCSS = `.gd-markdown p, .gd-markdown ul, .gd-markdown ol, .gd-markdown pre, .gd-markdown blockquote { margin: 0 0 1em; }
+        .gd-markdown ul, .gd-markdown ol { padding-left: 1.5em; }
+        .gd-markdown code { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 0.9em; background: rgba(0,0,0,0.06); padding: 0.1em 0.3em; border-radius: 3px; }
+        .gd-markdown pre { background: #f4f4f7; padding: 12px 16px; border-radius: 6px; overflow-x: auto; }
+        .gd-markdown pre code { background: none; padding: 0; }
+        .gd-markdown blockquote { border-left: 3px solid #d0d0e0; padding-left: 1em; color: #666; }
+        .gd-markdown a { color: #5c55e6; }
+        .gd-markdown img { max-width: 100%; }`
+
+var (
+	mdHeading   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?[ \t]*#*[ \t]*$`)
+	mdRule      = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdFence     = regexp.MustCompile("^ {0,3}(```+|~~~+)[ \t]*([^ \t`]*)")
+	mdListItem  = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])[ \t]+(.*)$`)
+	mdQuoteLine = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+)
+
+// renderMarkdownBlocks renders lines as block-level markdown.
+func renderMarkdownBlocks(b *strings.Builder, lines []string, shift int) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + renderMarkdownInline(strings.TrimRight(strings.Join(paragraph, "\n"), " \t")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case mdFence.MatchString(line):
+			flush()
+			m := mdFence.FindStringSubmatch(line)
+			fence := strings.TrimSpace(m[1])
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence[:3]) && strings.Trim(strings.TrimSpace(lines[i]), fence[:1]) == "" && len(strings.TrimSpace(lines[i])) >= len(fence) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code")
+			if m[2] != "" {
+				b.WriteString(` class="language-` + template.HTMLEscapeString(m[2]) + `"`)
+			}
+			b.WriteString(">" + template.HTMLEscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case mdHeading.MatchString(line):
+			flush()
+			m := mdHeading.FindStringSubmatch(line)
+			level := len(m[1]) + shift
+			if level > 6 {
+				level = 6
+			}
+			tag := "h" + string(rune('0'+level))
+			b.WriteString("<" + tag + ">" + renderMarkdownInline(m[2]) + "</" + tag + ">\n")
+
+		case mdRule.MatchString(line):
+			flush()
+			b.WriteString("<hr>\n")
+
+		case mdQuoteLine.MatchString(line):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && mdQuoteLine.MatchString(lines[i]); i++ {
+				quoted = append(quoted, mdQuoteLine.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			b.WriteString("<blockquote>\n")
+			renderMarkdownBlocks(b, quoted, shift)
+			b.WriteString("</blockquote>\n")
+
+		case mdListItem.MatchString(line):
+			flush()
+			i = renderMarkdownList(b, lines, i, shift) - 1
+
+		default:
+			paragraph = append(paragraph, strings.TrimLeft(line, " \t"))
+		}
+	}
+	flush()
+}
+
+// renderMarkdownList renders the list starting at lines[start] and returns
+// the index of the first line after it. Lines indented past the marker
+// continue the current item and may hold nested lists.
+func renderMarkdownList(b *strings.Builder, lines []string, start, shift int) int {
+	first := mdListItem.FindStringSubmatch(lines[start])
+	ordered := first[2][0] >= '0' && first[2][0] <= '9'
+	indent := len(first[1])
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if n := strings.TrimLeft(first[2][:len(first[2])-1], "0"); n != "" && n != "1" {
+			tag = `ol start="` + n + `"`
+		}
+	}
+	b.WriteString("<" + tag + ">\n")
+
+	var item []string
+	offset := 0
+	loose := false
+	writeItem := func() {
+		if item == nil {
+			return
+		}
+		var inner strings.Builder
+		renderMarkdownBlocks(&inner, item, shift)
+		html := strings.TrimSuffix(inner.String(), "\n")
+		if !loose {
+			// Tight lists don't wrap their text in paragraphs.
+			html = strings.ReplaceAll(strings.ReplaceAll(html, "<p>", ""), "</p>", "")
+		}
+		b.WriteString("<li>" + html + "</li>\n")
+		item = nil
+	}
+
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if m := mdListItem.FindStringSubmatch(line); m != nil && len(m[1]) <= indent {
+			if (m[2][0] >= '0' && m[2][0] <= '9') != ordered {
+				break
+			}
+			writeItem()
+			item = []string{m[3]}
+			offset = len(line) - len(m[3])
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			// A blank line ends the list unless the next line is another
+			// item or is indented to continue this one.
+			if i+1 >= len(lines) {
+				break
+			}
+			next := lines[i+1]
+			if m := mdListItem.FindStringSubmatch(next); m == nil || len(m[1]) > indent {
+				if leadingSpaces(next) <= indent {
+					break
+				}
+				item = append(item, "")
+			}
+			loose = true
+			continue
+		}
+		// Indented lines continue the item; unindented ones continue its
+		// last paragraph unless they start another block.
+		if leadingSpaces(line) <= indent && (mdHeading.MatchString(line) || mdFence.MatchString(line) || mdRule.MatchString(line) || mdQuoteLine.MatchString(line)) {
+			break
+		}
+		item = append(item, strings.TrimPrefix(line, strings.Repeat(" ", min(leadingSpaces(line), offset))))
+	}
+	writeItem()
+
+	if ordered {
+		b.WriteString("</ol>\n")
+	} else {
+		b.WriteString("</ul>\n")
+	}
+	return i
+}
+
+// leadingSpaces counts the spaces a line starts with, a tab counting as
+// four.
+func leadingSpaces(line string) int {
+	n := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// renderMarkdownInline renders inline markdown, escaping everything else.
+func renderMarkdownInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_{}[]()#+-.!<>|~", s[i+1]) >= 0:
+			b.WriteString(template.HTMLEscapeString(s[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			run := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+			fence := s[i : i+run]
+			if end := strings.Index(s[i+run:], fence); end >= 0 {
+				code := s[i+run : i+run+end]
+				if trimmed := strings.TrimSpace(code); trimmed != "" {
+					code = trimmed
+				}
+				b.WriteString("<code>" + template.HTMLEscapeString(code) + "</code>")
+				i += run + end + run
+				continue
+			}
+			b.WriteString(fence)
+			i += run
+			continue
+
+		case c == '!' && strings.HasPrefix(s[i+1:], "["):
+			if text, url, n, ok := markdownLink(s[i+1:]); ok && safeMarkdownURL(url) {
+				b.WriteString(`<img src="` + template.HTMLEscapeString(url) + `" alt="` + template.HTMLEscapeString(text) + `">`)
+				i += 1 + n
+				continue
+			}
+
+		case c == '[':
+			if text, url, n, ok := markdownLink(s[i:]); ok && safeMarkdownURL(url) {
+				b.WriteString(`<a href="` + template.HTMLEscapeString(url) + `" target="_blank" rel="noopener">` + renderMarkdownInline(text) + "</a>")
+				i += n
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				url := s[i+1 : i+end]
+				if !strings.ContainsAny(url, " \n") && (strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")) {
+					escaped := template.HTMLEscapeString(url)
+					b.WriteString(`<a href="` + escaped + `" target="_blank" rel="noopener">` + escaped + "</a>")
+					i += end + 1
+					continue
+				}
+			}
+
+		case (c == '*' || c == '_') && i+1 < len(s) && s[i+1] == c:
+			delim := s[i : i+2]
+			if end := strings.Index(s[i+2:], delim); end > 0 && emphasisBoundary(s, i, i+2+end+2, c) {
+				b.WriteString("<strong>" + renderMarkdownInline(s[i+2:i+2+end]) + "</strong>")
+				i += 2 + end + 2
+				continue
+			}
+
+		case c == '*' || c == '_':
+			if end := closingEmphasis(s[i+1:], c); end > 0 && emphasisBoundary(s, i, i+1+end+1, c) {
+				b.WriteString("<em>" + renderMarkdownInline(s[i+1:i+1+end]) + "</em>")
+				i += 1 + end + 1
+				continue
+			}
+
+		case c == ' ':
+			// Two or more trailing spaces make a hard line break.
+			j := i
+			for j < len(s) && s[j] == ' ' {
+				j++
+			}
+			if j-i >= 2 && j < len(s) && s[j] == '\n' {
+				b.WriteString("<br>\n")
+				i = j + 1
+				continue
+			}
+		}
+		b.WriteString(template.HTMLEscapeString(s[i : i+1]))
+		i++
+	}
+	return b.String()
+}
+
+// markdownLink parses "[text](url)" at the start of s, returning the text,
+// the URL without its optional title, and the length consumed.
+func markdownLink(s string) (text, url string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				if !strings.HasPrefix(s[i+1:], "(") {
+					return "", "", 0, false
+				}
+				end := strings.IndexByte(s[i+2:], ')')
+				if end < 0 {
+					return "", "", 0, false
+				}
+				fields := strings.Fields(s[i+2 : i+2+end])
+				if len(fields) == 0 {
+					return "", "", 0, false
+				}
+				return s[1:i], strings.Trim(fields[0], "<>"), i + 2 + end + 1, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+// safeMarkdownURL reports whether url is relative or uses the http, https or
+// mailto scheme.
+func safeMarkdownURL(url string) bool {
+	colon := strings.IndexByte(url, ':')
+	if colon < 0 || strings.ContainsAny(url[:colon], "/?#") {
+		return true
+	}
+	switch strings.ToLower(url[:colon]) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// closingEmphasis returns the index in s of the delimiter closing a single
+// c emphasis, or -1.
+func closingEmphasis(s string, c byte) int {
+	if s == "" || s[0] == ' ' {
+		return -1
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] == c && s[i-1] != ' ' && (i+1 == len(s) || s[i+1] != c) {
+			return i
+		}
+	}
+	return -1
+}
+
+// emphasisBoundary reports whether the emphasis s[start:end] delimited by c
+// stands on word boundaries. Underscores inside words, as in snake_case,
+// are literal.
+func emphasisBoundary(s string, start, end int, c byte) bool {
+	if c != '_' {
+		return true
+	}
+	isWord := func(b byte) bool {
+		return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+	}
+	return (start == 0 || !isWord(s[start-1])) && (end >= len(s) || !isWord(s[end]))
+}
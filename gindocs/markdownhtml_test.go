@@ -0,0 +1,43 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Hello **world**", "<p>Hello <strong>world</strong></p>\n"},
+		{"Use `snake_case` and _emphasis_ but not snake_case", "<p>Use <code>snake_case</code> and <em>emphasis</em> but not snake_case</p>\n"},
+		{"# Intro", "<h3>Intro</h3>\n"},
+		{"See [the guide](https://example.com/a?b=1&c=2).", `<p>See <a href="https://example.com/a?b=1&amp;c=2" target="_blank" rel="noopener">the guide</a>.</p>` + "\n"},
+		{"[bad](javascript:alert(1))", "<p>[bad](javascript:alert(1))</p>\n"},
+		{"<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"- one\n- two\n  - nested\n- three", "<ul>\n<li>one</li>\n<li>two\n<ul>\n<li>nested</li>\n</ul></li>\n<li>three</li>\n</ul>\n"},
+		{"3. c\n4. d", "<ol start=\"3\">\n<li>c</li>\n<li>d</li>\n</ol>\n"},
+		{"```bash\ncurl -H 'A: <b>'\n```", "<pre><code class=\"language-bash\">curl -H &#39;A: &lt;b&gt;&#39;</code></pre>\n"},
+		{"> quoted\n> text", "<blockquote>\n<p>quoted\ntext</p>\n</blockquote>\n"},
+		{"a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>\n"},
+		{"line one  \nline two", "<p>line one<br>\nline two</p>\n"},
+	}
+	for _, tt := range tests {
+		if got := markdownToHTML(tt.in, 2); got != tt.want {
+			t.Errorf("markdownToHTML(%q) =\n%s\nwant\n%s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCustomSectionsRenderMarkdown(t *testing.T) {
+	cfg := Config{CustomSections: []Section{{Title: "Auth", Content: "Send a `token`:\n\n- one\n- two"}}}
+	for _, html := range []string{
+		renderScalarHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg),
+		renderSwaggerHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg),
+	} {
+		if !strings.Contains(html, "<p>Send a <code>token</code>:</p>") || !strings.Contains(html, "<li>one</li>") {
+			t.Error("expected custom section markdown to be rendered")
+		}
+		if strings.Contains(html, "pre-wrap") {
+			t.Error("expected sections without pre-wrap")
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package gindocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// mergeSource is a Config.MergeSpecs document as JSON, or the error that
+// kept it from loading.
+type mergeSource struct {
+	name string
+	data []byte
+	err  error
+}
+
+// loadMergeSpecs reads the Config.MergeSpecs documents once, so builds
+// neither re-read files nor re-fetch URLs.
+func (gd *GinDocs) loadMergeSpecs() {
+	for _, source := range gd.config.MergeSpecs {
+		data, err := readSpec(source)
+		gd.mergeSources = append(gd.mergeSources, mergeSource{name: source, data: data, err: err})
+	}
+}
+
+// mergeSpecs merges the paths, tags, components and security schemes of the
+// Config.MergeSpecs documents into spec. The generated spec wins on
+// conflicts; conflicts and unreadable documents are returned as warnings.
+func (gd *GinDocs) mergeSpecs(spec *OpenAPISpec) []string {
+	var warnings []string
+	for _, source := range gd.mergeSources {
+		if source.err != nil {
+			warnings = append(warnings, fmt.Sprintf("MergeSpecs: %v", source.err))
+			continue
+		}
+		// Each build decodes a fresh copy, since later passes modify the
+		// merged operations.
+		other, err := parseSpec(source.data, source.name)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("MergeSpecs: %v", err))
+			continue
+		}
+		if !strings.HasPrefix(other.OpenAPI, "3.") {
+			warnings = append(warnings, fmt.Sprintf("MergeSpecs: %s is not an OpenAPI 3 document", source.name))
+			continue
+		}
+		warnings = append(warnings, mergeSpec(spec, other, source.name)...)
+	}
+	return warnings
+}
+
+// mergeSpec merges other into spec, returning the conflicts.
+func mergeSpec(spec, other *OpenAPISpec, source string) []string {
+	var warnings []string
+
+	for _, path := range sortedKeys(other.Paths) {
+		item, ok := spec.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			spec.Paths[path] = item
+		}
+		for _, method := range httpMethods {
+			op := other.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+			if item.GetOperation(method) != nil {
+				warnings = append(warnings, fmt.Sprintf("MergeSpecs: %s %s from %s is already documented", method, path, source))
+				continue
+			}
+			item.SetOperation(method, op)
+		}
+	}
+
+	known := make(map[string]bool, len(spec.Tags))
+	for _, tag := range spec.Tags {
+		known[tag.Name] = true
+	}
+	for _, tag := range other.Tags {
+		if !known[tag.Name] {
+			known[tag.Name] = true
+			spec.Tags = append(spec.Tags, tag)
+		}
+	}
+
+	if other.Components == nil {
+		return warnings
+	}
+	if spec.Components == nil {
+		spec.Components = &ComponentsObject{}
+	}
+	c, o := spec.Components, other.Components
+	warnings = append(warnings, mergeComponents(&c.Schemas, o.Schemas, "schema", source)...)
+	warnings = append(warnings, mergeComponents(&c.SecuritySchemes, o.SecuritySchemes, "security scheme", source)...)
+	warnings = append(warnings, mergeComponents(&c.Parameters, o.Parameters, "parameter", source)...)
+	warnings = append(warnings, mergeComponents(&c.RequestBodies, o.RequestBodies, "request body", source)...)
+	warnings = append(warnings, mergeComponents(&c.Responses, o.Responses, "response", source)...)
+	return warnings
+}
+
+// mergeComponents adds the components of src missing from dst. Components
+// that exist in both with different definitions are reported.
+func mergeComponents[V any](dst *map[string]V, src map[string]V, kind, source string) []string {
+	var warnings []string
+	for _, name := range sortedKeys(src) {
+		if *dst == nil {
+			*dst = make(map[string]V)
+		}
+		existing, ok := (*dst)[name]
+		if !ok {
+			(*dst)[name] = src[name]
+			continue
+		}
+		a, _ := json.Marshal(existing)
+		b, _ := json.Marshal(src[name])
+		if !bytes.Equal(a, b) {
+			warnings = append(warnings, fmt.Sprintf("MergeSpecs: %s %q from %s differs from the generated one", kind, name, source))
+		}
+	}
+	return warnings
+}
+
+// LoadSpec reads an OpenAPI JSON or YAML document from a file path or an
+// http(s) URL.
+func LoadSpec(source string) (*OpenAPISpec, error) {
+	data, err := readSpec(source)
+	if err != nil {
+		return nil, err
+	}
+	return parseSpec(data, source)
+}
+
+// readSpec reads a JSON or YAML document from a file path or an http(s) URL
+// and returns it as JSON.
+func readSpec(source string) ([]byte, error) {
+	var data []byte
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Get(source)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", source, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetching %s: %s", source, resp.Status)
+		}
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", source, err)
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(source); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", source, err)
+		}
+	}
+
+	// YAML is a superset of JSON, but JSON decodes faster and keeps
+	// large integers exact.
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		converted, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", source, err)
+		}
+		data = converted
+	}
+	return data, nil
+}
+
+// parseSpec decodes a JSON OpenAPI document read from source.
+func parseSpec(data []byte, source string) (*OpenAPISpec, error) {
+	var spec OpenAPISpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", source, err)
+	}
+	return &spec, nil
+}
@@ -0,0 +1,114 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMergeSpecs(t *testing.T) {
+	legacy := `openapi: 3.0.3
+info:
+  title: Legacy
+  version: "1"
+tags:
+  - name: Billing
+paths:
+  /invoices:
+    get:
+      tags: [Billing]
+      summary: List invoices
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Invoice"
+        "404":
+          $ref: "#/components/responses/NotFound"
+  /users:
+    get:
+      summary: Legacy users
+components:
+  schemas:
+    Invoice:
+      type: object
+      properties:
+        total:
+          type: number
+    Problem:
+      type: object
+      properties:
+        detail:
+          type: string
+  responses:
+    NotFound:
+      description: Not found
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/Problem"
+  securitySchemes:
+    legacyKey:
+      type: apiKey
+      name: X-Legacy-Key
+      in: header
+`
+	path := filepath.Join(t.TempDir(), "legacy.yaml")
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	gd := Mount(r, nil, Config{MergeSpecs: []string{path, filepath.Join(t.TempDir(), "missing.json")}})
+
+	spec := gd.getSpec()
+	if op := spec.Paths["/invoices"].GetOperation("GET"); op == nil || op.Summary != "List invoices" {
+		t.Fatalf("merged operation = %+v", op)
+	}
+	if op := spec.Paths["/users"].GetOperation("GET"); op.Summary == "Legacy users" {
+		t.Error("generated operation should win over the merged one")
+	}
+	if _, ok := spec.Components.Schemas["Invoice"]; !ok {
+		t.Error("merged schema is missing")
+	}
+	if _, ok := spec.Components.SecuritySchemes["legacyKey"]; !ok {
+		t.Error("merged security scheme is missing")
+	}
+	if tags := spec.Tags; len(tags) == 0 || tags[len(tags)-1].Name != "Billing" {
+		t.Errorf("merged tag is missing: %+v", tags)
+	}
+
+	if resp := spec.Paths["/invoices"].Get.Responses["404"]; resp == nil || resp.Ref != "#/components/responses/NotFound" {
+		t.Errorf("404 response = %+v, want the $ref kept", resp)
+	}
+	data, _ := json.Marshal(inlineSchemas(spec))
+	if strings.Contains(string(data), "#/components/schemas/") {
+		t.Errorf("inlined spec has dangling schema refs: %s", data)
+	}
+
+	// The documents are read once at Mount, not on every build.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	gd.Rebuild()
+	if spec := gd.getSpec(); spec.Paths["/invoices"] == nil {
+		t.Error("merged paths lost on rebuild")
+	}
+
+	warnings := strings.Join(gd.Warnings(), "\n")
+	for _, want := range []string{"GET /users from " + path + " is already documented", "missing.json"} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings missing %q:\n%s", want, warnings)
+		}
+	}
+}
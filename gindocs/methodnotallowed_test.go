@@ -0,0 +1,35 @@
+package gindocs
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMethodNotAllowed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/widgets", func(c *gin.Context) {})
+	r.POST("/widgets", func(c *gin.Context) {})
+	r.DELETE("/widgets/:id", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{MethodNotAllowed: true})
+	gd.Route("DELETE /widgets/:id").Response(405, nil, "Widgets can't be deleted")
+
+	paths := gd.Spec().Paths
+	for _, op := range []*OperationObject{paths["/widgets"].Get, paths["/widgets"].Post} {
+		resp := op.Responses["405"]
+		if resp == nil || resp.Headers["Allow"] == nil || resp.Headers["Allow"].Schema.Example != "GET, POST" {
+			t.Errorf("expected 405 with Allow: GET, POST, got %+v", resp)
+		}
+	}
+	if resp := paths["/widgets/{id}"].Delete.Responses["405"]; resp.Description != "Widgets can't be deleted" {
+		t.Errorf("expected the explicit 405 to be kept, got %+v", resp)
+	}
+
+	r = gin.New()
+	r.GET("/widgets", func(c *gin.Context) {})
+	if resp := Mount(r, nil).Spec().Paths["/widgets"].Get.Responses["405"]; resp != nil {
+		t.Errorf("expected no 405 by default, got %+v", resp)
+	}
+}
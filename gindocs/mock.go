@@ -0,0 +1,147 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mockStatusHeader selects which documented response the mock server returns.
+const mockStatusHeader = "X-Mock-Status"
+
+// handleMock serves example responses for documented operations at
+// {prefix}/mock/<path>. The lowest 2xx response is returned unless the
+// X-Mock-Status header names another documented status code.
+func (gd *GinDocs) handleMock(c *gin.Context) {
+	spec := gd.requestSpec(c)
+	path := c.Param("path")
+
+	op, params := matchOperation(spec, c.Request.Method, path)
+	if op == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no documented operation for " + c.Request.Method + " " + path})
+		return
+	}
+
+	code := c.GetHeader(mockStatusHeader)
+	if code == "" {
+		code = firstSuccessCode(op.Responses)
+	}
+	resp, ok := op.Responses[code]
+	status, err := strconv.Atoi(code)
+	if !ok || err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status " + code + " is not documented for this operation"})
+		return
+	}
+
+	c.Header("X-Mock", "true")
+	media, ok := resp.Content["application/json"]
+	if !ok || media.Schema == nil {
+		c.Status(status)
+		return
+	}
+
+	var schemas map[string]*SchemaObject
+	if spec.Components != nil {
+		schemas = spec.Components.Schemas
+	}
+	body := media.Example
+	if body == nil {
+		body = sampleValue(media.Schema, schemas, "", map[string]bool{})
+	}
+	c.JSON(status, withPathParams(body, params))
+}
+
+// matchOperation finds the operation whose path template matches path,
+// preferring templates with more literal segments. It returns the operation
+// and the path parameter values.
+func matchOperation(spec *OpenAPISpec, method, path string) (*OperationObject, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var best *OperationObject
+	var bestParams map[string]string
+	bestLiterals := -1
+	for _, template := range sortedKeys(spec.Paths) {
+		op := spec.Paths[template].GetOperation(method)
+		if op == nil {
+			continue
+		}
+		parts := strings.Split(strings.Trim(template, "/"), "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+
+		params := make(map[string]string)
+		literals := 0
+		for i, part := range parts {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				if segments[i] == "" {
+					literals = -1
+					break
+				}
+				params[part[1:len(part)-1]] = segments[i]
+				continue
+			}
+			if part != segments[i] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > bestLiterals {
+			best, bestParams, bestLiterals = op, params, literals
+		}
+	}
+
+	return best, bestParams
+}
+
+// firstSuccessCode returns the lowest 2xx status code, or the lowest code.
+func firstSuccessCode(responses map[string]*Response) string {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if strings.HasPrefix(code, "2") {
+			return code
+		}
+	}
+	if len(codes) > 0 {
+		return codes[0]
+	}
+	return ""
+}
+
+// withPathParams copies path parameter values into matching top-level fields
+// of an object example, so GET /users/42 returns a user with id 42.
+func withPathParams(body interface{}, params map[string]string) interface{} {
+	obj, ok := body.(map[string]interface{})
+	if !ok || len(params) == 0 {
+		return body
+	}
+
+	out := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		out[k] = v
+	}
+	for name, value := range params {
+		current, ok := out[name]
+		if !ok {
+			continue
+		}
+		switch current.(type) {
+		case int, int64, float64, json.Number:
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				out[name] = n
+			}
+		case string:
+			out[name] = value
+		}
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMockServer(t *testing.T) {
+	type Widget struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/widgets/:id", func(c *gin.Context) {})
+	r.GET("/widgets/featured", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{MockServer: true})
+	gd.Route("GET /widgets/:id").Response(200, Widget{}, "Widget").Response(404, nil, "Not found")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/docs/mock/widgets/42", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var widget Widget
+	if err := json.Unmarshal(w.Body.Bytes(), &widget); err != nil {
+		t.Fatalf("invalid mock body: %v", err)
+	}
+	if widget.ID != 42 {
+		t.Errorf("expected the path param to fill id, got %d", widget.ID)
+	}
+
+	req := httptest.NewRequest("GET", "/docs/mock/widgets/42", nil)
+	req.Header.Set(mockStatusHeader, "404")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound || w.Body.Len() != 0 {
+		t.Errorf("expected an empty 404, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if op, _ := matchOperation(gd.getSpec(), "GET", "/widgets/featured"); op == nil || op.OperationID != "getWidgetsFeatured" {
+		t.Errorf("expected the literal path to win over the parameter, got %+v", op)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", "/docs/mock/widgets/42", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an undocumented operation, got %d", w.Code)
+	}
+}
@@ -0,0 +1,60 @@
+package gindocs
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	// globalModelsMu guards globalModels.
+	globalModelsMu sync.RWMutex
+	// globalModels holds models registered with Models.
+	globalModels []interface{}
+)
+
+// Models registers GORM models for every GinDocs instance, so feature
+// packages can register their own models instead of listing them all in
+// Config.Models:
+//
+//	func init() {
+//		gindocs.Models(User{}, Team{})
+//	}
+func Models(models ...interface{}) {
+	globalModelsMu.Lock()
+	defer globalModelsMu.Unlock()
+	globalModels = append(globalModels, models...)
+}
+
+// AddModels registers additional GORM models after Mount. The spec is
+// rebuilt on the next request.
+func (gd *GinDocs) AddModels(models ...interface{}) {
+	gd.modelsMu.Lock()
+	gd.config.Models = append(gd.config.Models[:len(gd.config.Models):len(gd.config.Models)], models...)
+	gd.modelsMu.Unlock()
+
+	gd.InvalidateCache()
+}
+
+// models returns Config.Models, models added with AddModels, and models
+// registered with Models, skipping repeated types.
+func (gd *GinDocs) models() []interface{} {
+	gd.modelsMu.RLock()
+	all := append([]interface{}{}, gd.config.Models...)
+	gd.modelsMu.RUnlock()
+
+	globalModelsMu.RLock()
+	all = append(all, globalModels...)
+	globalModelsMu.RUnlock()
+
+	seen := make(map[reflect.Type]bool, len(all))
+	models := all[:0]
+	for _, model := range all {
+		t := derefType(reflect.TypeOf(model))
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		models = append(models, model)
+	}
+	return models
+}
@@ -0,0 +1,58 @@
+package gindocs
+
+import "testing"
+
+func TestModels_Registration(t *testing.T) {
+	type Invoice struct {
+		ID    uint   `json:"id" gorm:"primaryKey"`
+		Total int    `json:"total"`
+		Note  string `json:"note"`
+	}
+	type Payment struct {
+		ID uint `json:"id" gorm:"primaryKey"`
+	}
+
+	globalModelsMu.Lock()
+	saved := globalModels
+	globalModelsMu.Unlock()
+	t.Cleanup(func() {
+		globalModelsMu.Lock()
+		globalModels = saved
+		globalModelsMu.Unlock()
+	})
+
+	Models(Invoice{})
+	gd := newGinDocs(nil, nil, mergeConfig(Config{Models: []interface{}{&Invoice{}}}))
+	gd.AddModels(Payment{})
+
+	models := gd.models()
+	if len(models) != 2 {
+		t.Fatalf("expected 2 models after de-duplication, got %d", len(models))
+	}
+
+	gd.registerGORMModels()
+	for _, name := range []string{"Invoice", "CreateInvoice", "Payment", "UpdatePayment"} {
+		if !gd.registry.Has(name) {
+			t.Errorf("expected schema %s to be registered", name)
+		}
+	}
+}
+
+func TestModels_QualifiedVariantNames(t *testing.T) {
+	type Widget struct {
+		ID   uint   `json:"id" gorm:"primaryKey"`
+		Name string `json:"name"`
+	}
+
+	gd := newGinDocs(nil, nil, mergeConfig(Config{SchemaNaming: SchemaNamingPackage, Models: []interface{}{Widget{}}}))
+	gd.registerGORMModels()
+	for _, name := range []string{"gindocs.Widget", "gindocs.CreateWidget", "gindocs.UpdateWidget"} {
+		if !gd.registry.Has(name) {
+			t.Errorf("expected schema %s to be registered, got %v", name, sortedKeys(gd.registry.All()))
+		}
+	}
+
+	if got := variantName("Create", "Page[gindocs.Widget]"); got != "CreatePage[gindocs.Widget]" {
+		t.Errorf("generic variant = %q", got)
+	}
+}
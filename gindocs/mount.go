@@ -1,6 +1,8 @@
 package gindocs
 
 import (
+	"slices"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -8,11 +10,38 @@ import (
 // Mount registers Gin Docs routes on the given router.
 // db is optional — pass nil if not using GORM models.
 // configs is variadic — pass zero or one Config.
+// No routes are registered with Config.Disabled set, or in release mode
+// unless Config.EnabledEnvironments includes it.
+// With Config.Strict set, call MustCheck once the overrides are registered
+// to fail at startup if any route violates the policy.
+//
+// A router can carry several instances with different Prefixes, e.g. public
+// docs at /docs and admin docs at /internal/docs with IncludePrefixes set.
+// Each keeps its own overrides and leaves the other's doc routes out.
 func Mount(router *gin.Engine, db *gorm.DB, configs ...Config) *GinDocs {
 	cfg := mergeConfig(configs...)
 
 	gd := newGinDocs(router, db, cfg)
+	// Overrides apply to Spec() and exports even when no routes are served.
+	gd.loadOverridesFile()
+	gd.loadMergeSpecs()
+	if !docsEnabled(cfg) {
+		gd.disabled = true
+		return gd
+	}
 	gd.registerHandlers()
 
 	return gd
 }
+
+// docsEnabled reports whether the docs routes are registered in the current
+// Gin mode.
+func docsEnabled(cfg Config) bool {
+	if cfg.Disabled {
+		return false
+	}
+	if len(cfg.EnabledEnvironments) > 0 {
+		return slices.Contains(cfg.EnabledEnvironments, gin.Mode())
+	}
+	return gin.Mode() != gin.ReleaseMode
+}
@@ -0,0 +1,55 @@
+package gindocs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// section renders the requirements as a documentation section.
+// Returns false if there is nothing to document.
+func (n *NetworkRequirements) section() (Section, bool) {
+	if n == nil {
+		return Section{}, false
+	}
+
+	var lines []string
+	if len(n.AllowedIPRanges) > 0 {
+		ranges := "Requests are only accepted from these IP ranges:\n"
+		for _, r := range n.AllowedIPRanges {
+			ranges += "\n- `" + r + "`"
+		}
+		lines = append(lines, ranges)
+	}
+	if n.MinTLSVersion != "" {
+		lines = append(lines, fmt.Sprintf("TLS %s or newer is required.", n.MinTLSVersion))
+	}
+	if n.MutualTLS {
+		lines = append(lines, "Clients must present a TLS client certificate (mutual TLS).")
+	}
+	if n.SNI != "" {
+		lines = append(lines, fmt.Sprintf("Clients must send the SNI server name `%s`.", n.SNI))
+	}
+	if n.Notes != "" {
+		lines = append(lines, n.Notes)
+	}
+
+	if len(lines) == 0 {
+		return Section{}, false
+	}
+	return Section{Title: "Network Requirements", Content: strings.Join(lines, "\n\n")}, true
+}
+
+// uiSections returns the custom sections not attached to a tag plus
+// generated ones.
+func (gd *GinDocs) uiSections() []Section {
+	var sections []Section
+	for _, section := range gd.config.CustomSections {
+		if section.Tag == "" {
+			sections = append(sections, section)
+		}
+	}
+	if section, ok := gd.config.NetworkRequirements.section(); ok {
+		sections = append(sections, section)
+	}
+	return sections
+}
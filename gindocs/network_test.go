@@ -0,0 +1,45 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNetworkRequirements(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", func(c *gin.Context) {})
+	gd := Mount(r, nil, Config{
+		CustomSections: []Section{{Title: "Support", Content: "Email us."}},
+		NetworkRequirements: &NetworkRequirements{
+			AllowedIPRanges: []string{"10.0.0.0/8"},
+			MinTLSVersion:   "1.2",
+			MutualTLS:       true,
+		},
+	})
+
+	data, err := json.Marshal(gd.Spec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"x-network":{"allowedIpRanges":["10.0.0.0/8"],"minTlsVersion":"1.2","mutualTls":true}`) {
+		t.Errorf("expected x-network in the spec, got %s", data)
+	}
+
+	sections := gd.uiSections()
+	if len(sections) != 2 || sections[1].Title != "Network Requirements" {
+		t.Fatalf("uiSections = %+v", sections)
+	}
+	for _, want := range []string{"- `10.0.0.0/8`", "TLS 1.2 or newer is required.", "mutual TLS"} {
+		if !strings.Contains(sections[1].Content, want) {
+			t.Errorf("expected %q in:\n%s", want, sections[1].Content)
+		}
+	}
+
+	if _, ok := (&NetworkRequirements{}).section(); ok {
+		t.Error("expected no section for empty requirements")
+	}
+}
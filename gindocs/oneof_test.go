@@ -0,0 +1,39 @@
+package gindocs
+
+import "testing"
+
+func TestResponseOneOf_Discriminator(t *testing.T) {
+	type SearchUserResult struct {
+		Kind string `json:"kind" binding:"required,oneof=user"`
+		Name string `json:"name"`
+	}
+	type SearchPostResult struct {
+		Kind  string `json:"kind" binding:"required,oneof=post"`
+		Title string `json:"title"`
+	}
+
+	gd := newGinDocs(nil, nil, mergeConfig())
+	gd.Route("GET /search").
+		ResponseOneOfArray(200, "Search results", SearchUserResult{}, SearchPostResult{}).
+		Discriminator("kind")
+
+	op := gd.buildOperation(RouteMetadata{Method: "GET", Path: "/search"})
+	schema := op.Responses["200"].Content["application/json"].Schema
+	if schema == nil || schema.Type != "array" || schema.Items == nil {
+		t.Fatalf("expected an array response, got %+v", schema)
+	}
+
+	union := schema.Items
+	if len(union.OneOf) != 2 {
+		t.Fatalf("expected 2 oneOf variants, got %d", len(union.OneOf))
+	}
+	if union.Discriminator == nil || union.Discriminator.PropertyName != "kind" {
+		t.Fatalf("expected discriminator on kind, got %+v", union.Discriminator)
+	}
+	if got := union.Discriminator.Mapping["user"]; got != RefPath("SearchUserResult") {
+		t.Errorf("expected user to map to SearchUserResult, got %q", got)
+	}
+	if got := union.Discriminator.Mapping["post"]; got != RefPath("SearchPostResult") {
+		t.Errorf("expected post to map to SearchPostResult, got %q", got)
+	}
+}
@@ -1,7 +1,12 @@
 package gindocs
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -16,9 +21,11 @@ func (gd *GinDocs) assembleSpec() *OpenAPISpec {
 	spec := &OpenAPISpec{
 		OpenAPI: "3.1.0",
 		Info: InfoObject{
-			Title:       title,
-			Description: gd.config.Description,
-			Version:     gd.config.Version,
+			Title:          title,
+			Description:    gd.config.Description,
+			TermsOfService: gd.config.TermsOfService,
+			Version:        gd.config.Version,
+			Extensions:     gd.config.InfoExtensions,
 		},
 		Paths: make(map[string]*PathItem),
 		Components: &ComponentsObject{
@@ -43,12 +50,34 @@ func (gd *GinDocs) assembleSpec() *OpenAPISpec {
 		}
 	}
 
+	// Add external docs.
+	if gd.config.ExternalDocs != (ExternalDocsInfo{}) {
+		spec.ExternalDocs = &ExternalDocsObject{
+			Description: gd.config.ExternalDocs.Description,
+			URL:         gd.config.ExternalDocs.URL,
+		}
+	}
+
+	// Add network requirements.
+	spec.Network = gd.config.NetworkRequirements
+
 	// Add servers.
 	for _, s := range gd.config.Servers {
-		spec.Servers = append(spec.Servers, ServerObject{
+		server := ServerObject{
 			URL:         s.URL,
 			Description: s.Description,
-		})
+		}
+		for name, v := range s.Variables {
+			if server.Variables == nil {
+				server.Variables = make(map[string]*ServerVariableObject)
+			}
+			server.Variables[name] = &ServerVariableObject{
+				Enum:        v.Enum,
+				Default:     v.Default,
+				Description: v.Description,
+			}
+		}
+		spec.Servers = append(spec.Servers, server)
 	}
 
 	// Add security schemes based on config.
@@ -112,6 +141,16 @@ func (gd *GinDocs) assembleSpec() *OpenAPISpec {
 		}
 	}
 
+	// Apply the overrides file on top of the code overrides.
+	fileWarnings := gd.applyFileOverrides(spec, tagSet)
+
+	// Document 405 responses with the methods each path allows.
+	if gd.config.MethodNotAllowed {
+		for _, pathItem := range spec.Paths {
+			addMethodNotAllowed(pathItem)
+		}
+	}
+
 	// Build sorted tag list.
 	var tagNames []string
 	for tag := range tagSet {
@@ -121,6 +160,8 @@ func (gd *GinDocs) assembleSpec() *OpenAPISpec {
 	for _, name := range tagNames {
 		spec.Tags = append(spec.Tags, TagObject{Name: name})
 	}
+	gd.describeTags(spec)
+	sectionWarnings := gd.describeTagSections(spec)
 
 	// Copy registered schemas to components.
 	if gd.registry != nil {
@@ -129,6 +170,39 @@ func (gd *GinDocs) assembleSpec() *OpenAPISpec {
 		}
 	}
 
+	// Secrets accepted in request bodies are never returned.
+	markSensitiveRequestFields(spec, gd.config.SensitiveFieldNames)
+
+	// Point request bodies and responses at direction-specific schemas.
+	if gd.config.SchemaViews {
+		splitSchemaViews(spec)
+	}
+
+	// Add the paths and components of hand-written specs.
+	mergeWarnings := gd.mergeSpecs(spec)
+
+	// Drop schemas that no operation references.
+	if !gd.config.KeepUnusedSchemas {
+		pruneSchemas(spec)
+	}
+
+	// Estimate response sizes once all schemas are known.
+	if gd.config.PayloadEstimates {
+		addPayloadEstimates(spec, gd.config.PayloadWarnBytes)
+	}
+
+	if gd.config.CodeSamples {
+		addCodeSamples(spec, gd.config.CodeSampleHook)
+	}
+
+	gd.warnings = append(gd.collectWarnings(routes, spec), fileWarnings...)
+	gd.warnings = append(gd.warnings, mergeWarnings...)
+	gd.warnings = append(gd.warnings, sectionWarnings...)
+
+	if gd.config.SpecHook != nil {
+		gd.config.SpecHook(spec)
+	}
+
 	return spec
 }
 
@@ -141,35 +215,145 @@ func (gd *GinDocs) buildOperation(route RouteMetadata) *OperationObject {
 		Responses:   make(map[string]*Response),
 	}
 
+	// Record the middleware that runs before the handler.
+	if gd.config.ExposeMiddlewares && len(route.Handlers) > 1 {
+		op.Middlewares = route.Handlers[:len(route.Handlers)-1]
+	}
+
 	// Add path parameters.
 	for _, param := range route.PathParams {
-		op.Parameters = append(op.Parameters, ParameterObject{
+		p := ParameterObject{
 			Name:        param,
 			In:          "path",
 			Required:    true,
 			Description: inferParamDescription(param),
 			Schema:      inferParamSchema(param),
-		})
+		}
+		// Gin's *name parameters match the rest of the path, slashes included.
+		if strings.HasSuffix(route.Path, "/*"+param) {
+			p.Extensions = map[string]interface{}{"x-catch-all": true}
+		}
+		op.Parameters = append(op.Parameters, p)
 	}
 
 	// Add inferred query parameters.
 	queryParams := inferQueryParams(route.Method, route.Path)
 	op.Parameters = append(op.Parameters, queryParams...)
 
+	// Document the pagination convention on list endpoints.
+	if gd.config.Pagination != nil && isListEndpoint(route.Method, route.Path) {
+		addPaginationParams(op, gd.config.Pagination)
+	}
+
 	// Infer response status codes.
 	statusCodes := inferStatusCodes(route.Method, route.PathParams)
 	for code, desc := range statusCodes {
 		op.Responses[code] = &Response{
 			Description: desc,
 		}
+		if gd.config.ErrorModel != nil && code >= "400" {
+			op.Responses[code].Content = map[string]MediaType{
+				"application/json": {Schema: typeToSchema(reflect.TypeOf(gd.config.ErrorModel), gd.registry)},
+			}
+		}
+	}
+
+	// Take the summary and description from the handler's doc comment.
+	if gd.config.HandlerComments && route.SourceFile != "" {
+		if summary, description := handlerComment(gd.config.SourceRoot, route.SourceFile, route.HandlerName, route.SourceLine); summary != "" {
+			op.Summary = summary
+			op.Description = description
+		}
 	}
 
 	// Apply route and group overrides.
-	gd.applyRouteOverrides(route.Method, route.Path, op)
+	gd.applyRouteOverrides(route, op)
+
+	// Infer security from auth middleware when no override set it.
+	gd.inferSecurity(route, op)
+
+	// Document filtering and sorting once the response schema is known.
+	if gd.config.ListConventions != nil && isListEndpoint(route.Method, route.Path) {
+		gd.addListParams(op, route.Path, gd.config.ListConventions)
+	}
+
+	// Link to the handler source in DevMode.
+	if gd.config.SourceLinks && gd.config.DevMode && route.SourceFile != "" {
+		op.Source = gd.sourceLink(route.SourceFile, route.SourceLine)
+		op.Description = appendSourceLink(op.Description, op.Source)
+	}
+
+	if gd.config.OperationHook != nil {
+		gd.config.OperationHook(route, op)
+	}
 
 	return op
 }
 
+// sourceLink builds a SourceLink for a handler location using the configured
+// source root and URL template.
+func (gd *GinDocs) sourceLink(file string, line int) *SourceLink {
+	root := gd.config.SourceRoot
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+	if root != "" {
+		if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = filepath.ToSlash(rel)
+		}
+	}
+
+	link := &SourceLink{File: file, Line: line}
+	if gd.config.SourceURLTemplate != "" {
+		link.URL = strings.NewReplacer(
+			"{file}", file,
+			"{line}", strconv.Itoa(line),
+		).Replace(gd.config.SourceURLTemplate)
+	}
+	return link
+}
+
+// appendSourceLink adds a markdown "View source" line to a description.
+func appendSourceLink(description string, link *SourceLink) string {
+	location := fmt.Sprintf("%s:%d", link.File, link.Line)
+	line := "Source: `" + location + "`"
+	if link.URL != "" {
+		line = "[View source](" + link.URL + ") — `" + location + "`"
+	}
+	if description == "" {
+		return line
+	}
+	return description + "\n\n" + line
+}
+
+// addMethodNotAllowed adds a 405 response listing the allowed methods to every
+// operation on the path item, unless the operation already documents one.
+func addMethodNotAllowed(pathItem *PathItem) {
+	var allowed []string
+	for _, method := range httpMethods {
+		if pathItem.GetOperation(method) != nil {
+			allowed = append(allowed, method)
+		}
+	}
+	allow := strings.Join(allowed, ", ")
+
+	for _, method := range allowed {
+		op := pathItem.GetOperation(method)
+		if _, ok := op.Responses["405"]; ok {
+			continue
+		}
+		op.Responses["405"] = &Response{
+			Description: "Method not allowed. Supported methods: " + allow,
+			Headers: map[string]*Header{
+				"Allow": {
+					Description: "Methods supported by this path",
+					Schema:      &SchemaObject{Type: "string", Example: allow},
+				},
+			},
+		}
+	}
+}
+
 // inferParamDescription generates a description for a path parameter.
 func inferParamDescription(param string) string {
 	lower := strings.ToLower(param)
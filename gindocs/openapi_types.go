@@ -1,5 +1,12 @@
 package gindocs
 
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
 // OpenAPISpec represents a complete OpenAPI 3.1 specification.
 type OpenAPISpec struct {
 	OpenAPI      string                `json:"openapi"`
@@ -10,6 +17,10 @@ type OpenAPISpec struct {
 	Security     []SecurityRequirement `json:"security,omitempty"`
 	Tags         []TagObject           `json:"tags,omitempty"`
 	ExternalDocs *ExternalDocsObject   `json:"externalDocs,omitempty"`
+	Network      *NetworkRequirements  `json:"x-network,omitempty"`
+
+	// Extensions holds vendor extension (x-*) fields.
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // InfoObject provides metadata about the API.
@@ -20,6 +31,9 @@ type InfoObject struct {
 	Contact        *ContactObject `json:"contact,omitempty"`
 	License        *LicenseObject `json:"license,omitempty"`
 	Version        string         `json:"version"`
+
+	// Extensions holds vendor extension (x-*) fields.
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // ContactObject holds contact information.
@@ -37,8 +51,26 @@ type LicenseObject struct {
 
 // ServerObject describes a server.
 type ServerObject struct {
-	URL         string `json:"url"`
-	Description string `json:"description,omitempty"`
+	URL         string                           `json:"url"`
+	Description string                           `json:"description,omitempty"`
+	Variables   map[string]*ServerVariableObject `json:"variables,omitempty"`
+}
+
+// ServerVariableObject describes a variable substituted in a server URL.
+type ServerVariableObject struct {
+	Enum        []string `json:"enum,omitempty"`
+	Default     string   `json:"default"`
+	Description string   `json:"description,omitempty"`
+}
+
+// ResolvedURL returns the server URL with every variable replaced by its
+// default value.
+func (s ServerObject) ResolvedURL() string {
+	url := s.URL
+	for name, v := range s.Variables {
+		url = strings.ReplaceAll(url, "{"+name+"}", v.Default)
+	}
+	return url
 }
 
 // PathItem describes operations available on a single path.
@@ -52,6 +84,19 @@ type PathItem struct {
 	Options *OperationObject `json:"options,omitempty"`
 }
 
+// httpMethods lists the HTTP methods a PathItem can hold, in display order.
+var httpMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// sortedKeys returns the keys of a map in sorted order, for stable output.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // SetOperation sets the operation for the given HTTP method on the path item.
 func (p *PathItem) SetOperation(method string, op *OperationObject) {
 	switch method {
@@ -72,6 +117,27 @@ func (p *PathItem) SetOperation(method string, op *OperationObject) {
 	}
 }
 
+// GetOperation returns the operation for the given HTTP method, or nil.
+func (p *PathItem) GetOperation(method string) *OperationObject {
+	switch method {
+	case "GET":
+		return p.Get
+	case "POST":
+		return p.Post
+	case "PUT":
+		return p.Put
+	case "PATCH":
+		return p.Patch
+	case "DELETE":
+		return p.Delete
+	case "HEAD":
+		return p.Head
+	case "OPTIONS":
+		return p.Options
+	}
+	return nil
+}
+
 // OperationObject describes a single API operation on a path.
 type OperationObject struct {
 	Tags         []string              `json:"tags,omitempty"`
@@ -84,6 +150,29 @@ type OperationObject struct {
 	Security     []SecurityRequirement `json:"security,omitempty"`
 	Deprecated   bool                  `json:"deprecated,omitempty"`
 	ExternalDocs *ExternalDocsObject   `json:"externalDocs,omitempty"`
+	Sunset       string                `json:"x-sunset,omitempty"`
+	ReplacedBy   string                `json:"x-replaced-by,omitempty"`
+	Source       *SourceLink           `json:"x-source,omitempty"`
+	FeatureFlag  string                `json:"x-feature-flag,omitempty"`
+	Audiences    []string              `json:"x-audience,omitempty"`
+	Middlewares  []string              `json:"x-middlewares,omitempty"`
+
+	PayloadEstimate *PayloadEstimate `json:"x-payload-estimate,omitempty"`
+
+	WebSocket *StreamObject `json:"x-websocket,omitempty"`
+	SSE       *StreamObject `json:"x-sse,omitempty"`
+
+	CodeSamples []CodeSample `json:"x-codeSamples,omitempty"`
+
+	// Extensions holds vendor extension (x-*) fields.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// SourceLink points to the handler source code of an operation.
+type SourceLink struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	URL  string `json:"url,omitempty"`
 }
 
 // ParameterObject describes a single operation parameter.
@@ -93,8 +182,13 @@ type ParameterObject struct {
 	Description string        `json:"description,omitempty"`
 	Required    bool          `json:"required,omitempty"`
 	Deprecated  bool          `json:"deprecated,omitempty"`
+	Style       string        `json:"style,omitempty"`
+	Explode     *bool         `json:"explode,omitempty"`
 	Schema      *SchemaObject `json:"schema,omitempty"`
 	Example     interface{}   `json:"example,omitempty"`
+
+	// Extensions holds vendor extension (x-*) fields.
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // RequestBodyObject describes a request body.
@@ -112,9 +206,16 @@ type MediaType struct {
 
 // Response describes a single response from an API operation.
 type Response struct {
+	// Ref points at a reusable response in components.responses; the
+	// other fields are ignored when it is set.
+	Ref string `json:"$ref,omitempty"`
+
 	Description string               `json:"description"`
 	Content     map[string]MediaType `json:"content,omitempty"`
 	Headers     map[string]*Header   `json:"headers,omitempty"`
+
+	// Extensions holds vendor extension (x-*) fields.
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // Header describes a response header.
@@ -171,6 +272,71 @@ type SchemaObject struct {
 	AllOf []*SchemaObject `json:"allOf,omitempty"`
 	OneOf []*SchemaObject `json:"oneOf,omitempty"`
 	AnyOf []*SchemaObject `json:"anyOf,omitempty"`
+
+	// Discriminator names the property that tells oneOf variants apart.
+	Discriminator *DiscriminatorObject `json:"discriminator,omitempty"`
+
+	// Extensions holds vendor extension (x-*) fields.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON encodes the schema, expressing Nullable as an OpenAPI 3.1 type array.
+func (s SchemaObject) MarshalJSON() ([]byte, error) {
+	type plain SchemaObject
+	aux := struct {
+		plain
+		Type     interface{} `json:"type,omitempty"`
+		Nullable *bool       `json:"nullable,omitempty"`
+	}{plain: plain(s)}
+
+	if s.Type != "" {
+		aux.Type = s.Type
+		if s.Nullable {
+			aux.Type = []string{s.Type, "null"}
+		}
+	}
+
+	data, err := json.Marshal(aux)
+	if err != nil {
+		return nil, err
+	}
+	return marshalExtensions(data, s.Extensions)
+}
+
+// UnmarshalJSON decodes a schema, accepting both string and array "type" values.
+func (s *SchemaObject) UnmarshalJSON(data []byte) error {
+	type plain SchemaObject
+	aux := struct {
+		*plain
+		Type interface{} `json:"type,omitempty"`
+	}{plain: (*plain)(s)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	switch v := aux.Type.(type) {
+	case string:
+		s.Type = v
+	case []interface{}:
+		for _, item := range v {
+			name, _ := item.(string)
+			if name == "null" {
+				s.Nullable = true
+			} else if name != "" {
+				s.Type = name
+			}
+		}
+	}
+
+	s.Extensions = unmarshalExtensions(data, reflect.TypeOf(plain{}))
+	return nil
+}
+
+// DiscriminatorObject identifies the oneOf variant of a payload by a property value.
+type DiscriminatorObject struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
 }
 
 // ComponentsObject holds reusable components.
@@ -190,6 +356,9 @@ type SecuritySchemeObject struct {
 	In           string `json:"in,omitempty"`     // for apiKey: "header", "query", "cookie"
 	Scheme       string `json:"scheme,omitempty"` // for http: "bearer", "basic"
 	BearerFormat string `json:"bearerFormat,omitempty"`
+
+	// Extensions holds vendor extension (x-*) fields.
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // SecurityRequirement maps security scheme names to required scopes.
@@ -200,6 +369,9 @@ type TagObject struct {
 	Name         string              `json:"name"`
 	Description  string              `json:"description,omitempty"`
 	ExternalDocs *ExternalDocsObject `json:"externalDocs,omitempty"`
+
+	// Extensions holds vendor extension (x-*) fields.
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // ExternalDocsObject describes external documentation.
@@ -1,9 +1,13 @@
 package gindocs
 
 import (
+	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,32 +15,93 @@ import (
 // RouteOverride holds documentation overrides for a specific route.
 type RouteOverride struct {
 	gd     *GinDocs
+	sub    *SubDocs
 	method string
 	path   string
 
+	// handler is the function name for overrides created with RouteFor.
+	handler string
+
+	hidden      bool
 	summary     *string
 	description *string
+	operationID string
 	tags        []string
 	deprecated  *bool
+	sunset      string
+	replacedBy  string
+	featureFlag string
+	audiences   []string
 	security    []string
+	extensions  map[string]interface{}
+
+	externalDocs *ExternalDocsObject
+
+	stream      streamKind
+	streamTypes []reflect.Type
 
 	requestBodyType reflect.Type
+	requestBodies   []requestBodyOverride
 	responses       []responseOverride
+
+	sparseFields bool
+	fieldNames   []string
+
+	discriminator string
+
+	// problems are builder misuses reported by Warnings.
+	problems []string
 }
 
 type responseOverride struct {
 	statusCode  int
+	mediaType   string
 	bodyType    reflect.Type
 	description string
+
+	// schema and example are set instead of bodyType for raw JSON samples.
+	schema  *SchemaObject
+	example interface{}
+
+	// variants are set instead of bodyType for oneOf responses.
+	variants []reflect.Type
+	array    bool
+}
+
+type requestBodyOverride struct {
+	mediaType string
+	bodyType  reflect.Type
 }
 
 // GroupOverride holds documentation overrides for a route group.
 type GroupOverride struct {
 	gd      *GinDocs
+	sub     *SubDocs
 	pattern string
 
-	tags     []string
-	security []string
+	// pathPattern is pattern without its method prefix; regexp is set
+	// when it is a regular expression.
+	pathPattern string
+	regexp      *regexp.Regexp
+
+	tags          []string
+	security      []string
+	methods       []string
+	responses     []responseOverride
+	parameters    []parameterOverride
+	summaryPrefix string
+	deprecated    *bool
+	audiences     []string
+
+	// problems are builder misuses reported by Warnings.
+	problems []string
+}
+
+type parameterOverride struct {
+	name        string
+	in          string
+	paramType   reflect.Type
+	description string
 }
 
 // Route returns a RouteOverride builder for the specified "METHOD /path" key.
@@ -65,13 +130,73 @@ func (gd *GinDocs) Route(key string) *RouteOverride {
 	return override
 }
 
+// Hide excludes routes, given as "METHOD /path" keys, from the docs.
+func (gd *GinDocs) Hide(keys ...string) {
+	if gd.hidden == nil {
+		gd.hidden = make(map[string]bool)
+	}
+	for _, key := range keys {
+		method, path, ok := strings.Cut(key, " ")
+		if !ok {
+			method, path = "GET", key
+		}
+		gd.hidden[strings.ToUpper(method)+" "+path] = true
+	}
+}
+
+// AddRoute documents an endpoint the Gin router doesn't serve, such as a
+// WebSocket upgrade handled by another mux or a planned endpoint, and returns
+// its RouteOverride builder. path uses Gin syntax ("/ws/:room").
+func (gd *GinDocs) AddRoute(method, path string) *RouteOverride {
+	key := strings.ToUpper(method) + " " + path
+	if gd.manualRoutes == nil {
+		gd.manualRoutes = make(map[string]bool)
+	}
+	gd.manualRoutes[key] = true
+	gd.InvalidateCache()
+	return gd.Route(key)
+}
+
+// RouteFor returns a RouteOverride builder for every route served by handler.
+// The route is resolved through the handler's runtime function name, so the
+// override follows the handler when its path changes. Overrides registered
+// with Route take precedence.
+func (gd *GinDocs) RouteFor(handler gin.HandlerFunc) *RouteOverride {
+	name := getFuncName(handler)
+	override := &RouteOverride{
+		gd:      gd,
+		handler: name,
+	}
+
+	if gd.handlerOverrides == nil {
+		gd.handlerOverrides = make(map[string]*RouteOverride)
+	}
+	gd.handlerOverrides[name] = override
+
+	return override
+}
+
+// Hidden excludes the route from the docs.
+func (r *RouteOverride) Hidden() *RouteOverride {
+	r.hidden = true
+	return r
+}
+
 // Summary sets the operation summary.
 func (r *RouteOverride) Summary(s string) *RouteOverride {
 	r.summary = &s
 	return r
 }
 
-// Description sets the operation description.
+// OperationID sets an explicit operation ID, keeping deep links stable
+// even if the route path changes.
+func (r *RouteOverride) OperationID(id string) *RouteOverride {
+	r.operationID = id
+	return r
+}
+
+// Description sets the operation description, in markdown (CommonMark) as
+// rendered by Scalar and Swagger UI.
 func (r *RouteOverride) Description(d string) *RouteOverride {
 	r.description = &d
 	return r
@@ -89,6 +214,52 @@ func (r *RouteOverride) Deprecated(d bool) *RouteOverride {
 	return r
 }
 
+// Sunset sets the date the operation will be removed and marks it deprecated.
+func (r *RouteOverride) Sunset(date time.Time) *RouteOverride {
+	r.sunset = date.Format("2006-01-02")
+	return r
+}
+
+// ReplacedBy names the operation (e.g., "GET /api/v2/users") that supersedes
+// this one and marks it deprecated.
+func (r *RouteOverride) ReplacedBy(key string) *RouteOverride {
+	r.replacedBy = key
+	return r
+}
+
+// FeatureFlag marks the route as gated by a feature flag. It is only included
+// in the served spec when Config.FeatureFlags reports the flag as enabled.
+func (r *RouteOverride) FeatureFlag(name string) *RouteOverride {
+	r.featureFlag = name
+	return r
+}
+
+// Audience limits the operation to the named audiences (e.g. "internal").
+// Filtered specs such as /docs/openapi.json?audience=public omit it.
+func (r *RouteOverride) Audience(names ...string) *RouteOverride {
+	r.audiences = append(r.audiences, names...)
+	return r
+}
+
+// ExternalDocs links the operation to external documentation.
+func (r *RouteOverride) ExternalDocs(url, description string) *RouteOverride {
+	r.externalDocs = &ExternalDocsObject{URL: url, Description: description}
+	return r
+}
+
+// Extension sets a vendor extension on the operation, e.g. Extension("x-internal", true).
+// The "x-" prefix is added if key lacks it.
+func (r *RouteOverride) Extension(key string, value interface{}) *RouteOverride {
+	if !strings.HasPrefix(key, "x-") {
+		key = "x-" + key
+	}
+	if r.extensions == nil {
+		r.extensions = make(map[string]interface{})
+	}
+	r.extensions[key] = value
+	return r
+}
+
 // Security sets security scheme names for this route.
 func (r *RouteOverride) Security(schemes ...string) *RouteOverride {
 	r.security = append(r.security, schemes...)
@@ -101,6 +272,33 @@ func (r *RouteOverride) RequestBody(v interface{}) *RouteOverride {
 	return r
 }
 
+// RequestBodyContent registers a request body type for a specific media type,
+// e.g. "application/vnd.example.v2+json". Call it once per version.
+func (r *RouteOverride) RequestBodyContent(mediaType string, v interface{}) *RouteOverride {
+	r.requestBodies = append(r.requestBodies, requestBodyOverride{
+		mediaType: mediaType,
+		bodyType:  reflect.TypeOf(v),
+	})
+	return r
+}
+
+// ResponseContent registers a response body for a specific media type. Calls
+// with the same status code add content types to one response, which documents
+// Accept-header versioning (application/vnd.example.v1+json, ...v2+json).
+func (r *RouteOverride) ResponseContent(statusCode int, mediaType string, body interface{}, description string) *RouteOverride {
+	r.Response(statusCode, body, description)
+	r.responses[len(r.responses)-1].mediaType = mediaType
+	return r
+}
+
+// Fields documents a ?fields=a,b query parameter for partial responses.
+// Allowed names default to the properties of the success response schema.
+func (r *RouteOverride) Fields(allowed ...string) *RouteOverride {
+	r.sparseFields = true
+	r.fieldNames = allowed
+	return r
+}
+
 // Response registers a response for this route.
 func (r *RouteOverride) Response(statusCode int, body interface{}, description string) *RouteOverride {
 	var bodyType reflect.Type
@@ -115,17 +313,108 @@ func (r *RouteOverride) Response(statusCode int, body interface{}, description s
 	return r
 }
 
+// ResponseJSON adds a response documented from a sample JSON payload, for
+// handlers that return gin.H or other untyped values. The schema is inferred
+// from the sample, which also becomes the example. If rawJSON isn't valid JSON
+// the response is documented without a body.
+func (r *RouteOverride) ResponseJSON(statusCode int, rawJSON string, description string) *RouteOverride {
+	resp := responseOverride{statusCode: statusCode, description: description}
+	if schema, example, ok := parseJSONSample(rawJSON); ok {
+		resp.schema = schema
+		resp.example = example
+	} else {
+		r.problems = append(r.problems, fmt.Sprintf("ResponseJSON(%d): invalid JSON sample", statusCode))
+	}
+	r.responses = append(r.responses, resp)
+	return r
+}
+
+// ResponseOneOf adds a response whose body is exactly one of the variant types.
+func (r *RouteOverride) ResponseOneOf(statusCode int, description string, variants ...interface{}) *RouteOverride {
+	r.responses = append(r.responses, responseOverride{
+		statusCode:  statusCode,
+		description: description,
+		variants:    variantTypes(variants),
+	})
+	return r
+}
+
+// ResponseOneOfArray adds a response whose body is an array of items that are
+// each one of the variant types, e.g. mixed search results.
+func (r *RouteOverride) ResponseOneOfArray(statusCode int, description string, variants ...interface{}) *RouteOverride {
+	r.responses = append(r.responses, responseOverride{
+		statusCode:  statusCode,
+		description: description,
+		variants:    variantTypes(variants),
+		array:       true,
+	})
+	return r
+}
+
+// Discriminator names the property that identifies the variant of the route's
+// oneOf responses. Variants whose property has a single allowed value (e.g.
+// binding:"oneof=user") are mapped to that value.
+func (r *RouteOverride) Discriminator(property string) *RouteOverride {
+	r.discriminator = property
+	return r
+}
+
+// variantTypes returns the types of the given instances, skipping nils.
+func variantTypes(variants []interface{}) []reflect.Type {
+	types := make([]reflect.Type, 0, len(variants))
+	for _, v := range variants {
+		if v != nil {
+			types = append(types, reflect.TypeOf(v))
+		}
+	}
+	return types
+}
+
+// oneOfSchema builds a oneOf schema over the variant types.
+func (gd *GinDocs) oneOfSchema(variants []reflect.Type, discriminator string) *SchemaObject {
+	schema := &SchemaObject{}
+	for _, t := range variants {
+		schema.OneOf = append(schema.OneOf, typeToSchema(t, gd.registry))
+	}
+	if discriminator == "" {
+		return schema
+	}
+
+	schema.Discriminator = &DiscriminatorObject{PropertyName: discriminator}
+	for _, variant := range schema.OneOf {
+		resolved, ok := gd.registry.Get(strings.TrimPrefix(variant.Ref, RefPath("")))
+		if variant.Ref == "" || !ok {
+			continue
+		}
+		if prop := resolved.Properties[discriminator]; prop != nil && len(prop.Enum) == 1 {
+			if schema.Discriminator.Mapping == nil {
+				schema.Discriminator.Mapping = make(map[string]string)
+			}
+			schema.Discriminator.Mapping[fmt.Sprint(prop.Enum[0])] = variant.Ref
+		}
+	}
+	return schema
+}
+
 // Group returns a GroupOverride builder for routes matching the given pattern.
+// Patterns are globs ("/api/*/admin/**"), regular expressions starting with
+// "^", and may be prefixed with methods ("POST,PUT /api/posts/**").
+// Like Route, calling Group again with the same pattern replaces the earlier
+// override; groups with different patterns all apply, in registration order.
 func (gd *GinDocs) Group(pattern string) *GroupOverride {
 	override := &GroupOverride{
 		gd:      gd,
 		pattern: pattern,
 	}
+	override.parsePattern()
 
-	if gd.groupOverrides == nil {
-		gd.groupOverrides = make(map[string]*GroupOverride)
+	for i, existing := range gd.groupOverrides {
+		if existing.pattern == pattern {
+			gd.groupOverrides[i] = override
+			return override
+		}
 	}
-	gd.groupOverrides[pattern] = override
+	gd.groupOverrides = append(gd.groupOverrides, override)
 
 	return override
 }
@@ -142,6 +431,119 @@ func (g *GroupOverride) Security(schemes ...string) *GroupOverride {
 	return g
 }
 
+// Methods limits the group to routes with the given HTTP methods.
+func (g *GroupOverride) Methods(methods ...string) *GroupOverride {
+	for _, m := range methods {
+		g.methods = append(g.methods, strings.ToUpper(m))
+	}
+	return g
+}
+
+// Response documents a response shared by all routes in the group, such as
+// a 403 on admin routes. Routes that already document the status keep theirs.
+func (g *GroupOverride) Response(statusCode int, body interface{}, description string) *GroupOverride {
+	var bodyType reflect.Type
+	if body != nil {
+		bodyType = reflect.TypeOf(body)
+	}
+	g.responses = append(g.responses, responseOverride{
+		statusCode:  statusCode,
+		bodyType:    bodyType,
+		description: description,
+	})
+	return g
+}
+
+// Parameter documents a parameter shared by all routes in the group.
+// in is "query", "header", "path" or "cookie"; v is an example value whose
+// type determines the schema.
+func (g *GroupOverride) Parameter(name, in string, v interface{}, description string) *GroupOverride {
+	g.parameters = append(g.parameters, parameterOverride{
+		name:        name,
+		in:          in,
+		paramType:   reflect.TypeOf(v),
+		description: description,
+	})
+	return g
+}
+
+// SummaryPrefix prepends prefix to the summary of every route in the group.
+func (g *GroupOverride) SummaryPrefix(prefix string) *GroupOverride {
+	g.summaryPrefix = prefix
+	return g
+}
+
+// Deprecated marks all routes in the group as deprecated. Route overrides
+// can still set Deprecated(false).
+func (g *GroupOverride) Deprecated(d bool) *GroupOverride {
+	g.deprecated = &d
+	return g
+}
+
+// Audience limits all routes in the group to the named audiences.
+func (g *GroupOverride) Audience(names ...string) *GroupOverride {
+	g.audiences = append(g.audiences, names...)
+	return g
+}
+
+// matches reports whether the group applies to a route.
+func (g *GroupOverride) matches(method, path string) bool {
+	if g.regexp != nil {
+		if !g.regexp.MatchString(path) {
+			return false
+		}
+	} else if !matchGroupPattern(path, g.pathPattern) {
+		return false
+	}
+	if len(g.methods) == 0 {
+		return true
+	}
+	for _, m := range g.methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// applyGroupAdditions adds a group's shared responses, parameters and
+// summary prefix to an operation.
+func (gd *GinDocs) applyGroupAdditions(group *GroupOverride, op *OperationObject) {
+	for _, resp := range group.responses {
+		code := strconv.Itoa(resp.statusCode)
+		if _, ok := op.Responses[code]; ok {
+			continue
+		}
+		response := &Response{Description: resp.description}
+		if resp.bodyType != nil {
+			response.Content = map[string]MediaType{
+				"application/json": {Schema: typeToSchema(resp.bodyType, gd.registry)},
+			}
+		}
+		op.Responses[code] = response
+	}
+
+	for _, param := range group.parameters {
+		var schema *SchemaObject
+		if param.paramType != nil {
+			schema = typeToSchema(param.paramType, gd.registry)
+		} else {
+			schema = &SchemaObject{Type: "string"}
+		}
+		setParameter(op, ParameterObject{
+			Name:        param.name,
+			In:          param.in,
+			Required:    param.in == "path",
+			Description: param.description,
+			Schema:      schema,
+		})
+	}
+
+	if group.summaryPrefix != "" && !strings.HasPrefix(op.Summary, group.summaryPrefix) {
+		op.Summary = group.summaryPrefix + op.Summary
+	}
+}
+
 // DocConfig holds inline documentation configuration for the Doc() middleware.
 type DocConfig struct {
 	// Summary is the operation summary.
@@ -169,42 +571,110 @@ func Doc(cfg DocConfig) gin.HandlerFunc {
 }
 
 // applyRouteOverrides applies route and group overrides to an operation.
-func (gd *GinDocs) applyRouteOverrides(method, path string, op *OperationObject) {
-	// Apply group overrides first.
-	for pattern, override := range gd.groupOverrides {
-		if matchGroupPattern(path, pattern) {
-			if len(override.tags) > 0 {
-				op.Tags = override.tags
-			}
-			if len(override.security) > 0 {
-				for _, scheme := range override.security {
-					op.Security = append(op.Security, SecurityRequirement{
-						scheme: []string{},
-					})
-				}
+func (gd *GinDocs) applyRouteOverrides(route RouteMetadata, op *OperationObject) {
+	var groups []*GroupOverride
+	for _, group := range gd.groupOverrides {
+		if group.matches(route.Method, route.Path) {
+			groups = append(groups, group)
+		}
+	}
+
+	// Sub-documenter defaults apply to every route under their prefix.
+	for _, sub := range gd.subs {
+		if sub.covers(route.Path) {
+			gd.applySubDefaults(op, sub)
+		}
+	}
+
+	// Apply group defaults first.
+	for _, group := range groups {
+		if group.sub != nil {
+			gd.applySubDefaults(op, group.sub)
+		}
+		if len(group.tags) > 0 {
+			op.Tags = group.tags
+		}
+		if len(group.security) > 0 {
+			for _, scheme := range group.security {
+				op.Security = append(op.Security, SecurityRequirement{
+					scheme: []string{},
+				})
 			}
 		}
+		if group.deprecated != nil {
+			op.Deprecated = *group.deprecated
+		}
+		if len(group.audiences) > 0 {
+			op.Audiences = group.audiences
+		}
 	}
 
 	// Apply route-level overrides (higher priority).
-	key := method + " " + path
-	override, ok := gd.routeOverrides[key]
+	override, ok := gd.routeOverrides[route.Method+" "+route.Path]
 	if !ok {
-		return
+		override, ok = gd.handlerOverrides[route.HandlerName]
+	}
+	if ok {
+		gd.applyRouteOverride(route, override, op)
+	}
+
+	// Group additions go last so route responses don't replace them.
+	for _, group := range groups {
+		gd.applyGroupAdditions(group, op)
+	}
+}
+
+// applyRouteOverride applies a single route-level override to an operation.
+func (gd *GinDocs) applyRouteOverride(route RouteMetadata, override *RouteOverride, op *OperationObject) {
+	// Anonymous request and response structs are named after the route.
+	typeName := capitalize(generateOperationID(route.Method, route.Path))
+	bodySchema := func(t reflect.Type, suffix string) *SchemaObject {
+		return gd.registry.withHint(typeName+suffix, func() *SchemaObject {
+			return typeToSchema(t, gd.registry)
+		})
 	}
 
+	if override.sub != nil {
+		gd.applySubDefaults(op, override.sub)
+	}
 	if override.summary != nil {
 		op.Summary = *override.summary
 	}
 	if override.description != nil {
 		op.Description = *override.description
 	}
+	if override.operationID != "" {
+		op.OperationID = override.operationID
+	}
 	if len(override.tags) > 0 {
 		op.Tags = override.tags
 	}
 	if override.deprecated != nil {
 		op.Deprecated = *override.deprecated
 	}
+	if override.sunset != "" {
+		op.Sunset = override.sunset
+		op.Deprecated = true
+	}
+	if override.replacedBy != "" {
+		op.ReplacedBy = override.replacedBy
+		op.Deprecated = true
+	}
+	if override.featureFlag != "" {
+		op.FeatureFlag = override.featureFlag
+	}
+	if len(override.audiences) > 0 {
+		op.Audiences = override.audiences
+	}
+	if override.externalDocs != nil {
+		op.ExternalDocs = override.externalDocs
+	}
+	for key, value := range override.extensions {
+		if op.Extensions == nil {
+			op.Extensions = make(map[string]interface{})
+		}
+		op.Extensions[key] = value
+	}
 	if len(override.security) > 0 {
 		op.Security = nil
 		for _, scheme := range override.security {
@@ -216,7 +686,7 @@ func (gd *GinDocs) applyRouteOverrides(method, path string, op *OperationObject)
 
 	// Apply request body override.
 	if override.requestBodyType != nil {
-		schema := typeToSchema(override.requestBodyType, gd.registry)
+		schema := bodySchema(override.requestBodyType, "RequestBody")
 		op.RequestBody = &RequestBodyObject{
 			Required: true,
 			Content: map[string]MediaType{
@@ -224,35 +694,133 @@ func (gd *GinDocs) applyRouteOverrides(method, path string, op *OperationObject)
 			},
 		}
 	}
+	for _, body := range override.requestBodies {
+		if op.RequestBody == nil {
+			op.RequestBody = &RequestBodyObject{Required: true, Content: map[string]MediaType{}}
+		}
+		op.RequestBody.Content[body.mediaType] = MediaType{Schema: bodySchema(body.bodyType, "RequestBody")}
+	}
 
-	// Apply response overrides.
+	// Apply response overrides. Overrides sharing a status code are merged
+	// into one response with a content entry per media type.
 	if len(override.responses) > 0 {
 		op.Responses = make(map[string]*Response)
 		for _, resp := range override.responses {
 			code := strconv.Itoa(resp.statusCode)
-			response := &Response{
-				Description: resp.description,
+			response, ok := op.Responses[code]
+			if !ok {
+				response = &Response{Description: resp.description}
+				op.Responses[code] = response
 			}
-			if resp.bodyType != nil {
-				schema := typeToSchema(resp.bodyType, gd.registry)
-				response.Content = map[string]MediaType{
-					"application/json": {Schema: schema},
+			var media MediaType
+			switch {
+			case resp.bodyType != nil && resp.statusCode < 300:
+				media.Schema = bodySchema(resp.bodyType, "ResponseBody")
+			case resp.bodyType != nil:
+				media.Schema = bodySchema(resp.bodyType, code+"ResponseBody")
+			case resp.schema != nil:
+				media = MediaType{Schema: resp.schema, Example: resp.example}
+			case len(resp.variants) > 0:
+				media.Schema = gd.oneOfSchema(resp.variants, override.discriminator)
+				if resp.array {
+					media.Schema = &SchemaObject{Type: "array", Items: media.Schema}
 				}
+			default:
+				continue
+			}
+			mediaType := resp.mediaType
+			if mediaType == "" {
+				mediaType = "application/json"
 			}
-			op.Responses[code] = response
+			if response.Content == nil {
+				response.Content = make(map[string]MediaType)
+			}
+			response.Content[mediaType] = media
+		}
+	}
+
+	if override.stream != streamNone {
+		explicit := make(map[string]bool, len(override.responses))
+		for _, resp := range override.responses {
+			explicit[strconv.Itoa(resp.statusCode)] = true
+		}
+		applyStream(op, override.stream, override.streamTypes, explicit, func(t reflect.Type) *SchemaObject {
+			return bodySchema(t, "Message")
+		})
+	}
+
+	if override.sparseFields {
+		addFieldsParam(op, override.fieldNames, gd.registry)
+	}
+
+	// Document validation failures for constrained request bodies.
+	bodyTypes := []reflect.Type{override.requestBodyType}
+	for _, body := range override.requestBodies {
+		bodyTypes = append(bodyTypes, body.bodyType)
+	}
+	for _, t := range bodyTypes {
+		if t != nil && hasBindingConstraints(t, map[reflect.Type]bool{}) {
+			gd.addValidationResponse(op)
+			break
 		}
 	}
 }
 
-// matchGroupPattern checks if a path matches a group pattern.
-func matchGroupPattern(path, pattern string) bool {
-	if strings.HasSuffix(pattern, "/*") {
-		prefix := strings.TrimSuffix(pattern, "/*")
-		return strings.HasPrefix(path, prefix)
+// addFieldsParam adds the sparse fieldset query parameter to an operation and
+// notes the reduced shape on its success responses.
+func addFieldsParam(op *OperationObject, allowed []string, registry *TypeRegistry) {
+	if len(allowed) == 0 {
+		allowed = responseFieldNames(op, registry)
+	}
+
+	items := &SchemaObject{Type: "string"}
+	for _, name := range allowed {
+		items.Enum = append(items.Enum, name)
 	}
-	if strings.HasSuffix(pattern, "*") {
-		prefix := strings.TrimSuffix(pattern, "*")
-		return strings.HasPrefix(path, prefix)
+	explode := false
+	op.Parameters = append(op.Parameters, ParameterObject{
+		Name:        "fields",
+		In:          "query",
+		Description: "Comma-separated fields to include in the response. All fields are returned when omitted.",
+		Style:       "form",
+		Explode:     &explode,
+		Schema:      &SchemaObject{Type: "array", Items: items},
+		Example:     allowed[:min(2, len(allowed))],
+	})
+
+	for code, resp := range op.Responses {
+		if strings.HasPrefix(code, "2") && len(resp.Content) > 0 {
+			resp.Description = strings.TrimSuffix(resp.Description, ".") +
+				". Only the requested fields are present when `fields` is set."
+		}
+	}
+}
+
+// responseFieldNames returns the sorted property names of an operation's
+// success response object, looking through arrays and $refs.
+func responseFieldNames(op *OperationObject, registry *TypeRegistry) []string {
+	for _, code := range sortedKeys(op.Responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		resp := op.Responses[code]
+		schema := resp.Content["application/json"].Schema
+		if schema != nil && schema.Type == "array" {
+			schema = schema.Items
+		}
+		if schema != nil && schema.Ref != "" {
+			schema, _ = registry.Get(strings.TrimPrefix(schema.Ref, RefPath("")))
+		}
+		if schema == nil || len(schema.Properties) == 0 {
+			continue
+		}
+
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
 	}
-	return path == pattern
+	return nil
 }
@@ -0,0 +1,293 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func createWidget(c *gin.Context) {}
+
+func deleteWidget(c *gin.Context) {}
+
+func TestRouteFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v2/widgets", createWidget)
+
+	gd := Mount(r, nil)
+	gd.RouteFor(createWidget).Summary("Create a widget")
+	gd.RouteFor(deleteWidget).Summary("Delete a widget")
+
+	op := gd.Spec().Paths["/api/v2/widgets"].Post
+	if op == nil || op.Summary != "Create a widget" {
+		t.Fatalf("expected RouteFor summary, got %+v", op)
+	}
+
+	warnings := strings.Join(gd.Warnings(), "\n")
+	if !strings.Contains(warnings, "deleteWidget): handler is not registered on any route") {
+		t.Errorf("expected warning for unregistered handler, got:\n%s", warnings)
+	}
+}
+
+func TestGroupOverrideAdditions(t *testing.T) {
+	type Forbidden struct {
+		Error string `json:"error"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin/users", func(c *gin.Context) {})
+	r.POST("/admin/users", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Group("/admin/*").
+		Response(403, Forbidden{}, "Admins only").
+		Parameter("X-Tenant", "header", "", "Tenant ID").
+		SummaryPrefix("[Admin] ")
+	gd.Group("/admin/**").Methods("POST").Deprecated(true)
+	gd.Route("GET /admin/users").Summary("List users").Response(200, []Forbidden{}, "Users")
+
+	paths := gd.Spec().Paths["/admin/users"]
+	get, post := paths.Get, paths.Post
+	if get.Summary != "[Admin] List users" {
+		t.Errorf("summary = %q", get.Summary)
+	}
+	if resp := get.Responses["403"]; resp == nil || resp.Content["application/json"].Schema == nil {
+		t.Errorf("expected group 403 alongside route responses, got %+v", get.Responses)
+	}
+	if len(get.Parameters) != 1 || get.Parameters[0].Name != "X-Tenant" || get.Parameters[0].Schema.Type != "string" {
+		t.Errorf("parameters = %+v", get.Parameters)
+	}
+	if get.Deprecated || !post.Deprecated {
+		t.Errorf("Methods filter: GET deprecated=%v, POST deprecated=%v", get.Deprecated, post.Deprecated)
+	}
+
+	// A second Group with the same pattern replaces the first.
+	gd.Group("/admin/*").Tags("Admin")
+	gd.InvalidateCache()
+	get = gd.Spec().Paths["/admin/users"].Get
+	if get.Summary != "List users" || get.Responses["403"] != nil || len(get.Tags) != 1 || get.Tags[0] != "Admin" {
+		t.Errorf("expected the replacing group only, got summary %q, tags %v, responses %v", get.Summary, get.Tags, sortedKeys(get.Responses))
+	}
+}
+
+func TestHide(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/widgets", func(c *gin.Context) {})
+	r.DELETE("/widgets/:id", func(c *gin.Context) {})
+	r.POST("/widgets", createWidget)
+
+	gd := Mount(r, nil)
+	gd.Hide("delete /widgets/:id", "PUT /widgets/:id")
+	gd.RouteFor(createWidget).Hidden()
+
+	spec := gd.Spec()
+	if spec.Paths["/widgets"] == nil || spec.Paths["/widgets"].Get == nil {
+		t.Fatal("expected GET /widgets to stay documented")
+	}
+	if spec.Paths["/widgets"].Post != nil {
+		t.Error("expected POST /widgets to be hidden")
+	}
+	if _, ok := spec.Paths["/widgets/{id}"]; ok {
+		t.Error("expected DELETE /widgets/{id} to be hidden")
+	}
+
+	warnings := strings.Join(gd.Warnings(), "\n")
+	if !strings.Contains(warnings, `Hide("PUT /widgets/:id"): no such route`) {
+		t.Errorf("expected warning for hiding a missing route, got:\n%s", warnings)
+	}
+}
+
+func TestAddRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/widgets", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.AddRoute("get", "/ws/:room").Summary("Join a room")
+	gd.AddRoute("GET", "/widgets").Summary("List widgets")
+
+	spec := gd.Spec()
+	ws := spec.Paths["/ws/{room}"]
+	if ws == nil || ws.Get == nil || ws.Get.Summary != "Join a room" {
+		t.Fatalf("expected GET /ws/{room} to be documented, got %+v", ws)
+	}
+	if len(ws.Get.Parameters) != 1 || ws.Get.Parameters[0].Name != "room" {
+		t.Errorf("parameters = %+v", ws.Get.Parameters)
+	}
+	if got := spec.Paths["/widgets"].Get.Summary; got != "List widgets" {
+		t.Errorf("served route summary = %q", got)
+	}
+	if warnings := gd.Warnings(); len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+}
+
+func TestStreams(t *testing.T) {
+	type ChatMessage struct {
+		Text string `json:"text"`
+	}
+	type PriceTick struct {
+		Symbol string  `json:"symbol"`
+		Price  float64 `json:"price"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ws", func(c *gin.Context) {})
+	r.GET("/prices", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Route("GET /ws").WebSocket(ChatMessage{}).Response(401, nil, "Login required")
+	gd.Route("GET /prices").SSE(PriceTick{})
+
+	spec := gd.Spec()
+	ws := spec.Paths["/ws"].Get
+	if ws.WebSocket == nil || len(ws.WebSocket.Messages) != 1 || ws.WebSocket.Messages[0].Ref != RefPath("ChatMessage") {
+		t.Errorf("x-websocket = %+v", ws.WebSocket)
+	}
+	if ws.Responses["101"] == nil || ws.Responses["200"] != nil || ws.Responses["401"] == nil {
+		t.Errorf("websocket responses = %v", sortedKeys(ws.Responses))
+	}
+	if _, ok := spec.Components.Schemas["ChatMessage"]; !ok {
+		t.Error("expected ChatMessage to survive schema pruning")
+	}
+
+	sse := spec.Paths["/prices"].Get
+	media, ok := sse.Responses["200"].Content["text/event-stream"]
+	if sse.SSE == nil || !ok || media.Schema.Ref != RefPath("PriceTick") {
+		t.Errorf("sse = %+v, 200 content = %+v", sse.SSE, sse.Responses["200"].Content)
+	}
+	// Explicit success responses survive the stream defaults.
+	r = gin.New()
+	r.GET("/ws", func(c *gin.Context) {})
+	r.GET("/prices", func(c *gin.Context) {})
+	gd = Mount(r, nil)
+	gd.Route("GET /ws").WebSocket(ChatMessage{}).Response(200, ChatMessage{}, "History, without an upgrade")
+	gd.Route("GET /prices").SSE(PriceTick{}).Response(200, []PriceTick{}, "Latest prices")
+
+	spec = gd.Spec()
+	ws = spec.Paths["/ws"].Get
+	if ws.Responses["101"] == nil || ws.Responses["200"] == nil || ws.Responses["200"].Description != "History, without an upgrade" {
+		t.Errorf("websocket responses = %v", sortedKeys(ws.Responses))
+	}
+	prices := spec.Paths["/prices"].Get.Responses["200"]
+	if prices.Description != "Latest prices" || len(prices.Content) != 2 || prices.Content["text/event-stream"].Schema == nil {
+		t.Errorf("expected the explicit 200 with both JSON and the event stream, got %+v", prices)
+	}
+}
+
+type TestLoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type TestLoginResponse struct {
+	Token string `json:"token"`
+}
+
+func TestSensitiveFieldsRequestOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/login", func(c *gin.Context) {})
+	gd := Mount(r, nil)
+	gd.Route("POST /login").RequestBody(TestLoginRequest{}).Response(200, TestLoginResponse{}, "Logged in")
+
+	schemas := gd.getSpec().Components.Schemas
+	if !schemas["TestLoginRequest"].Properties["password"].WriteOnly {
+		t.Error("request password should be writeOnly")
+	}
+	if token := schemas["TestLoginResponse"].Properties["token"]; token == nil || token.WriteOnly {
+		t.Errorf("response token = %+v, want a regular property", token)
+	}
+}
+
+func TestFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	r.GET("/users/:id", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Route("GET /users").Response(200, []TestLoginRequest{}, "Users").Fields()
+	gd.Route("GET /users/:id").Response(200, TestLoginRequest{}, "A user.").Fields("email")
+
+	paths := gd.Spec().Paths
+	list := paths["/users"].Get
+	if len(list.Parameters) != 1 {
+		t.Fatalf("parameters = %+v", list.Parameters)
+	}
+	fields := list.Parameters[0]
+	if fields.Name != "fields" || fields.In != "query" || fields.Style != "form" || fields.Explode == nil || *fields.Explode {
+		t.Errorf("fields = %+v", fields)
+	}
+	if enum := fields.Schema.Items.Enum; len(enum) != 2 || enum[0] != "email" || enum[1] != "password" {
+		t.Errorf("expected the response properties as allowed fields, got %v", enum)
+	}
+
+	get := paths["/users/{id}"].Get
+	if enum := get.Parameters[len(get.Parameters)-1].Schema.Items.Enum; len(enum) != 1 || enum[0] != "email" {
+		t.Errorf("expected the explicit allowed fields, got %v", enum)
+	}
+	if desc := get.Responses["200"].Description; desc != "A user. Only the requested fields are present when `fields` is set." {
+		t.Errorf("description = %q", desc)
+	}
+}
+
+func TestMediaTypeVersioning(t *testing.T) {
+	const v1, v2 = "application/vnd.example.v1+json", "application/vnd.example.v2+json"
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/login", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Route("POST /login").
+		RequestBodyContent(v1, TestLoginRequest{}).
+		RequestBodyContent(v2, TestLoginResponse{}).
+		ResponseContent(200, v1, TestLoginResponse{}, "Logged in").
+		ResponseContent(200, v2, TestLoginRequest{}, "Logged in (v2)")
+
+	op := gd.Spec().Paths["/login"].Post
+	body := op.RequestBody.Content
+	if body[v1].Schema.Ref != RefPath("TestLoginRequest") || body[v2].Schema.Ref != RefPath("TestLoginResponse") {
+		t.Errorf("request content = %+v", body)
+	}
+
+	resp := op.Responses["200"]
+	if resp.Description != "Logged in" || len(resp.Content) != 2 {
+		t.Fatalf("expected one 200 response with both versions, got %+v", resp)
+	}
+	if resp.Content[v1].Schema.Ref != RefPath("TestLoginResponse") || resp.Content[v2].Schema.Ref != RefPath("TestLoginRequest") {
+		t.Errorf("response content = %+v", resp.Content)
+	}
+}
+
+func TestExternalDocs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	r.POST("/users", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{ExternalDocs: ExternalDocsInfo{URL: "https://docs.example.com", Description: "Guides"}})
+	gd.Route("GET /users").ExternalDocs("https://docs.example.com/users", "Listing users")
+
+	spec := gd.Spec()
+	if spec.ExternalDocs == nil || spec.ExternalDocs.URL != "https://docs.example.com" || spec.ExternalDocs.Description != "Guides" {
+		t.Errorf("spec externalDocs = %+v", spec.ExternalDocs)
+	}
+	if docs := spec.Paths["/users"].Get.ExternalDocs; docs == nil || docs.URL != "https://docs.example.com/users" {
+		t.Errorf("operation externalDocs = %+v", docs)
+	}
+	if docs := spec.Paths["/users"].Post.ExternalDocs; docs != nil {
+		t.Errorf("expected no externalDocs on other operations, got %+v", docs)
+	}
+
+	r = gin.New()
+	if docs := Mount(r, nil).Spec().ExternalDocs; docs != nil {
+		t.Errorf("expected no externalDocs by default, got %+v", docs)
+	}
+}
@@ -0,0 +1,48 @@
+package gindocs
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// pageTitle returns the <title> of the UI pages: Config.PageTitle, or the
+// API title.
+func pageTitle(title string, cfg Config) string {
+	if cfg.PageTitle != "" {
+		return cfg.PageTitle
+	}
+	return title
+}
+
+// headTags renders the favicon link and meta tags of the UI pages. Open
+// Graph title and description tags default to the page title and the API
+// description so links unfurl in chat apps; Config.MetaTags overrides them.
+func headTags(title string, cfg Config) string {
+	meta := map[string]string{
+		"og:type":  "website",
+		"og:title": pageTitle(title, cfg),
+	}
+	if cfg.Description != "" {
+		description := strings.Join(strings.Fields(cfg.Description), " ")
+		meta["description"] = description
+		meta["og:description"] = description
+	}
+	for name, content := range cfg.MetaTags {
+		meta[name] = content
+	}
+
+	var b strings.Builder
+	if cfg.Favicon != "" {
+		fmt.Fprintf(&b, `<link rel="icon" href="%s">`+"\n    ", template.HTMLEscapeString(cfg.Favicon))
+	}
+	for _, name := range sortedKeys(meta) {
+		// Open Graph tags use the property attribute.
+		attr := "name"
+		if strings.HasPrefix(name, "og:") {
+			attr = "property"
+		}
+		fmt.Fprintf(&b, `<meta %s="%s" content="%s">`+"\n    ", attr, template.HTMLEscapeString(name), template.HTMLEscapeString(meta[name]))
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,53 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHeadTags(t *testing.T) {
+	cfg := mergeConfig(Config{
+		Title:       "Acme API",
+		Description: "Orders and\ninvoices.",
+		PageTitle:   "Acme Developers",
+		Favicon:     "/static/favicon.ico",
+		MetaTags:    map[string]string{"og:image": "https://acme.test/card.png", "twitter:card": "summary_large_image"},
+	})
+
+	for _, html := range []string{
+		renderScalarHTML("Acme API", uiSource{specURL: "/docs/openapi.json"}, "", cfg),
+		renderSwaggerHTML("Acme API", uiSource{specURL: "/docs/openapi.json"}, "", cfg),
+	} {
+		for _, want := range []string{
+			"<title>Acme Developers</title>",
+			`<link rel="icon" href="/static/favicon.ico">`,
+			`<meta property="og:title" content="Acme Developers">`,
+			`<meta property="og:description" content="Orders and invoices.">`,
+			`<meta name="description" content="Orders and invoices.">`,
+			`<meta property="og:image" content="https://acme.test/card.png">`,
+			`<meta name="twitter:card" content="summary_large_image">`,
+		} {
+			if !strings.Contains(html, want) {
+				t.Errorf("expected page to contain %q", want)
+			}
+		}
+	}
+}
+
+func TestHeadAndFooterHTML(t *testing.T) {
+	cfg := mergeConfig(Config{
+		HeadHTML:       `<script src="https://analytics.test/a.js"></script>`,
+		BodyFooterHTML: `<div id="chat"></div>`,
+	})
+	for _, html := range []string{
+		renderScalarHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg),
+		renderSwaggerHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg),
+	} {
+		if !strings.Contains(html, "<script src=\"https://analytics.test/a.js\"></script>\n</head>") {
+			t.Error("expected HeadHTML at the end of <head>")
+		}
+		if !strings.Contains(html, "<div id=\"chat\"></div>\n</body>") {
+			t.Error("expected BodyFooterHTML at the end of <body>")
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package gindocs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// isListEndpoint reports whether a route is a GET collection endpoint,
+// i.e. its last path segment is not a parameter.
+func isListEndpoint(method, path string) bool {
+	if method != "GET" {
+		return false
+	}
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	lastSeg := segments[len(segments)-1]
+	return !strings.HasPrefix(lastSeg, ":") && !strings.HasPrefix(lastSeg, "*")
+}
+
+// addPaginationParams documents the pagination query parameters on a list
+// operation and notes the page size limit in its description.
+func addPaginationParams(op *OperationObject, p *PaginationConfig) {
+	one := 1.0
+	perPage := &SchemaObject{Type: "integer", Minimum: &one}
+	limit := ""
+	if p.MaxPerPage > 0 {
+		maxPerPage := float64(p.MaxPerPage)
+		perPage.Maximum = &maxPerPage
+		limit = fmt.Sprintf(" (max %d)", p.MaxPerPage)
+	}
+
+	op.Parameters = append(op.Parameters,
+		ParameterObject{
+			Name:        p.PageParam,
+			In:          "query",
+			Description: "Page number, starting at 1",
+			Schema:      &SchemaObject{Type: "integer", Minimum: &one, Default: 1},
+		},
+		ParameterObject{
+			Name:        p.PerPageParam,
+			In:          "query",
+			Description: "Items per page" + limit,
+			Schema:      perPage,
+		},
+		ParameterObject{
+			Name:        p.SortParam,
+			In:          "query",
+			Description: "Sort field; prefix with - for descending order",
+			Schema:      &SchemaObject{Type: "string"},
+			Example:     "-created_at",
+		},
+	)
+
+	note := fmt.Sprintf("Results are paginated with `%s` and `%s`.", p.PageParam, p.PerPageParam)
+	if p.MaxPerPage > 0 {
+		note += fmt.Sprintf(" At most %d items are returned per page.", p.MaxPerPage)
+	}
+	if op.Description != "" {
+		op.Description += "\n\n"
+	}
+	op.Description += note
+}
@@ -0,0 +1,55 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIsListEndpoint(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"GET", "/users", true},
+		{"GET", "/users/:id/posts", true},
+		{"GET", "/users/:id", false},
+		{"GET", "/files/*path", false},
+		{"POST", "/users", false},
+	}
+	for _, tt := range tests {
+		if got := isListEndpoint(tt.method, tt.path); got != tt.want {
+			t.Errorf("isListEndpoint(%s, %s) = %v", tt.method, tt.path, got)
+		}
+	}
+}
+
+func TestPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	r.GET("/users/:id", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{Pagination: &PaginationConfig{PerPageParam: "limit", MaxPerPage: 100}})
+	paths := gd.Spec().Paths
+
+	list := paths["/users"].Get
+	params := make(map[string]ParameterObject)
+	for _, p := range list.Parameters {
+		params[p.Name] = p
+	}
+	if len(params) != 3 || params["page"].In != "query" || params["sort"].In != "query" {
+		t.Fatalf("expected page, limit and sort query parameters, got %+v", list.Parameters)
+	}
+	if max := params["limit"].Schema.Maximum; max == nil || *max != 100 {
+		t.Errorf("expected limit capped at 100, got %+v", params["limit"].Schema)
+	}
+	if !strings.Contains(list.Description, "paginated with `page` and `limit`. At most 100 items") {
+		t.Errorf("description = %q", list.Description)
+	}
+
+	if item := paths["/users/{id}"].Get; len(item.Parameters) != 1 {
+		t.Errorf("expected only the path parameter on a single-item endpoint, got %+v", item.Parameters)
+	}
+}
@@ -0,0 +1,96 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// PayloadEstimate describes the typical size of an operation's success response,
+// computed from the generated example (one element per array).
+type PayloadEstimate struct {
+	// Bytes is the size of the example response as compact JSON.
+	Bytes int `json:"bytes"`
+
+	// Depth is the maximum object/array nesting depth of the response.
+	Depth int `json:"depth"`
+
+	// ExceedsThreshold is set when Bytes is above Config.PayloadWarnBytes.
+	ExceedsThreshold bool `json:"exceedsThreshold,omitempty"`
+}
+
+// addPayloadEstimates sets x-payload-estimate on every operation with a typed 2xx response.
+func addPayloadEstimates(spec *OpenAPISpec, warnBytes int) {
+	var schemas map[string]*SchemaObject
+	if spec.Components != nil {
+		schemas = spec.Components.Schemas
+	}
+
+	for _, pathItem := range spec.Paths {
+		for _, method := range httpMethods {
+			op := pathItem.GetOperation(method)
+			if op == nil {
+				continue
+			}
+			if estimate := estimatePayload(op.Responses, schemas); estimate != nil {
+				estimate.ExceedsThreshold = warnBytes > 0 && estimate.Bytes > warnBytes
+				op.PayloadEstimate = estimate
+			}
+		}
+	}
+}
+
+// estimatePayload estimates the first 2xx JSON response that has a schema.
+func estimatePayload(responses map[string]*Response, schemas map[string]*SchemaObject) *PayloadEstimate {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		media, ok := responses[code].Content["application/json"]
+		if !ok || media.Schema == nil {
+			continue
+		}
+
+		sample := media.Example
+		if sample == nil {
+			sample = sampleValue(media.Schema, schemas, "", map[string]bool{})
+		}
+		data, err := json.Marshal(sample)
+		if err != nil {
+			return nil
+		}
+
+		var normalized interface{}
+		_ = json.Unmarshal(data, &normalized)
+		return &PayloadEstimate{Bytes: len(data), Depth: payloadDepth(normalized)}
+	}
+
+	return nil
+}
+
+// payloadDepth returns the nesting depth of a JSON-decoded value.
+func payloadDepth(v interface{}) int {
+	depth := 0
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for _, item := range val {
+			if d := payloadDepth(item); d > depth {
+				depth = d
+			}
+		}
+		return depth + 1
+	case []interface{}:
+		for _, item := range val {
+			if d := payloadDepth(item); d > depth {
+				depth = d
+			}
+		}
+		return depth + 1
+	}
+	return 0
+}
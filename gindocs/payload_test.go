@@ -0,0 +1,57 @@
+package gindocs
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAddPayloadEstimates(t *testing.T) {
+	example := map[string]interface{}{"items": []interface{}{map[string]interface{}{"id": 1}}}
+	spec := &OpenAPISpec{Paths: map[string]*PathItem{
+		"/users": {
+			Get: &OperationObject{Responses: map[string]*Response{
+				"200": {Content: map[string]MediaType{"application/json": {
+					Schema:  &SchemaObject{Type: "object"},
+					Example: example,
+				}}},
+			}},
+			Delete: &OperationObject{Responses: map[string]*Response{
+				"204": {Description: "Deleted"},
+			}},
+		},
+	}}
+
+	addPayloadEstimates(spec, 10)
+
+	// {"items":[{"id":1}]}
+	get := spec.Paths["/users"].Get.PayloadEstimate
+	if get == nil || get.Bytes != 20 || get.Depth != 3 || !get.ExceedsThreshold {
+		t.Errorf("estimate = %+v", get)
+	}
+	if del := spec.Paths["/users"].Delete.PayloadEstimate; del != nil {
+		t.Errorf("expected no estimate without a response body, got %+v", del)
+	}
+}
+
+func TestPayloadEstimatesFromSchema(t *testing.T) {
+	type Tag struct {
+		Name string `json:"name"`
+	}
+	type Post struct {
+		ID   int   `json:"id"`
+		Tags []Tag `json:"tags"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/posts", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{PayloadEstimates: true})
+	gd.Route("GET /posts").Response(200, []Post{}, "Posts")
+
+	estimate := gd.Spec().Paths["/posts"].Get.PayloadEstimate
+	if estimate == nil || estimate.Bytes == 0 || estimate.Depth != 4 || estimate.ExceedsThreshold {
+		t.Errorf("estimate = %+v", estimate)
+	}
+}
@@ -0,0 +1,121 @@
+package gindocs
+
+// referencedSchemas returns the names of the component schemas reachable
+// from the spec's operations and non-schema components.
+func referencedSchemas(spec *OpenAPISpec) map[string]bool {
+	var schemas map[string]*SchemaObject
+	if spec.Components != nil {
+		schemas = spec.Components.Schemas
+	}
+
+	seen := make(map[string]bool)
+	var visit func(s *SchemaObject)
+	visit = func(s *SchemaObject) {
+		for _, name := range schemaRefs(s) {
+			if !seen[name] {
+				seen[name] = true
+				visit(schemas[name])
+			}
+		}
+	}
+	visitResponse := func(resp *Response) {
+		if resp == nil {
+			return
+		}
+		for _, media := range resp.Content {
+			visit(media.Schema)
+		}
+		for _, header := range resp.Headers {
+			if header != nil {
+				visit(header.Schema)
+			}
+		}
+	}
+
+	for _, pathItem := range spec.Paths {
+		for _, method := range httpMethods {
+			op := pathItem.GetOperation(method)
+			if op == nil {
+				continue
+			}
+			for _, param := range op.Parameters {
+				visit(param.Schema)
+			}
+			if op.RequestBody != nil {
+				for _, media := range op.RequestBody.Content {
+					visit(media.Schema)
+				}
+			}
+			for _, resp := range op.Responses {
+				visitResponse(resp)
+			}
+			for _, stream := range []*StreamObject{op.WebSocket, op.SSE} {
+				if stream != nil {
+					for _, message := range stream.Messages {
+						visit(message)
+					}
+				}
+			}
+		}
+	}
+
+	if spec.Components != nil {
+		for _, param := range spec.Components.Parameters {
+			if param != nil {
+				visit(param.Schema)
+			}
+		}
+		for _, body := range spec.Components.RequestBodies {
+			if body != nil {
+				for _, media := range body.Content {
+					visit(media.Schema)
+				}
+			}
+		}
+		for _, resp := range spec.Components.Responses {
+			visitResponse(resp)
+		}
+	}
+
+	return seen
+}
+
+// pruneSchemas drops component schemas that nothing references. The
+// components object is copied, so specs sharing it are unaffected.
+func pruneSchemas(spec *OpenAPISpec) {
+	if spec.Components == nil || len(spec.Components.Schemas) == 0 {
+		return
+	}
+
+	used := referencedSchemas(spec)
+	components := *spec.Components
+	components.Schemas = make(map[string]*SchemaObject, len(used))
+	for name, schema := range spec.Components.Schemas {
+		if used[name] {
+			components.Schemas[name] = schema
+		}
+	}
+	spec.Components = &components
+}
+
+// pruneTags drops tag definitions that no operation uses.
+func pruneTags(spec *OpenAPISpec) {
+	used := make(map[string]bool)
+	for _, pathItem := range spec.Paths {
+		for _, method := range httpMethods {
+			if op := pathItem.GetOperation(method); op != nil {
+				for _, tag := range op.Tags {
+					used[tag] = true
+				}
+			}
+		}
+	}
+
+	var tags []TagObject
+	for _, tag := range spec.Tags {
+		if used[tag.Name] {
+			tags = append(tags, tag)
+		}
+	}
+	spec.Tags = tags
+}
@@ -0,0 +1,97 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestFilteredSpec(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Customer struct {
+		Name    string  `json:"name"`
+		Address Address `json:"address"`
+	}
+	type Post struct {
+		Title string `json:"title"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/customers", func(c *gin.Context) {})
+	r.GET("/api/v2/customers", func(c *gin.Context) {})
+	r.GET("/api/v2/posts", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Route("GET /api/v1/customers").Tags("Customers").Response(200, []Customer{}, "Customers")
+	gd.Route("GET /api/v2/customers").Tags("Customers").Response(200, []Customer{}, "Customers")
+	gd.Route("GET /api/v2/posts").Tags("Posts").Response(200, []Post{}, "Posts")
+
+	fetch := func(query string) OpenAPISpec {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json"+query, nil))
+		var spec OpenAPISpec
+		if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+			t.Fatal(err)
+		}
+		return spec
+	}
+
+	spec := fetch("?tags=Customers")
+	if len(spec.Paths) != 2 || spec.Paths["/api/v2/posts"] != nil {
+		t.Errorf("tag filter kept %d paths", len(spec.Paths))
+	}
+	if spec.Components.Schemas["Post"] != nil || spec.Components.Schemas["Address"] == nil {
+		t.Errorf("expected only Customer and Address schemas, got %v", sortedKeys(spec.Components.Schemas))
+	}
+	if len(spec.Tags) != 1 || spec.Tags[0].Name != "Customers" {
+		t.Errorf("tags = %+v", spec.Tags)
+	}
+
+	spec = fetch("?prefix=/api/v2")
+	if len(spec.Paths) != 2 || spec.Paths["/api/v1/customers"] != nil {
+		t.Errorf("prefix filter kept %v", sortedKeys(spec.Paths))
+	}
+
+	if full := gd.Spec(); full.Components.Schemas["Post"] == nil {
+		t.Error("filtering must not modify the cached spec")
+	}
+}
+
+func TestPruneUnusedSchemas(t *testing.T) {
+	type Tag struct {
+		ID   uint   `json:"id" gorm:"primaryKey"`
+		Name string `json:"name"`
+	}
+	type Note struct {
+		ID   uint   `json:"id" gorm:"primaryKey"`
+		Body string `json:"body"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/notes", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{Models: []interface{}{Tag{}, Note{}}})
+	gd.Route("GET /notes").Response(200, []Note{}, "Notes")
+
+	schemas := gd.Spec().Components.Schemas
+	if _, ok := schemas["Note"]; !ok {
+		t.Error("expected referenced Note schema to be kept")
+	}
+	for _, name := range []string{"Tag", "CreateTag", "UpdateTag", "CreateNote", "UpdateNote"} {
+		if _, ok := schemas[name]; ok {
+			t.Errorf("expected unused schema %s to be pruned", name)
+		}
+	}
+
+	gd = Mount(gin.New(), nil, Config{Models: []interface{}{Tag{}}, KeepUnusedSchemas: true})
+	if _, ok := gd.Spec().Components.Schemas["CreateTag"]; !ok {
+		t.Error("expected KeepUnusedSchemas to keep CreateTag")
+	}
+}
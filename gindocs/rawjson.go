@@ -0,0 +1,56 @@
+package gindocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// parseJSONSample decodes a sample JSON payload and infers a schema for it.
+// Returns false if the payload isn't valid JSON.
+func parseJSONSample(raw string) (*SchemaObject, interface{}, bool) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	dec.UseNumber()
+
+	var sample interface{}
+	if err := dec.Decode(&sample); err != nil || dec.More() {
+		return nil, nil, false
+	}
+	return schemaFromJSON(sample), sample, true
+}
+
+// schemaFromJSON infers a schema from a value decoded with UseNumber.
+func schemaFromJSON(v interface{}) *SchemaObject {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		schema := &SchemaObject{Type: "object", Properties: make(map[string]*SchemaObject, len(val))}
+		for key, item := range val {
+			schema.Properties[key] = schemaFromJSON(item)
+			if item != nil {
+				schema.Required = append(schema.Required, key)
+			}
+		}
+		sort.Strings(schema.Required)
+		return schema
+	case []interface{}:
+		items := &SchemaObject{}
+		if len(val) > 0 {
+			items = schemaFromJSON(val[0])
+		}
+		return &SchemaObject{Type: "array", Items: items}
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return &SchemaObject{Type: "integer"}
+		}
+		return &SchemaObject{Type: "number"}
+	case string:
+		if _, err := time.Parse(time.RFC3339, val); err == nil {
+			return &SchemaObject{Type: "string", Format: "date-time"}
+		}
+		return &SchemaObject{Type: "string"}
+	case bool:
+		return &SchemaObject{Type: "boolean"}
+	}
+	return &SchemaObject{}
+}
@@ -0,0 +1,37 @@
+package gindocs
+
+import "testing"
+
+func TestParseJSONSample(t *testing.T) {
+	schema, example, ok := parseJSONSample(`{"status":"ok","count":3,"ratio":0.5,"at":"2024-01-02T03:04:05Z","tags":["a"],"meta":null}`)
+	if !ok {
+		t.Fatal("expected valid JSON to parse")
+	}
+	if example == nil {
+		t.Error("expected the sample to be kept as the example")
+	}
+
+	want := map[string]string{"status": "string", "count": "integer", "ratio": "number", "at": "string", "tags": "array", "meta": ""}
+	for name, typ := range want {
+		prop, ok := schema.Properties[name]
+		if !ok {
+			t.Fatalf("missing property %s", name)
+		}
+		if prop.Type != typ {
+			t.Errorf("%s: expected type %q, got %q", name, typ, prop.Type)
+		}
+	}
+	if schema.Properties["at"].Format != "date-time" {
+		t.Error("expected RFC 3339 strings to get date-time format")
+	}
+	if schema.Properties["tags"].Items.Type != "string" {
+		t.Error("expected array items to be inferred from the first element")
+	}
+	if len(schema.Required) != 5 {
+		t.Errorf("expected null fields to be optional, got required %v", schema.Required)
+	}
+
+	if _, _, ok := parseJSONSample(`{"broken":`); ok {
+		t.Error("expected invalid JSON to be rejected")
+	}
+}
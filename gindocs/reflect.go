@@ -38,3 +38,67 @@ func handlerPackageName(handlerName string) string {
 func getFuncName(f interface{}) string {
 	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
 }
+
+// funcSourceLocation returns the file and line where a function is defined.
+func funcSourceLocation(f interface{}) (string, int) {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return "", 0
+	}
+	fn := runtime.FuncForPC(v.Pointer())
+	if fn == nil {
+		return "", 0
+	}
+	return fn.FileLine(fn.Entry())
+}
+
+// handlerChains returns the names of every handler of each route, middleware
+// first, keyed by "METHOD /path". gin.RoutesInfo only exposes the last
+// handler, so the chains are read from the router's trees.
+func handlerChains(engine interface{}) map[string][]string {
+	v := reflect.ValueOf(engine)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	trees := v.Elem().FieldByName("trees")
+	if !trees.IsValid() || trees.Kind() != reflect.Slice {
+		return nil
+	}
+
+	chains := make(map[string][]string)
+	for i := 0; i < trees.Len(); i++ {
+		tree := trees.Index(i)
+		method := tree.FieldByName("method")
+		root := tree.FieldByName("root")
+		if method.Kind() != reflect.String || root.Kind() != reflect.Ptr {
+			return nil
+		}
+		collectHandlerChains(root, "", method.String(), chains)
+	}
+	return chains
+}
+
+// collectHandlerChains walks a gin route tree node and its children.
+func collectHandlerChains(n reflect.Value, prefix, method string, chains map[string][]string) {
+	if n.IsNil() {
+		return
+	}
+	node := n.Elem()
+	path := prefix + node.FieldByName("path").String()
+
+	if handlers := node.FieldByName("handlers"); handlers.Kind() == reflect.Slice && handlers.Len() > 0 {
+		names := make([]string, handlers.Len())
+		for i := range names {
+			if fn := runtime.FuncForPC(handlers.Index(i).Pointer()); fn != nil {
+				names[i] = fn.Name()
+			}
+		}
+		chains[method+" "+path] = names
+	}
+
+	if children := node.FieldByName("children"); children.Kind() == reflect.Slice {
+		for i := 0; i < children.Len(); i++ {
+			collectHandlerChains(children.Index(i), path, method, chains)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package gindocs
 
 import (
+	"fmt"
+	"path"
 	"reflect"
+	"strings"
 	"sync"
 )
 
@@ -9,23 +12,72 @@ import (
 type TypeRegistry struct {
 	mu      sync.RWMutex
 	schemas map[string]*SchemaObject
-	// seen tracks types currently being processed (for circular reference detection).
-	seen map[reflect.Type]bool
+	// stack holds the structs currently being processed, outermost first,
+	// for circular reference detection and the depth limit.
+	stack []stackEntry
+	// cut is the stack index of the outermost struct a pending truncation
+	// depends on, or -1. Structs deeper than it are truncated depending on
+	// where they were reached, so they aren't registered.
+	cut int
+
+	// disableNullable turns off nullable schemas for pointer fields.
+	disableNullable bool
+
+	// preferValidate gives validate tag rules precedence over binding tag rules.
+	preferValidate bool
+
+	// typeSchemas maps Go types to fixed schemas, bypassing reflection.
+	typeSchemas map[reflect.Type]*SchemaObject
+
+	// schemaHook is called after each schema is registered.
+	schemaHook func(name string, s *SchemaObject)
+
+	// maxDepth limits struct nesting; 0 means unlimited.
+	maxDepth int
+
+	// cyclePolicy decides how recursive types are documented.
+	cyclePolicy CyclePolicy
+
+	// modelComments takes schema and property descriptions from the Go
+	// source of struct types under sourceRoot.
+	modelComments bool
+	sourceRoot    string
+
+	// naming is the strategy used to derive schema names from types.
+	naming SchemaNaming
+
+	// types records which Go type claimed each schema name, and names the
+	// name given to each type.
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+
+	// hint names the next anonymous struct, e.g. "GetApiSearchResponseBody"
+	// or "OrderShipping" for the Shipping field of Order.
+	hint string
+
+	// warnings collects non-fatal issues found while building schemas.
+	warnings []string
 }
 
 // newTypeRegistry creates a new TypeRegistry.
 func newTypeRegistry() *TypeRegistry {
 	return &TypeRegistry{
 		schemas: make(map[string]*SchemaObject),
-		seen:    make(map[reflect.Type]bool),
+		cut:     -1,
+		types:   make(map[string]reflect.Type),
+		names:   make(map[reflect.Type]string),
 	}
 }
 
 // Register adds a schema to the registry under the given name.
 func (r *TypeRegistry) Register(name string, schema *SchemaObject) {
 	r.mu.Lock()
-	defer r.mu.Unlock()
 	r.schemas[name] = schema
+	r.mu.Unlock()
+
+	if r.schemaHook != nil {
+		r.schemaHook(name, schema)
+	}
 }
 
 // Get retrieves a schema by name.
@@ -55,6 +107,16 @@ func (r *TypeRegistry) All() map[string]*SchemaObject {
 	return result
 }
 
+// mappedSchema returns a copy of the configured schema for a type, or nil.
+func (r *TypeRegistry) mappedSchema(t reflect.Type) *SchemaObject {
+	mapped, ok := r.typeSchemas[t]
+	if !ok || mapped == nil {
+		return nil
+	}
+	schema := *mapped
+	return &schema
+}
+
 // RefPath returns the OpenAPI $ref path for a named schema.
 func RefPath(name string) string {
 	return "#/components/schemas/" + name
@@ -67,25 +129,143 @@ func SchemaRef(name string) *SchemaObject {
 	}
 }
 
-// markSeen marks a type as currently being processed (for circular ref detection).
-func (r *TypeRegistry) markSeen(t reflect.Type) {
+// stackEntry is a struct being processed.
+type stackEntry struct {
+	t reflect.Type
+	// referenced is set when a recursive $ref points at the struct, so it
+	// must be registered.
+	referenced bool
+}
+
+// push marks a type as being processed and returns its stack index.
+func (r *TypeRegistry) push(t reflect.Type) int {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.seen[t] = true
+	r.stack = append(r.stack, stackEntry{t: t})
+	return len(r.stack) - 1
 }
 
-// unmarkSeen removes a type from the processing set.
-func (r *TypeRegistry) unmarkSeen(t reflect.Type) {
+// pop removes the innermost type from the processing stack.
+func (r *TypeRegistry) pop() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	delete(r.seen, t)
+	r.stack = r.stack[:len(r.stack)-1]
+}
+
+// depth returns the number of structs currently being processed.
+func (r *TypeRegistry) depth() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.stack)
 }
 
-// isSeen checks if a type is currently being processed.
-func (r *TypeRegistry) isSeen(t reflect.Type) bool {
+// stackIndex returns the stack index of a type being processed, or -1.
+func (r *TypeRegistry) stackIndex(t reflect.Type) int {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return r.seen[t]
+	for i, entry := range r.stack {
+		if entry.t == t {
+			return i
+		}
+	}
+	return -1
+}
+
+// reference records a recursive $ref to the struct at stack index i.
+func (r *TypeRegistry) reference(i int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stack[i].referenced = true
+}
+
+// truncate records a truncation that depends on the struct at stack index i.
+func (r *TypeRegistry) truncate(i int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cut < 0 || i < r.cut {
+		r.cut = i
+	}
+}
+
+// settle reports whether the struct at stack index i, now processed, must be
+// registered. It isn't when a truncation inside it depends on a struct
+// further out, unless a recursive $ref points at it.
+func (r *TypeRegistry) settle(i int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cut < 0 || r.cut >= i {
+		r.cut = -1
+		return true
+	}
+	return r.stack[i].referenced
+}
+
+// nameFor returns the component name for a named type using the configured
+// naming strategy. When another type already uses the name, the type is
+// renamed with its package (then its full import path) and a warning is
+// recorded, so both schemas survive.
+func (r *TypeRegistry) nameFor(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	r.mu.Lock()
+	if name, ok := r.names[t]; ok {
+		r.mu.Unlock()
+		return name
+	}
+
+	if t.Name() == "" {
+		name := r.anonymousName(t)
+		r.mu.Unlock()
+		return name
+	}
+
+	candidates := []string{
+		schemaNameWith(t, r.naming),
+		schemaNameWith(t, SchemaNamingPackage),
+		schemaNameWith(t, SchemaNamingFullPath),
+	}
+	free := func(name string) bool {
+		existing, ok := r.types[name]
+		return !ok || existing == t
+	}
+	name := ""
+	for _, candidate := range candidates {
+		if free(candidate) {
+			name = candidate
+			break
+		}
+	}
+	// Types declared inside different functions share a full path too.
+	for i := 2; name == ""; i++ {
+		if candidate := fmt.Sprintf("%s%d", candidates[0], i); free(candidate) {
+			name = candidate
+		}
+	}
+	existing, collided := r.types[candidates[0]]
+	collided = collided && existing != t
+	r.types[name] = t
+	r.names[t] = name
+	r.mu.Unlock()
+
+	if collided {
+		r.warn(fmt.Sprintf("schema name collision: %s and %s both map to %q; documenting %s as %q",
+			existing, t, candidates[0], t, name))
+	}
+	return name
+}
+
+// warn records a non-fatal issue once.
+func (r *TypeRegistry) warn(msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, w := range r.warnings {
+		if w == msg {
+			return
+		}
+	}
+	r.warnings = append(r.warnings, msg)
 }
 
 // schemaName generates a schema name from a reflect.Type.
@@ -103,3 +283,43 @@ func schemaName(t reflect.Type) string {
 
 	return name
 }
+
+// anonymousName names an anonymous struct after the hint set by its owner
+// (a route or a field), numbering repeats. r.mu must be held.
+func (r *TypeRegistry) anonymousName(t reflect.Type) string {
+	base := r.hint
+	if base == "" {
+		base = schemaName(t)
+	}
+	name := base
+	for i := 2; r.types[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	r.types[name] = t
+	r.names[t] = name
+	return name
+}
+
+// withHint runs fn with hint as the name for the anonymous struct it
+// converts, if any.
+func (r *TypeRegistry) withHint(hint string, fn func() *SchemaObject) *SchemaObject {
+	prev := r.hint
+	r.hint = hint
+	defer func() { r.hint = prev }()
+	return fn()
+}
+
+// schemaNameWith names a named type using a naming strategy.
+func schemaNameWith(t reflect.Type, naming SchemaNaming) string {
+	pkg := t.PkgPath()
+	if pkg == "" {
+		return t.Name()
+	}
+	switch naming {
+	case SchemaNamingPackage:
+		return path.Base(pkg) + "." + t.Name()
+	case SchemaNamingFullPath:
+		return strings.ReplaceAll(pkg, "/", ".") + "." + t.Name()
+	}
+	return t.Name()
+}
@@ -0,0 +1,89 @@
+package gindocs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Location struct {
+	City string `json:"city"`
+}
+
+func TestSchemaNamingCollision(t *testing.T) {
+	registry := newTypeRegistry()
+	local := registry.nameFor(reflect.TypeOf(Location{}))
+	other := registry.nameFor(reflect.TypeOf(&time.Location{}))
+
+	if local != "Location" || other != "time.Location" {
+		t.Errorf("names = %q, %q; want Location, time.Location", local, other)
+	}
+	if again := registry.nameFor(reflect.TypeOf(time.Location{})); again != other {
+		t.Errorf("expected a stable name, got %q then %q", other, again)
+	}
+	if len(registry.warnings) != 1 || !strings.Contains(registry.warnings[0], `documenting time.Location as "time.Location"`) {
+		t.Errorf("warnings = %v", registry.warnings)
+	}
+}
+
+func TestSchemaNamingStrategies(t *testing.T) {
+	typ := reflect.TypeOf(Location{})
+	tests := map[SchemaNaming]string{
+		SchemaNamingShort:    "Location",
+		SchemaNamingPackage:  "gindocs.Location",
+		SchemaNamingFullPath: "github.com.MUKE-coder.gin-docs.gindocs.Location",
+	}
+	for naming, want := range tests {
+		registry := newTypeRegistry()
+		registry.naming = naming
+		if got := registry.nameFor(typ); got != want {
+			t.Errorf("naming %d: got %q, want %q", naming, got, want)
+		}
+	}
+}
+
+func TestAnonymousStructNames(t *testing.T) {
+	type Order struct {
+		ID       int `json:"id"`
+		Shipping struct {
+			City string `json:"city"`
+		} `json:"shipping"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/search", func(c *gin.Context) {})
+	r.POST("/orders", func(c *gin.Context) {})
+
+	gd := Mount(r, nil)
+	gd.Route("GET /search").
+		Response(200, struct {
+			Results []string `json:"results"`
+		}{}, "Results").
+		Response(400, struct {
+			Reason string `json:"reason"`
+		}{}, "Bad query")
+	gd.Route("POST /orders").
+		RequestBody(struct {
+			Items []struct {
+				SKU string `json:"sku"`
+			} `json:"items"`
+		}{}).
+		Response(201, Order{}, "Created")
+
+	schemas := gd.Spec().Components.Schemas
+	for _, name := range []string{
+		"GetSearchResponseBody",
+		"GetSearch400ResponseBody",
+		"PostOrdersRequestBody",
+		"PostOrdersRequestBodyItems",
+		"OrderShipping",
+	} {
+		if _, ok := schemas[name]; !ok {
+			t.Errorf("expected schema %s, got %v", name, sortedKeys(schemas))
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package gindocs
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxSeedCount caps the rows inserted per model by one seed request.
+const maxSeedCount = 100
+
+// SeedResult reports what a seed request inserted.
+type SeedResult struct {
+	// Seeded maps model names to the number of rows inserted.
+	Seeded map[string]int `json:"seeded"`
+
+	// Errors maps model names to the first insert error, if any.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// handleSandboxSeed inserts example rows for the registered models.
+func (gd *GinDocs) handleSandboxSeed(c *gin.Context) {
+	if token := gd.config.SandboxSeedToken; token != "" &&
+		subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Seed-Token")), []byte(token)) != 1 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid seed token"})
+		return
+	}
+	if gd.db == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "no database configured"})
+		return
+	}
+
+	count, err := strconv.Atoi(c.DefaultQuery("count", "5"))
+	if err != nil || count < 1 || count > maxSeedCount {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("count must be between 1 and %d", maxSeedCount)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gd.seedModels(count))
+}
+
+// seedModels inserts count example rows per registered model. Rows are built
+// from the model's Create schema, so auto-generated fields are left to the database.
+func (gd *GinDocs) seedModels(count int) SeedResult {
+	result := SeedResult{Seeded: map[string]int{}, Errors: map[string]string{}}
+
+	// Read from the registry: unused Create schemas are pruned from the spec.
+	gd.getSpec()
+	gd.specMu.RLock()
+	registry := gd.registry
+	gd.specMu.RUnlock()
+	schemas := registry.All()
+
+	for _, model := range gd.models() {
+		t := derefType(reflect.TypeOf(model))
+		if t.Kind() != reflect.Struct || t.Name() == "" {
+			continue
+		}
+
+		name := registry.nameFor(t)
+		sample, ok := sampleValue(SchemaRef(variantName("Create", name)), schemas, name, map[string]bool{}).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		unique := uniqueJSONFields(t, registry)
+
+		for i := 0; i < count; i++ {
+			row := make(map[string]interface{}, len(sample))
+			for k, v := range sample {
+				row[k] = v
+			}
+			// Unique columns need a distinct value per row.
+			suffix := strconv.FormatInt(time.Now().UnixNano()+int64(i), 36)
+			for _, field := range unique {
+				if s, ok := row[field].(string); ok {
+					row[field] = uniqueSeedValue(s, suffix)
+				}
+			}
+
+			record := reflect.New(t).Interface()
+			data, _ := json.Marshal(row)
+			if err := json.Unmarshal(data, record); err != nil {
+				result.Errors[name] = err.Error()
+				break
+			}
+			if err := gd.db.Create(record).Error; err != nil {
+				result.Errors[name] = err.Error()
+				break
+			}
+			result.Seeded[name]++
+		}
+	}
+
+	return result
+}
+
+// uniqueJSONFields returns the JSON names of a model's unique string fields.
+func uniqueJSONFields(t reflect.Type, registry *TypeRegistry) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && derefType(field.Type).Kind() == reflect.Struct {
+			fields = append(fields, uniqueJSONFields(derefType(field.Type), registry)...)
+			continue
+		}
+
+		tags := registry.fieldTags(field)
+		if !tags.UniqueIndex || derefType(field.Type).Kind() != reflect.String {
+			continue
+		}
+		name := tags.JSONName
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// uniqueSeedValue makes an example string unique, keeping emails valid.
+func uniqueSeedValue(s, suffix string) string {
+	if at := strings.Index(s, "@"); at > 0 {
+		return s[:at] + "+" + suffix + s[at:]
+	}
+	return s + "-" + suffix
+}
@@ -0,0 +1,78 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+type SeedUser struct {
+	ID    uint   `json:"id" gorm:"primaryKey"`
+	Email string `json:"email" gorm:"uniqueIndex" binding:"required,email"`
+	Name  string `json:"name"`
+}
+
+func TestSandboxSeed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seed := func(cfg Config, url, token string) *httptest.ResponseRecorder {
+		r := gin.New()
+		cfg.SandboxSeed = true
+		cfg.Models = []interface{}{SeedUser{}}
+		Mount(r, db, cfg)
+		req := httptest.NewRequest(http.MethodPost, url, nil)
+		if token != "" {
+			req.Header.Set("X-Seed-Token", token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Outside DevMode the endpoint only exists with a token.
+	if w := seed(Config{}, "/docs/sandbox/seed", ""); w.Code != http.StatusNotFound {
+		t.Errorf("without token: got %d, want 404", w.Code)
+	}
+	if w := seed(Config{SandboxSeedToken: "s3cret"}, "/docs/sandbox/seed", "wrong"); w.Code != http.StatusUnauthorized {
+		t.Errorf("wrong token: got %d, want 401", w.Code)
+	}
+	if w := seed(Config{SandboxSeedToken: "s3cret"}, "/docs/sandbox/seed?count=1000", "s3cret"); w.Code != http.StatusBadRequest {
+		t.Errorf("count=1000: got %d, want 400", w.Code)
+	}
+
+	w := seed(Config{SandboxSeedToken: "s3cret"}, "/docs/sandbox/seed?count=3", "s3cret")
+	var result SeedResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusOK || result.Seeded["SeedUser"] != 3 || len(result.Errors) != 0 {
+		t.Errorf("seed = %d %+v", w.Code, result)
+	}
+
+	if w := seed(Config{DevMode: true}, "/docs/sandbox/seed?count=1", ""); w.Code != http.StatusOK {
+		t.Errorf("DevMode without token: got %d, want 200", w.Code)
+	}
+}
+
+func TestUniqueSeedValue(t *testing.T) {
+	if got := uniqueSeedValue("ada@example.com", "x1"); got != "ada+x1@example.com" {
+		t.Errorf("email = %q", got)
+	}
+	if got := uniqueSeedValue("ada", "x1"); !strings.HasSuffix(got, "-x1") {
+		t.Errorf("string = %q", got)
+	}
+	if fields := uniqueJSONFields(reflect.TypeOf(SeedUser{}), newTypeRegistry()); len(fields) != 1 || fields[0] != "email" {
+		t.Errorf("unique fields = %v, want [email]", fields)
+	}
+}
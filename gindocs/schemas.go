@@ -1,12 +1,39 @@
 package gindocs
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// SchemaProvider is implemented by types that describe their own schema.
+// typeToSchema uses the returned schema as-is instead of reflecting on the type,
+// which gives full control over enums, custom wrappers, and oneOf unions.
+type SchemaProvider interface {
+	DocSchema() *SchemaObject
+}
+
+// schemaProviderType is the reflect.Type of the SchemaProvider interface.
+var schemaProviderType = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+
+// DocTitler is implemented by models that set their component schema title.
+type DocTitler interface {
+	DocTitle() string
+}
+
+// DocDescriber is implemented by models that describe their component schema.
+type DocDescriber interface {
+	DocDescription() string
+}
+
+// DocExampler is implemented by models that provide an object-level example.
+type DocExampler interface {
+	DocExample() interface{}
+}
+
 // typeToSchema converts a Go reflect.Type to an OpenAPI SchemaObject.
 // It registers struct types in the registry and returns $ref for known types.
 func typeToSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
@@ -15,11 +42,36 @@ func typeToSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
 		t = t.Elem()
 	}
 
+	// Configured type mappings win over everything else.
+	if schema := registry.mappedSchema(t); schema != nil {
+		return schema
+	}
+
+	// Self-describing types take precedence over reflection.
+	if schema := providedSchema(t); schema != nil {
+		return schema
+	}
+
+	// Types registered with RegisterEnum share an enum component.
+	if schema := enumSchema(t, registry); schema != nil {
+		return schema
+	}
+
+	// sql.NullString and friends serialize as their wrapped value.
+	if valueType := sqlNullValueType(t); valueType != nil {
+		return typeToSchema(valueType, registry)
+	}
+
 	// Handle special types first.
 	if schema := specialTypeSchema(t); schema != nil {
 		return schema
 	}
 
+	return kindToSchema(t, registry)
+}
+
+// kindToSchema converts a type to a schema based on its kind alone.
+func kindToSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
 	switch t.Kind() {
 	case reflect.Bool:
 		return &SchemaObject{Type: "boolean"}
@@ -66,15 +118,26 @@ func typeToSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
 		return &SchemaObject{}
 
 	default:
+		registry.warn(fmt.Sprintf("type %s (%s) is not supported; documented as string", t, t.Kind()))
 		return &SchemaObject{Type: "string"}
 	}
 }
 
 // specialTypeSchema handles well-known types that need special treatment.
 func specialTypeSchema(t reflect.Type) *SchemaObject {
-	// time.Time → string with date-time format.
-	if t == reflect.TypeOf(time.Time{}) {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		// time.Time → string with date-time format.
 		return &SchemaObject{Type: "string", Format: "date-time"}
+	case reflect.TypeOf(time.Duration(0)):
+		// encoding/json writes durations as integer nanoseconds. Map the type
+		// with Config.TypeSchemas if it is marshaled differently.
+		return &SchemaObject{Type: "integer", Format: "int64", Description: "Duration in nanoseconds"}
+	case reflect.TypeOf(json.Number("")):
+		return &SchemaObject{Type: "number"}
+	case reflect.TypeOf(json.RawMessage{}):
+		// Arbitrary embedded JSON.
+		return &SchemaObject{Type: "object", AdditionalProperties: &SchemaObject{}}
 	}
 
 	// Check for types that implement encoding.TextMarshaler (they serialize as strings).
@@ -86,6 +149,22 @@ func specialTypeSchema(t reflect.Type) *SchemaObject {
 	return nil
 }
 
+// providedSchema returns the schema of a type implementing SchemaProvider, or nil.
+func providedSchema(t reflect.Type) *SchemaObject {
+	if t.Kind() == reflect.Interface || !reflect.PtrTo(t).Implements(schemaProviderType) {
+		return nil
+	}
+
+	provided := reflect.New(t).Interface().(SchemaProvider).DocSchema()
+	if provided == nil {
+		return nil
+	}
+
+	// Copy so tag constraints applied later don't mutate the provider's schema.
+	schema := *provided
+	return &schema
+}
+
 // structToSchema converts a struct type to an OpenAPI SchemaObject.
 // Registers the struct in the registry and returns a $ref.
 func structToSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
@@ -94,7 +173,7 @@ func structToSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
 		t = t.Elem()
 	}
 
-	name := schemaName(t)
+	name := registry.nameFor(t)
 
 	// If already registered, return a $ref.
 	if registry.Has(name) {
@@ -102,13 +181,26 @@ func structToSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
 	}
 
 	// Check for circular references.
-	if registry.isSeen(t) {
+	if i := registry.stackIndex(t); i >= 0 {
+		if registry.cyclePolicy == CycleTruncate {
+			registry.warn(fmt.Sprintf("recursive type %s truncated at %s", t, name))
+			registry.truncate(i)
+			return &SchemaObject{Type: "object", Description: "Recursive reference to " + name + " (truncated)."}
+		}
+		registry.reference(i)
 		return SchemaRef(name)
 	}
 
+	// Stop descending into pathologically deep model graphs.
+	if registry.maxDepth > 0 && registry.depth() >= registry.maxDepth {
+		registry.warn(fmt.Sprintf("schema depth limit %d reached at %s; documented as a generic object", registry.maxDepth, name))
+		registry.truncate(0)
+		return &SchemaObject{Type: "object", Description: "Nested " + name + " omitted (maximum schema depth reached)."}
+	}
+
 	// Mark as being processed.
-	registry.markSeen(t)
-	defer registry.unmarkSeen(t)
+	index := registry.push(t)
+	defer registry.pop()
 
 	schema := &SchemaObject{
 		Type:       "object",
@@ -116,7 +208,21 @@ func structToSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
 	}
 
 	// Process all fields including embedded structs.
-	processStructFields(t, schema, registry)
+	processStructFields(t, name, schema, registry)
+
+	// The type's doc comment describes the schema.
+	if registry.modelComments {
+		schema.Description, _ = typeComments(registry.sourceRoot, t)
+	}
+
+	// Object-level metadata from optional interfaces.
+	applyDocInterfaces(t, schema)
+
+	// A schema truncated relative to where it was reached is inlined, so
+	// the type stays complete where it's reached at the top.
+	if !registry.settle(index) {
+		return schema
+	}
 
 	// Register the schema.
 	registry.Register(name, schema)
@@ -124,8 +230,29 @@ func structToSchema(t reflect.Type, registry *TypeRegistry) *SchemaObject {
 	return SchemaRef(name)
 }
 
-// processStructFields processes struct fields, handling embedded structs recursively.
-func processStructFields(t reflect.Type, schema *SchemaObject, registry *TypeRegistry) {
+// applyDocInterfaces sets the title, description, and example of a struct
+// schema from DocTitler, DocDescriber, and DocExampler implementations.
+func applyDocInterfaces(t reflect.Type, schema *SchemaObject) {
+	v := reflect.New(t).Interface()
+	if titler, ok := v.(DocTitler); ok {
+		schema.Title = titler.DocTitle()
+	}
+	if describer, ok := v.(DocDescriber); ok {
+		schema.Description = describer.DocDescription()
+	}
+	if exampler, ok := v.(DocExampler); ok {
+		schema.Example = exampler.DocExample()
+	}
+}
+
+// processStructFields processes struct fields, handling embedded structs
+// recursively. Anonymous struct fields are named after owner and the field.
+func processStructFields(t reflect.Type, owner string, schema *SchemaObject, registry *TypeRegistry) {
+	var comments map[string]string
+	if registry.modelComments {
+		_, comments = typeComments(registry.sourceRoot, t)
+	}
+
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 
@@ -143,19 +270,17 @@ func processStructFields(t reflect.Type, schema *SchemaObject, registry *TypeReg
 			if embeddedType.Kind() == reflect.Struct {
 				// Check if it's a special type (like time.Time).
 				if specialTypeSchema(embeddedType) == nil {
-					processStructFields(embeddedType, schema, registry)
+					processStructFields(embeddedType, owner, schema, registry)
 					continue
 				}
 			}
 		}
 
 		// Parse all tags.
-		tagInfo := mergeTags(
-			field.Tag.Get("json"),
-			field.Tag.Get("binding"),
-			field.Tag.Get("gorm"),
-			field.Tag.Get("docs"),
-		)
+		tagInfo := registry.fieldTags(field)
+		if tagInfo.Description == "" {
+			tagInfo.Description = comments[field.Name]
+		}
 
 		// Skip hidden or skipped fields.
 		if tagInfo.JSONSkip || tagInfo.GORMSkip || tagInfo.Hidden {
@@ -169,7 +294,9 @@ func processStructFields(t reflect.Type, schema *SchemaObject, registry *TypeReg
 		}
 
 		// Generate schema for the field type.
-		fieldSchema := fieldToSchema(field.Type, tagInfo, registry)
+		fieldSchema := registry.withHint(owner+capitalize(field.Name), func() *SchemaObject {
+			return fieldToSchema(field.Type, tagInfo, registry)
+		})
 
 		schema.Properties[propName] = fieldSchema
 
@@ -182,12 +309,22 @@ func processStructFields(t reflect.Type, schema *SchemaObject, registry *TypeReg
 
 // fieldToSchema generates a schema for a struct field, applying tag constraints.
 func fieldToSchema(t reflect.Type, tags TagInfo, registry *TypeRegistry) *SchemaObject {
+	nullable := isNullableType(t) && !registry.disableNullable
+
 	// Get the base schema from the type.
 	baseSchema := typeToSchema(t, registry)
 
 	// If it's a $ref, we can't add constraints directly.
 	// We need to use the base schema as-is.
 	if baseSchema.Ref != "" {
+		// A nullable $ref becomes anyOf the ref and null.
+		if nullable {
+			return &SchemaObject{
+				AnyOf:       []*SchemaObject{baseSchema, {Type: "null"}},
+				Description: tags.Description,
+				Deprecated:  tags.Deprecated,
+			}
+		}
 		// Apply description via wrapper if needed.
 		if tags.Description != "" || tags.Deprecated {
 			return &SchemaObject{
@@ -200,13 +337,55 @@ func fieldToSchema(t reflect.Type, tags TagInfo, registry *TypeRegistry) *Schema
 	}
 
 	// Apply tag constraints to the schema.
-	applyTagConstraints(baseSchema, tags)
+	applyTagConstraints(baseSchema, tags, derefType(t).Kind())
+
+	// Rules after "dive" apply to the elements of a slice.
+	if tags.Items != nil && baseSchema.Items != nil && baseSchema.Items.Ref == "" {
+		applyTagConstraints(baseSchema.Items, *tags.Items, derefType(derefType(t).Elem()).Kind())
+	}
+
+	if nullable {
+		baseSchema.Nullable = true
+	}
 
 	return baseSchema
 }
 
+// isNullableType reports whether a field type can hold a JSON null.
+func isNullableType(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr || sqlNullValueType(t) != nil
+}
+
+// sqlNullValueType returns the wrapped value type of a database/sql Null
+// struct (sql.NullString, sql.NullInt64, sql.Null[T], ...), or nil.
+func sqlNullValueType(t reflect.Type) reflect.Type {
+	if t.Kind() != reflect.Struct || t.PkgPath() != "database/sql" ||
+		!strings.HasPrefix(t.Name(), "Null") || t.NumField() != 2 {
+		return nil
+	}
+	if valid, ok := t.FieldByName("Valid"); !ok || valid.Type.Kind() != reflect.Bool {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Name != "Valid" {
+			return f.Type
+		}
+	}
+	return nil
+}
+
+// derefType strips all pointer indirections from a type.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
 // applyTagConstraints applies parsed tag information to a schema.
-func applyTagConstraints(schema *SchemaObject, tags TagInfo) {
+// kind is the field's Go kind, which decides whether min/max rules
+// constrain length, item count, or numeric range.
+func applyTagConstraints(schema *SchemaObject, tags TagInfo, kind reflect.Kind) {
 	// Description.
 	if tags.Description != "" {
 		schema.Description = tags.Description
@@ -221,11 +400,26 @@ func applyTagConstraints(schema *SchemaObject, tags TagInfo) {
 		}
 	}
 
+	// Rules without a schema keyword are described in prose.
+	if len(tags.Notes) > 0 {
+		notes := strings.Join(tags.Notes, ". ")
+		if schema.Description != "" {
+			schema.Description += ". " + notes
+		} else {
+			schema.Description = notes
+		}
+	}
+
 	// Format.
 	if tags.Format != "" {
 		schema.Format = tags.Format
 	}
 
+	// Pattern.
+	if tags.Pattern != "" && schema.Type == "string" {
+		schema.Pattern = tags.Pattern
+	}
+
 	// Enum.
 	if len(tags.Enum) > 0 {
 		for _, v := range tags.Enum {
@@ -233,14 +427,19 @@ func applyTagConstraints(schema *SchemaObject, tags TagInfo) {
 		}
 	}
 
-	// Numeric constraints — only apply to number/integer types.
-	if schema.Type == "integer" || schema.Type == "number" {
+	// Range and length constraints follow the Go kind, falling back to the
+	// schema type for kinds that don't decide it (e.g., custom marshalers).
+	switch constraintKind(kind, schema.Type) {
+	case "number":
 		schema.Minimum = tags.Minimum
 		schema.Maximum = tags.Maximum
-	}
+		if tags.MultipleOf != nil {
+			schema.MultipleOf = tags.MultipleOf
+		}
+		schema.ExclusiveMinimum = tags.ExclusiveMinimum
+		schema.ExclusiveMaximum = tags.ExclusiveMaximum
 
-	// String constraints — only apply to string types.
-	if schema.Type == "string" {
+	case "string":
 		schema.MinLength = tags.MinLength
 		schema.MaxLength = tags.MaxLength
 
@@ -248,18 +447,35 @@ func applyTagConstraints(schema *SchemaObject, tags TagInfo) {
 		if tags.GORMSize != nil && schema.MaxLength == nil {
 			schema.MaxLength = tags.GORMSize
 		}
+
+	case "array":
+		schema.MinItems = tags.MinLength
+		schema.MaxItems = tags.MaxLength
 	}
 
-	// Default value.
+	// Default value; the docs tag wins over GORM.
 	if tags.GORMDefault != nil {
 		schema.Default = parseDefaultValue(*tags.GORMDefault, schema.Type)
 	}
+	if tags.DocsDefault != nil {
+		schema.Default = parseDefaultValue(*tags.DocsDefault, schema.Type)
+	}
+
+	// Title.
+	if tags.DocsTitle != "" {
+		schema.Title = tags.DocsTitle
+	}
 
 	// ReadOnly for primary keys and auto-timestamps.
 	if tags.PrimaryKey || tags.AutoCreateTime || tags.AutoUpdateTime {
 		schema.ReadOnly = true
 	}
 
+	// WriteOnly for secrets that are accepted but never returned.
+	if tags.WriteOnly {
+		schema.WriteOnly = true
+	}
+
 	// Deprecated.
 	if tags.Deprecated {
 		schema.Deprecated = true
@@ -271,6 +487,38 @@ func applyTagConstraints(schema *SchemaObject, tags TagInfo) {
 	}
 }
 
+// constraintKind classifies a field as "number", "string", or "array" for the
+// purpose of applying min/max rules. The Go kind decides; the schema type only
+// has to agree with it. Kinds that don't imply a shape (structs with custom
+// marshalers, interfaces) defer to the schema type.
+func constraintKind(kind reflect.Kind, schemaType string) string {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if schemaType == "integer" || schemaType == "number" {
+			return "number"
+		}
+	case reflect.String:
+		if schemaType == "string" {
+			return "string"
+		}
+	case reflect.Slice, reflect.Array:
+		// []byte is documented as a base64 string.
+		if schemaType == "array" || schemaType == "string" {
+			return schemaType
+		}
+	default:
+		switch schemaType {
+		case "integer", "number":
+			return "number"
+		case "string", "array":
+			return schemaType
+		}
+	}
+	return ""
+}
+
 // parseDefaultValue converts a string default to the appropriate Go type.
 func parseDefaultValue(val, schemaType string) interface{} {
 	switch schemaType {
@@ -1,6 +1,8 @@
 package gindocs
 
 import (
+	"database/sql"
+	"encoding/json"
 	"reflect"
 	"testing"
 	"time"
@@ -25,6 +27,9 @@ func TestTypeToSchema_Primitives(t *testing.T) {
 		{"float64", reflect.TypeOf(float64(0)), "number", "double"},
 		{"string", reflect.TypeOf(""), "string", ""},
 		{"time.Time", reflect.TypeOf(time.Time{}), "string", "date-time"},
+		{"time.Duration", reflect.TypeOf(time.Duration(0)), "integer", "int64"},
+		{"json.Number", reflect.TypeOf(json.Number("")), "number", ""},
+		{"json.RawMessage", reflect.TypeOf(json.RawMessage{}), "object", ""},
 	}
 
 	for _, tt := range tests {
@@ -337,6 +342,14 @@ func TestParseDocsTag(t *testing.T) {
 		{"hidden", func(i TagInfo) bool { return i.Hidden }, "should be hidden"},
 		{"format:uri", func(i TagInfo) bool { return i.DocsFormat == "uri" }, "should have format"},
 		{"enum:a|b|c", func(i TagInfo) bool { return len(i.DocsEnum) == 3 }, "should have enum"},
+		{"description:'Price, in USD',hidden", func(i TagInfo) bool { return i.Description == "Price, in USD" && i.Hidden }, "should honor quotes"},
+		{`description:Time\, ISO: 8601`, func(i TagInfo) bool { return i.Description == "Time, ISO: 8601" }, "should honor escaped commas"},
+		{"pattern:^[a-z]+$", func(i TagInfo) bool { return i.Pattern == "^[a-z]+$" }, "should have pattern"},
+		{"minimum:0,maximum:9.5", func(i TagInfo) bool { return *i.DocsMinimum == 0 && *i.DocsMaximum == 9.5 }, "should have bounds"},
+		{"default:10,multipleOf:5", func(i TagInfo) bool { return *i.DocsDefault == "10" && *i.MultipleOf == 5 }, "should have default and multipleOf"},
+		{"title:Amount", func(i TagInfo) bool { return i.DocsTitle == "Amount" }, "should have title"},
+		{"description:User's name,example:Ada", func(i TagInfo) bool { return i.Description == "User's name" && i.Example == "Ada" }, "should keep apostrophes literal"},
+		{`pattern:^\d{3}$`, func(i TagInfo) bool { return i.Pattern == `^\d{3}$` }, "should keep backslashes"},
 	}
 
 	for _, tt := range tests {
@@ -382,3 +395,517 @@ func TestTypeToSchema_CircularRef(t *testing.T) {
 		t.Errorf("Children items ref = %q, want %q", children.Items.Ref, "#/components/schemas/TestNode")
 	}
 }
+
+type TestStatus string
+
+func (TestStatus) DocSchema() *SchemaObject {
+	return &SchemaObject{Type: "string", Enum: []interface{}{"draft", "published"}}
+}
+
+type TestProvidedStruct struct {
+	Status  TestStatus  `json:"status" docs:"description:Publication state"`
+	Pointer *TestStatus `json:"pointer"`
+}
+
+func TestTypeToSchema_SchemaProvider(t *testing.T) {
+	registry := newTypeRegistry()
+
+	schema := typeToSchema(reflect.TypeOf(TestStatus("")), registry)
+	if schema.Type != "string" || len(schema.Enum) != 2 {
+		t.Errorf("schema = %+v, want provided string enum", schema)
+	}
+
+	typeToSchema(reflect.TypeOf(TestProvidedStruct{}), registry)
+	registered, ok := registry.Get("TestProvidedStruct")
+	if !ok {
+		t.Fatal("TestProvidedStruct should be registered")
+	}
+	if got := registered.Properties["status"].Description; got != "Publication state" {
+		t.Errorf("status description = %q, want %q", got, "Publication state")
+	}
+	if len(registered.Properties["pointer"].Enum) != 2 {
+		t.Error("pointer fields should use the provided schema")
+	}
+}
+
+type TestNullable struct {
+	Nickname    *string    `json:"nickname"`
+	PublishedAt *time.Time `json:"published_at"`
+	Parent      *TestUser  `json:"parent"`
+	Name        string     `json:"name"`
+}
+
+func TestTypeToSchema_NullablePointers(t *testing.T) {
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(TestNullable{}), registry)
+
+	schema, _ := registry.Get("TestNullable")
+	if !schema.Properties["nickname"].Nullable {
+		t.Error("*string should be nullable")
+	}
+	if !schema.Properties["published_at"].Nullable {
+		t.Error("*time.Time should be nullable")
+	}
+	if schema.Properties["name"].Nullable {
+		t.Error("string should not be nullable")
+	}
+
+	parent := schema.Properties["parent"]
+	if len(parent.AnyOf) != 2 || parent.AnyOf[0].Ref == "" || parent.AnyOf[1].Type != "null" {
+		t.Errorf("*TestUser should be anyOf [$ref, null], got %+v", parent)
+	}
+
+	data, err := json.Marshal(schema.Properties["nickname"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"type":["string","null"]}` {
+		t.Errorf("JSON = %s, want 3.1 type array", data)
+	}
+
+	var decoded SchemaObject
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Type != "string" || !decoded.Nullable {
+		t.Errorf("decoded = %+v, want nullable string", decoded)
+	}
+}
+
+func TestTypeToSchema_NullableDisabled(t *testing.T) {
+	registry := newTypeRegistry()
+	registry.disableNullable = true
+	typeToSchema(reflect.TypeOf(TestNullable{}), registry)
+
+	schema, _ := registry.Get("TestNullable")
+	if schema.Properties["nickname"].Nullable {
+		t.Error("nullable should be disabled")
+	}
+	if schema.Properties["parent"].Ref == "" {
+		t.Error("pointer struct should be a plain $ref when nullable is disabled")
+	}
+}
+
+type TestValidateTag struct {
+	Name  string `json:"name" validate:"required,min=2"`
+	Email string `json:"email" binding:"required" validate:"email"`
+	Role  string `json:"role" binding:"oneof=admin user" validate:"oneof=a b c"`
+}
+
+type TestSQLNull struct {
+	Nickname  sql.NullString `json:"nickname"`
+	Count     sql.NullInt64  `json:"count"`
+	DeletedAt sql.NullTime   `json:"deleted_at"`
+}
+
+func TestTypeToSchema_SQLNull(t *testing.T) {
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(TestSQLNull{}), registry)
+
+	schema, _ := registry.Get("TestSQLNull")
+	tests := []struct {
+		prop       string
+		wantType   string
+		wantFormat string
+	}{
+		{"nickname", "string", ""},
+		{"count", "integer", "int64"},
+		{"deleted_at", "string", "date-time"},
+	}
+	for _, tt := range tests {
+		prop := schema.Properties[tt.prop]
+		if prop.Type != tt.wantType || prop.Format != tt.wantFormat || !prop.Nullable {
+			t.Errorf("%s = %+v, want nullable %s (%s)", tt.prop, prop, tt.wantType, tt.wantFormat)
+		}
+	}
+
+	// Configured mappings override the built-in handling.
+	registry = newTypeRegistry()
+	registry.typeSchemas = map[reflect.Type]*SchemaObject{
+		reflect.TypeOf(sql.NullTime{}): {Type: "string", Format: "date"},
+	}
+	typeToSchema(reflect.TypeOf(TestSQLNull{}), registry)
+
+	schema, _ = registry.Get("TestSQLNull")
+	if prop := schema.Properties["deleted_at"]; prop.Format != "date" || !prop.Nullable {
+		t.Errorf("deleted_at = %+v, want nullable date", prop)
+	}
+}
+
+func TestTypeToSchema_ValidateTag(t *testing.T) {
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(TestValidateTag{}), registry)
+
+	schema, _ := registry.Get("TestValidateTag")
+	if len(schema.Required) != 2 {
+		t.Errorf("Required = %v, want name and email", schema.Required)
+	}
+	if schema.Properties["email"].Format != "email" {
+		t.Error("validate:\"email\" should set format")
+	}
+	if len(schema.Properties["role"].Enum) != 2 {
+		t.Errorf("binding should win by default, got enum %v", schema.Properties["role"].Enum)
+	}
+
+	registry = newTypeRegistry()
+	registry.preferValidate = true
+	typeToSchema(reflect.TypeOf(TestValidateTag{}), registry)
+
+	schema, _ = registry.Get("TestValidateTag")
+	if len(schema.Properties["role"].Enum) != 3 {
+		t.Errorf("validate should win when preferred, got enum %v", schema.Properties["role"].Enum)
+	}
+}
+
+type TestValidateDive struct {
+	Emails []string `json:"emails" binding:"required" validate:"min=1,dive,email"`
+}
+
+func TestTypeToSchema_ValidateTagDive(t *testing.T) {
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(TestValidateDive{}), registry)
+
+	schema, _ := registry.Get("TestValidateDive")
+	if len(schema.Required) != 1 || schema.Required[0] != "emails" {
+		t.Errorf("Required = %v, want the binding tag's required to survive validate's dive", schema.Required)
+	}
+	emails := schema.Properties["emails"]
+	if emails.MinItems == nil || *emails.MinItems != 1 || emails.Items == nil || emails.Items.Format != "email" {
+		t.Errorf("emails = %+v, want minItems 1 and email items", emails)
+	}
+}
+
+type TestMinMax struct {
+	Name     string   `json:"name" binding:"min=2,max=100"`
+	Quantity int      `json:"quantity" binding:"min=1,max=100"`
+	Price    float64  `json:"price" binding:"min=0.5,max=99.5"`
+	Labels   []string `json:"labels" binding:"min=1,max=5"`
+	Score    int      `json:"score" binding:"gt=0,lt=10"`
+}
+
+func TestTypeToSchema_MinMaxByKind(t *testing.T) {
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(TestMinMax{}), registry)
+	schema, _ := registry.Get("TestMinMax")
+
+	name := schema.Properties["name"]
+	if name.MinLength == nil || *name.MinLength != 2 || name.MaxLength == nil || *name.MaxLength != 100 {
+		t.Errorf("string min/max should set length, got %v/%v", name.MinLength, name.MaxLength)
+	}
+	if name.Minimum != nil || name.Maximum != nil {
+		t.Error("string min/max should not set numeric range")
+	}
+
+	quantity := schema.Properties["quantity"]
+	if quantity.Minimum == nil || *quantity.Minimum != 1 || quantity.Maximum == nil || *quantity.Maximum != 100 {
+		t.Errorf("int min/max should set range, got %v/%v", quantity.Minimum, quantity.Maximum)
+	}
+	if quantity.MinLength != nil || quantity.MaxLength != nil {
+		t.Error("int min/max should not set length")
+	}
+
+	price := schema.Properties["price"]
+	if price.Minimum == nil || *price.Minimum != 0.5 || price.Maximum == nil || *price.Maximum != 99.5 {
+		t.Errorf("float min/max should set fractional range, got %v/%v", price.Minimum, price.Maximum)
+	}
+
+	labels := schema.Properties["labels"]
+	if labels.MinItems == nil || *labels.MinItems != 1 || labels.MaxItems == nil || *labels.MaxItems != 5 {
+		t.Errorf("slice min/max should set item count, got %v/%v", labels.MinItems, labels.MaxItems)
+	}
+
+	score := schema.Properties["score"]
+	if score.ExclusiveMinimum == nil || *score.ExclusiveMinimum != 0 || score.ExclusiveMaximum == nil || *score.ExclusiveMaximum != 10 {
+		t.Errorf("gt/lt should set exclusive bounds, got %v/%v", score.ExclusiveMinimum, score.ExclusiveMaximum)
+	}
+}
+
+type TestValidators struct {
+	Code     string   `json:"code" binding:"alphanum,len=6"`
+	Digits   string   `json:"digits" binding:"numeric"`
+	Emails   []string `json:"emails" binding:"min=1,max=3,dive,email,max=50"`
+	Company  string   `json:"company" binding:"required_if=Type business"`
+	Confirm  string   `json:"confirm" binding:"eqfield=Password"`
+	Password string   `json:"password"`
+	Type     string   `json:"type"`
+}
+
+func TestTypeToSchema_ExpandedValidators(t *testing.T) {
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(TestValidators{}), registry)
+	schema, _ := registry.Get("TestValidators")
+
+	code := schema.Properties["code"]
+	if code.Pattern != "^[a-zA-Z0-9]+$" {
+		t.Errorf("alphanum pattern = %q", code.Pattern)
+	}
+	if code.MinLength == nil || *code.MinLength != 6 || code.MaxLength == nil || *code.MaxLength != 6 {
+		t.Error("len=6 should set minLength and maxLength")
+	}
+	if schema.Properties["digits"].Pattern == "" {
+		t.Error("numeric should set a pattern")
+	}
+
+	emails := schema.Properties["emails"]
+	if emails.MinItems == nil || *emails.MinItems != 1 || emails.MaxItems == nil || *emails.MaxItems != 3 {
+		t.Error("rules before dive should set item count")
+	}
+	if emails.Items.Format != "email" || emails.Items.MaxLength == nil || *emails.Items.MaxLength != 50 {
+		t.Errorf("rules after dive should constrain items, got %+v", emails.Items)
+	}
+	if emails.MaxLength != nil || emails.Format != "" {
+		t.Error("rules after dive should not apply to the slice itself")
+	}
+
+	if got := schema.Properties["company"].Description; got != "Required when Type is business" {
+		t.Errorf("required_if description = %q", got)
+	}
+	if got := schema.Properties["confirm"].Description; got != "Must equal Password" {
+		t.Errorf("eqfield description = %q", got)
+	}
+	for _, r := range schema.Required {
+		if r == "company" {
+			t.Error("required_if should not make the field unconditionally required")
+		}
+	}
+}
+
+type TestEnumStatus string
+
+type TestEnumModel struct {
+	Status   TestEnumStatus  `json:"status"`
+	Previous *TestEnumStatus `json:"previous"`
+}
+
+func TestTypeToSchema_RegisterEnum(t *testing.T) {
+	RegisterEnum(TestEnumStatus("draft"), TestEnumStatus("published"))
+
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(TestEnumModel{}), registry)
+
+	enum, ok := registry.Get("TestEnumStatus")
+	if !ok {
+		t.Fatal("enum component should be registered")
+	}
+	if enum.Type != "string" || len(enum.Enum) != 2 || enum.Enum[0] != "draft" {
+		t.Errorf("enum = %+v, want string enum [draft published]", enum)
+	}
+
+	model, _ := registry.Get("TestEnumModel")
+	if model.Properties["status"].Ref != RefPath("TestEnumStatus") {
+		t.Errorf("status = %+v, want $ref to enum", model.Properties["status"])
+	}
+}
+
+type TestAccount struct {
+	Email      string `json:"email"`
+	Password   string `json:"password" binding:"required"`
+	ResetToken string `json:"reset_token"`
+	PIN        string `json:"pin" docs:"writeonly"`
+}
+
+func TestTypeToSchema_WriteOnly(t *testing.T) {
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(TestAccount{}), registry)
+
+	schema, _ := registry.Get("TestAccount")
+	if !schema.Properties["pin"].WriteOnly || schema.Properties["password"].WriteOnly {
+		t.Error("only docs:\"writeonly\" should apply outside request bodies")
+	}
+	markSensitive(schema, []string{"password", "secret", "token"})
+	for _, prop := range []string{"password", "reset_token", "pin"} {
+		if !schema.Properties[prop].WriteOnly {
+			t.Errorf("%s should be writeOnly", prop)
+		}
+	}
+	if schema.Properties["email"].WriteOnly {
+		t.Error("email should not be writeOnly")
+	}
+
+	stripWriteOnly(schema)
+	if _, ok := schema.Properties["password"]; ok {
+		t.Error("password should be stripped from the response schema")
+	}
+	if len(schema.Required) != 0 {
+		t.Errorf("Required = %v, want stripped", schema.Required)
+	}
+}
+
+func TestSplitSchemaViews(t *testing.T) {
+	spec := &OpenAPISpec{
+		Paths: map[string]*PathItem{
+			"/accounts": {
+				Post: &OperationObject{
+					RequestBody: &RequestBodyObject{Content: map[string]MediaType{
+						"application/json": {Schema: SchemaRef("Account")},
+					}},
+					Responses: map[string]*Response{"201": {Content: map[string]MediaType{
+						"application/json": {Schema: &SchemaObject{Type: "array", Items: SchemaRef("Account")}},
+					}}},
+				},
+			},
+		},
+		Components: &ComponentsObject{Schemas: map[string]*SchemaObject{
+			"Account": {
+				Type:     "object",
+				Required: []string{"id", "password"},
+				Properties: map[string]*SchemaObject{
+					"id":       {Type: "integer", ReadOnly: true},
+					"password": {Type: "string", WriteOnly: true},
+					"email":    {Type: "string"},
+				},
+			},
+		}},
+	}
+
+	splitSchemaViews(spec)
+
+	req := spec.Components.Schemas["AccountRequest"]
+	if _, ok := req.Properties["id"]; ok || req.Properties["password"] == nil {
+		t.Errorf("AccountRequest properties = %v, want password but not id", req.Properties)
+	}
+	resp := spec.Components.Schemas["AccountResponse"]
+	if _, ok := resp.Properties["password"]; ok || resp.Properties["id"] == nil {
+		t.Errorf("AccountResponse properties = %v, want id but not password", resp.Properties)
+	}
+	if len(resp.Required) != 1 || resp.Required[0] != "id" {
+		t.Errorf("AccountResponse required = %v, want [id]", resp.Required)
+	}
+
+	op := spec.Paths["/accounts"].Post
+	if got := op.RequestBody.Content["application/json"].Schema.Ref; got != RefPath("AccountRequest") {
+		t.Errorf("request body ref = %q, want AccountRequest", got)
+	}
+	if got := op.Responses["201"].Content["application/json"].Schema.Items.Ref; got != RefPath("AccountResponse") {
+		t.Errorf("response items ref = %q, want AccountResponse", got)
+	}
+}
+
+type TestDescribed struct {
+	Name string `json:"name"`
+}
+
+func (TestDescribed) DocTitle() string       { return "Described" }
+func (TestDescribed) DocDescription() string { return "A described model" }
+func (TestDescribed) DocExample() interface{} {
+	return TestDescribed{Name: "example"}
+}
+
+func TestTypeToSchema_DocInterfaces(t *testing.T) {
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(TestDescribed{}), registry)
+
+	schema, _ := registry.Get("TestDescribed")
+	if schema.Title != "Described" || schema.Description != "A described model" {
+		t.Errorf("title/description = %q/%q", schema.Title, schema.Description)
+	}
+	if ex, ok := schema.Example.(TestDescribed); !ok || ex.Name != "example" {
+		t.Errorf("Example = %+v, want TestDescribed{Name: example}", schema.Example)
+	}
+}
+
+func TestTypeRegistry_SchemaHook(t *testing.T) {
+	registry := newTypeRegistry()
+	var names []string
+	registry.schemaHook = func(name string, s *SchemaObject) {
+		names = append(names, name)
+		delete(s.Properties, "name")
+	}
+	typeToSchema(reflect.TypeOf(TestUser{}), registry)
+
+	schema, _ := registry.Get("TestUser")
+	if len(names) != 1 || names[0] != "TestUser" {
+		t.Errorf("hook called for %v, want [TestUser]", names)
+	}
+	if _, ok := schema.Properties["name"]; ok {
+		t.Error("hook changes should be kept")
+	}
+}
+
+type depthLevel3 struct {
+	Value string `json:"value"`
+}
+
+type depthLevel2 struct {
+	Next depthLevel3 `json:"next"`
+}
+
+type depthLevel1 struct {
+	Next depthLevel2 `json:"next"`
+}
+
+type cycleTeam struct {
+	Members []cycleMember `json:"members"`
+}
+
+type cycleMember struct {
+	Teams []cycleTeam `json:"teams"`
+}
+
+type cycleNode struct {
+	Name     string       `json:"name"`
+	Children []*cycleNode `json:"children"`
+}
+
+func TestTypeToSchema_MaxDepth(t *testing.T) {
+	registry := newTypeRegistry()
+	registry.maxDepth = 2
+
+	typeToSchema(reflect.TypeOf(depthLevel1{}), registry)
+
+	// depthLevel2 is truncated only because it was reached one level down,
+	// so it is inlined rather than registered.
+	level1, _ := registry.Get("depthLevel1")
+	next := level1.Properties["next"]
+	if next.Ref != "" || next.Properties["next"] == nil || next.Properties["next"].Type != "object" || next.Properties["next"].Ref != "" {
+		t.Fatalf("expected depthLevel1.next inline with a plain object below, got %+v", next)
+	}
+	if registry.Has("depthLevel2") || registry.Has("depthLevel3") {
+		t.Error("expected the truncated types not to be registered")
+	}
+	if len(registry.warnings) != 1 {
+		t.Errorf("warnings = %v", registry.warnings)
+	}
+
+	// Reached at the top, depthLevel2 is documented in full.
+	typeToSchema(reflect.TypeOf(depthLevel2{}), registry)
+	level2, _ := registry.Get("depthLevel2")
+	if level2 == nil || level2.Properties["next"].Ref != RefPath("depthLevel3") {
+		t.Errorf("expected depthLevel2.next to reference depthLevel3, got %+v", level2)
+	}
+}
+
+func TestTypeToSchema_CyclePolicy(t *testing.T) {
+	registry := newTypeRegistry()
+	typeToSchema(reflect.TypeOf(cycleNode{}), registry)
+	node, _ := registry.Get("cycleNode")
+	if ref := node.Properties["children"].Items.Ref; ref != RefPath("cycleNode") {
+		t.Errorf("CycleRef: children items = %q", ref)
+	}
+
+	registry = newTypeRegistry()
+	registry.cyclePolicy = CycleTruncate
+	typeToSchema(reflect.TypeOf(cycleNode{}), registry)
+	node, _ = registry.Get("cycleNode")
+	if items := node.Properties["children"].Items; items.Ref != "" || items.Type != "object" {
+		t.Errorf("CycleTruncate: children items = %+v", items)
+	}
+	if len(registry.warnings) != 1 {
+		t.Errorf("warnings = %v", registry.warnings)
+	}
+
+	// Truncating a cycle through another type keeps each type complete where
+	// it is reached at the top.
+	registry = newTypeRegistry()
+	registry.cyclePolicy = CycleTruncate
+	typeToSchema(reflect.TypeOf(cycleTeam{}), registry)
+	typeToSchema(reflect.TypeOf(cycleMember{}), registry)
+	member, _ := registry.Get("cycleMember")
+	if member == nil || member.Properties["teams"].Items.Ref != RefPath("cycleTeam") {
+		t.Errorf("expected cycleMember.teams to reference cycleTeam, got %+v", member)
+	}
+	team, _ := registry.Get("cycleTeam")
+	if lead := team.Properties["members"].Items; lead.Ref != "" || lead.Properties["teams"].Items.Ref != "" {
+		t.Errorf("expected cycleTeam.members inline with teams truncated, got %+v", lead)
+	}
+}
@@ -0,0 +1,140 @@
+package gindocs
+
+import "strings"
+
+// splitSchemaViews adds <Name>Request and <Name>Response component schemas for
+// every schema with readOnly or writeOnly properties (or that references one),
+// and points request bodies and responses at the matching view.
+func splitSchemaViews(spec *OpenAPISpec) {
+	if spec.Components == nil || len(spec.Components.Schemas) == 0 {
+		return
+	}
+	schemas := spec.Components.Schemas
+
+	// Find schemas that need views, including those that reference one.
+	split := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for name, schema := range schemas {
+			if !split[name] && (hasDirectionalProps(schema) || referencesAny(schema, split)) {
+				split[name] = true
+				changed = true
+			}
+		}
+	}
+
+	for name := range split {
+		schemas[name+"Request"] = schemaView(schemas[name], "Request", split, func(s *SchemaObject) bool { return s.ReadOnly })
+		schemas[name+"Response"] = schemaView(schemas[name], "Response", split, func(s *SchemaObject) bool { return s.WriteOnly })
+	}
+
+	for _, pathItem := range spec.Paths {
+		for _, method := range httpMethods {
+			op := pathItem.GetOperation(method)
+			if op == nil {
+				continue
+			}
+			if op.RequestBody != nil {
+				for mediaType, media := range op.RequestBody.Content {
+					media.Schema = schemaView(media.Schema, "Request", split, nil)
+					op.RequestBody.Content[mediaType] = media
+				}
+			}
+			for _, resp := range op.Responses {
+				for mediaType, media := range resp.Content {
+					media.Schema = schemaView(media.Schema, "Response", split, nil)
+					resp.Content[mediaType] = media
+				}
+			}
+		}
+	}
+}
+
+// hasDirectionalProps reports whether a schema has readOnly or writeOnly properties.
+func hasDirectionalProps(schema *SchemaObject) bool {
+	for _, prop := range schema.Properties {
+		if prop.ReadOnly || prop.WriteOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// referencesAny reports whether a schema references any of the named components.
+func referencesAny(schema *SchemaObject, names map[string]bool) bool {
+	if schema == nil {
+		return false
+	}
+	if schema.Ref != "" {
+		return names[strings.TrimPrefix(schema.Ref, RefPath(""))]
+	}
+	for _, child := range schemaChildren(schema) {
+		if referencesAny(child, names) {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaChildren lists the direct subschemas of a schema.
+func schemaChildren(schema *SchemaObject) []*SchemaObject {
+	var children []*SchemaObject
+	for _, prop := range schema.Properties {
+		children = append(children, prop)
+	}
+	children = append(children, schema.Items, schema.AdditionalProperties)
+	children = append(children, schema.AllOf...)
+	children = append(children, schema.OneOf...)
+	children = append(children, schema.AnyOf...)
+	return children
+}
+
+// schemaView returns a copy of schema with $refs to split components renamed
+// with suffix. Properties for which drop returns true are removed at the top level.
+func schemaView(schema *SchemaObject, suffix string, split map[string]bool, drop func(*SchemaObject) bool) *SchemaObject {
+	if schema == nil {
+		return nil
+	}
+
+	view := *schema
+	if name := strings.TrimPrefix(schema.Ref, RefPath("")); schema.Ref != "" && split[name] {
+		view.Ref = RefPath(name + suffix)
+		return &view
+	}
+
+	if schema.Properties != nil {
+		view.Properties = make(map[string]*SchemaObject, len(schema.Properties))
+		view.Required = nil
+		for name, prop := range schema.Properties {
+			if drop != nil && drop(prop) {
+				continue
+			}
+			view.Properties[name] = schemaView(prop, suffix, split, nil)
+		}
+		for _, r := range schema.Required {
+			if _, ok := view.Properties[r]; ok {
+				view.Required = append(view.Required, r)
+			}
+		}
+	}
+
+	view.Items = schemaView(schema.Items, suffix, split, nil)
+	view.AdditionalProperties = schemaView(schema.AdditionalProperties, suffix, split, nil)
+	view.AllOf = schemaViews(schema.AllOf, suffix, split)
+	view.OneOf = schemaViews(schema.OneOf, suffix, split)
+	view.AnyOf = schemaViews(schema.AnyOf, suffix, split)
+
+	return &view
+}
+
+// schemaViews applies schemaView to each schema in a list.
+func schemaViews(schemas []*SchemaObject, suffix string, split map[string]bool) []*SchemaObject {
+	if schemas == nil {
+		return nil
+	}
+	views := make([]*SchemaObject, len(schemas))
+	for i, s := range schemas {
+		views[i] = schemaView(s, suffix, split, nil)
+	}
+	return views
+}
@@ -0,0 +1,34 @@
+package gindocs
+
+import "fmt"
+
+// describeTagSections appends the custom sections attached to a tag to that
+// tag's description, titled with a heading. Sections naming a tag no
+// operation uses are returned as warnings.
+func (gd *GinDocs) describeTagSections(spec *OpenAPISpec) []string {
+	var warnings []string
+	for _, section := range gd.config.CustomSections {
+		if section.Tag == "" {
+			continue
+		}
+		found := false
+		for i := range spec.Tags {
+			if spec.Tags[i].Name != section.Tag {
+				continue
+			}
+			found = true
+			content := section.Content
+			if section.Title != "" {
+				content = "## " + section.Title + "\n\n" + content
+			}
+			if spec.Tags[i].Description != "" {
+				content = spec.Tags[i].Description + "\n\n" + content
+			}
+			spec.Tags[i].Description = content
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("CustomSections: section %q is attached to unknown tag %q", section.Title, section.Tag))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,38 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTagSections(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/auth/login", func(c *gin.Context) {})
+	gd := Mount(r, nil, Config{CustomSections: []Section{
+		{Title: "Tokens", Content: "Tokens expire after an hour.", Tag: "Auth"},
+		{Title: "Billing", Content: "Invoices are monthly.", Tag: "Billing"},
+		{Title: "Support", Content: "Email us."},
+	}})
+
+	spec := gd.getSpec()
+	var description string
+	for _, tag := range spec.Tags {
+		if tag.Name == "Auth" {
+			description = tag.Description
+		}
+	}
+	if description != "## Tokens\n\nTokens expire after an hour." {
+		t.Errorf("Auth description = %q", description)
+	}
+
+	sections := gd.uiSections()
+	if len(sections) != 1 || sections[0].Title != "Support" {
+		t.Errorf("uiSections = %+v, want only the untagged section", sections)
+	}
+	if warnings := strings.Join(gd.Warnings(), "\n"); !strings.Contains(warnings, `unknown tag "Billing"`) {
+		t.Errorf("expected a warning for the unknown tag, got %q", warnings)
+	}
+}
@@ -0,0 +1,94 @@
+package gindocs
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestServerURL derives the server URL a client used to reach the docs:
+// scheme and host of the request, or of X-Forwarded-Proto and
+// X-Forwarded-Host when the request comes from one of Config.TrustedProxies,
+// followed by the request's base path.
+func (gd *GinDocs) requestServerURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	host := c.Request.Host
+
+	if gd.fromTrustedProxy(c) {
+		if proto := forwardedValue(c.GetHeader("X-Forwarded-Proto")); proto != "" {
+			scheme = proto
+		}
+		if fwdHost := forwardedValue(c.GetHeader("X-Forwarded-Host")); fwdHost != "" {
+			host = fwdHost
+		}
+	}
+
+	return scheme + "://" + host + gd.requestBasePath(c)
+}
+
+// requestBasePath returns the path the app is served under behind a gateway:
+// X-Forwarded-Prefix from a trusted proxy, else Config.BasePath.
+func (gd *GinDocs) requestBasePath(c *gin.Context) string {
+	base := gd.config.BasePath
+	if gd.fromTrustedProxy(c) {
+		if prefix := forwardedValue(c.GetHeader("X-Forwarded-Prefix")); prefix != "" {
+			base = prefix
+		}
+	}
+	base = strings.TrimSuffix(base, "/")
+	if base != "" && !strings.HasPrefix(base, "/") {
+		base = "/" + base
+	}
+	return base
+}
+
+// docsURL returns the public URL path of the docs prefix for the request.
+func (gd *GinDocs) docsURL(c *gin.Context) string {
+	return gd.requestBasePath(c) + gd.config.Prefix
+}
+
+// fromTrustedProxy reports whether the request's remote address matches one
+// of Config.TrustedProxies (IPs or CIDR ranges).
+func (gd *GinDocs) fromTrustedProxy(c *gin.Context) bool {
+	ip := net.ParseIP(c.RemoteIP())
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range gd.config.TrustedProxies {
+		if strings.Contains(proxy, "/") {
+			if _, network, err := net.ParseCIDR(proxy); err == nil && network.Contains(ip) {
+				return true
+			}
+		} else if trusted := net.ParseIP(proxy); trusted != nil && trusted.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedValue returns the first entry of a comma-separated forwarding
+// header, which the proxy closest to the client set.
+func forwardedValue(header string) string {
+	first, _, _ := strings.Cut(header, ",")
+	return strings.TrimSpace(first)
+}
+
+// withRequestServer returns spec with the request's server URL when no
+// Servers are configured, so "Try It" and the code samples target the host
+// serving the docs.
+func (gd *GinDocs) withRequestServer(c *gin.Context, spec *OpenAPISpec) *OpenAPISpec {
+	if len(spec.Servers) > 0 {
+		return spec
+	}
+	server := gd.requestServerURL(c)
+	withServer := *spec
+	withServer.Servers = []ServerObject{{URL: server}}
+	if gd.config.CodeSamples {
+		return withSampleBaseURL(&withServer, server)
+	}
+	return &withServer
+}
@@ -0,0 +1,171 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+)
+
+// devTokenEnv is the environment variable AuthConfig.DevToken defaults to.
+const devTokenEnv = "GINDOCS_DEV_TOKEN"
+
+// devToken returns the security scheme and token the UI is pre-authorized
+// with in DevMode: Auth.DevToken, else $GINDOCS_DEV_TOKEN. Only Bearer and
+// API key auth are pre-filled.
+func devToken(cfg Config) (scheme, token string) {
+	if !cfg.DevMode {
+		return "", ""
+	}
+	token = cfg.Auth.DevToken
+	if token == "" {
+		token = os.Getenv(devTokenEnv)
+	}
+	switch cfg.Auth.Type {
+	case AuthBearer:
+		return "bearerAuth", token
+	case AuthAPIKey:
+		return "apiKeyAuth", token
+	}
+	return "", ""
+}
+
+// TokenEndpoint describes the login route the docs UI signs in with to get
+// a bearer token for "Try It" requests.
+type TokenEndpoint struct {
+	// URL is the login route, e.g. "/api/auth/login". Relative URLs are
+	// requested from the docs page's origin.
+	URL string
+
+	// Method is the HTTP method (default: "POST").
+	Method string
+
+	// Fields are the sign-in form fields, sent as a JSON object keyed by
+	// field name (default: email and password).
+	Fields []TokenField
+
+	// TokenPath is the dot-separated path of the token in the JSON
+	// response, e.g. "data.access_token" (default: "token", falling back
+	// to "access_token").
+	TokenPath string
+}
+
+// TokenField is a field of the sign-in form.
+type TokenField struct {
+	// Name is the JSON key the value is sent under.
+	Name string
+
+	// Label is shown next to the input (default: Name).
+	Label string
+
+	// Secret masks the input, as for passwords.
+	Secret bool
+}
+
+// defaultTokenFields are the sign-in fields used when none are configured.
+var defaultTokenFields = []TokenField{
+	{Name: "email", Label: "Email"},
+	{Name: "password", Label: "Password", Secret: true},
+}
+
+// signInHTML renders the "Sign in" button and form that request a token
+// from Config.Auth.TokenEndpoint and authorize the ui with it. It returns
+// "" unless bearer auth has a token endpoint.
+func signInHTML(cfg Config, ui UIType) string {
+	endpoint := cfg.Auth.TokenEndpoint
+	if endpoint == nil || endpoint.URL == "" || cfg.Auth.Type != AuthBearer || cfg.ReadOnly {
+		return ""
+	}
+	method := strings.ToUpper(endpoint.Method)
+	if method == "" {
+		method = "POST"
+	}
+	tokenPath := endpoint.TokenPath
+	if tokenPath == "" {
+		tokenPath = "token"
+	}
+	fields := endpoint.Fields
+	if len(fields) == 0 {
+		fields = defaultTokenFields
+	}
+
+	var inputs strings.Builder
+	for _, field := range fields {
+		label := field.Label
+		if label == "" {
+			label = field.Name
+		}
+		inputType, autocomplete := "text", "username"
+		if field.Secret {
+			inputType, autocomplete = "password", "current-password"
+		}
+		fmt.Fprintf(&inputs, `<label style="display:block;font-size:12px;color:#555;margin-bottom:8px;">%s<input name="%s" type="%s" autocomplete="%s" style="display:block;width:100%%;box-sizing:border-box;margin-top:2px;padding:6px;border:1px solid #ccc;border-radius:4px;"></label>`,
+			template.HTMLEscapeString(label), template.HTMLEscapeString(field.Name), inputType, autocomplete)
+	}
+
+	// authorize applies the token to the UI once it has loaded.
+	authorize := `if (window.ui) { window.ui.preauthorizeApiKey("bearerAuth", token); }`
+	if ui == UIScalar {
+		authorize = `if (window.gdScalar) { window.gdScalar.updateConfiguration({ authentication: { preferredSecurityScheme: "bearerAuth", securitySchemes: { bearerAuth: { token: token } } } }); }`
+	}
+
+	// json.Marshal escapes <, > and &, so the object is safe in a script.
+	config, _ := json.Marshal(map[string]string{"url": endpoint.URL, "method": method, "tokenPath": tokenPath})
+
+	return fmt.Sprintf(`<div id="gd-signin" style="position:relative;">
+        <button id="gd-signin-toggle" type="button" style="color:#fff;background:#2d3748;border:0;padding:6px 14px;border-radius:4px;font-size:13px;font-weight:600;cursor:pointer;">Sign in</button>
+        <form id="gd-signin-form" style="display:none;position:absolute;right:0;top:36px;width:240px;background:#fff;padding:16px;border-radius:6px;box-shadow:0 4px 16px rgba(0,0,0,0.15);">
+            %s
+            <button type="submit" style="width:100%%;color:#fff;background:#49cc90;border:0;padding:8px;border-radius:4px;font-weight:600;cursor:pointer;">Sign in</button>
+            <div id="gd-signin-status" style="font-size:12px;color:#c0392b;margin-top:8px;"></div>
+        </form>
+    </div>
+    <script>
+    (function () {
+        var endpoint = %s;
+        var toggle = document.getElementById("gd-signin-toggle");
+        var form = document.getElementById("gd-signin-form");
+        var status = document.getElementById("gd-signin-status");
+        function lookup(data, path) {
+            return path.split(".").reduce(function (v, key) { return v == null ? v : v[key]; }, data);
+        }
+        function apply(token) {
+            %s
+            toggle.textContent = "Signed in";
+        }
+        toggle.addEventListener("click", function () {
+            form.style.display = form.style.display === "none" ? "block" : "none";
+        });
+        form.addEventListener("submit", function (e) {
+            e.preventDefault();
+            var body = {};
+            new FormData(form).forEach(function (value, key) { body[key] = value; });
+            status.textContent = "";
+            fetch(endpoint.url, {
+                method: endpoint.method,
+                headers: { "Content-Type": "application/json", "Accept": "application/json" },
+                body: JSON.stringify(body),
+                credentials: "same-origin"
+            }).then(function (res) {
+                return res.json().catch(function () { return {}; }).then(function (data) {
+                    var token = res.ok && data && (lookup(data, endpoint.tokenPath) || data.access_token);
+                    if (!token) {
+                        status.textContent = "Sign in failed (" + res.status + ")";
+                        return;
+                    }
+                    sessionStorage.setItem("gindocs:token", token);
+                    apply(token);
+                    form.style.display = "none";
+                });
+            }).catch(function (err) {
+                status.textContent = "Sign in failed: " + err.message;
+            });
+        });
+        window.addEventListener("load", function () {
+            var saved = sessionStorage.getItem("gindocs:token");
+            if (saved) { setTimeout(function () { apply(saved); }, 0); }
+        });
+    })();
+    </script>`, inputs.String(), config, authorize)
+}
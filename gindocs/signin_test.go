@@ -0,0 +1,64 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSignInForm(t *testing.T) {
+	cfg := mergeConfig(Config{Auth: AuthConfig{
+		Type: AuthBearer,
+		TokenEndpoint: &TokenEndpoint{
+			URL:       "/api/auth/login",
+			Fields:    []TokenField{{Name: "username", Label: "Username"}, {Name: "password", Label: "Password", Secret: true}},
+			TokenPath: "data.jwt",
+		},
+	}})
+
+	swagger := renderSwaggerHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg)
+	for _, want := range []string{
+		`<input name="username" type="text"`,
+		`<input name="password" type="password"`,
+		`{"method":"POST","tokenPath":"data.jwt","url":"/api/auth/login"}`,
+		`window.ui.preauthorizeApiKey("bearerAuth", token)`,
+	} {
+		if !strings.Contains(swagger, want) {
+			t.Errorf("expected Swagger page to contain %q", want)
+		}
+	}
+
+	scalar := renderScalarHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg)
+	if !strings.Contains(scalar, "window.gdScalar.updateConfiguration") {
+		t.Error("expected the Scalar page to authorize through updateConfiguration")
+	}
+
+	// Offline exports and other auth types get no form.
+	if html := renderSwaggerHTML("API", uiSource{spec: []byte("{}")}, "", cfg); strings.Contains(html, "gd-signin") {
+		t.Error("expected no sign-in form offline")
+	}
+	cfg.Auth.Type = AuthAPIKey
+	if signInHTML(cfg, UISwagger) != "" {
+		t.Error("expected no sign-in form for API key auth")
+	}
+}
+
+func TestDevToken(t *testing.T) {
+	t.Setenv(devTokenEnv, "env-token")
+	cfg := mergeConfig(Config{DevMode: true, Auth: AuthConfig{Type: AuthBearer}})
+
+	swagger := renderSwaggerHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg)
+	if !strings.Contains(swagger, `window.ui.preauthorizeApiKey("bearerAuth", "env-token")`) {
+		t.Error("expected Swagger to be pre-authorized with the environment token")
+	}
+
+	cfg.Auth = AuthConfig{Type: AuthAPIKey, DevToken: "dev-key"}
+	if opts := scalarOptions(cfg); !strings.Contains(opts, `"securitySchemes":{"apiKeyAuth":{"value":"dev-key"}}`) {
+		t.Errorf("expected Scalar options to carry the API key, got %s", opts)
+	}
+
+	// Outside DevMode the token is never rendered.
+	cfg.DevMode = false
+	if strings.Contains(renderScalarHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg), "dev-key") {
+		t.Error("expected no dev token outside DevMode")
+	}
+}
@@ -0,0 +1,26 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Snapshot returns the spec as indented JSON with a trailing newline, suitable
+// for a golden file. Map keys are sorted, so unchanged specs produce identical
+// bytes.
+func (gd *GinDocs) Snapshot() ([]byte, error) {
+	data, err := json.MarshalIndent(gd.getSpec(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// WriteSnapshot writes the spec snapshot to path.
+func (gd *GinDocs) WriteSnapshot(path string) error {
+	data, err := gd.Snapshot()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,38 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSourceLinks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/widgets", createWidget)
+
+	gd := Mount(r, nil, Config{
+		DevMode:           true,
+		SourceLinks:       true,
+		SourceURLTemplate: "https://example.com/repo/{file}#L{line}",
+	})
+
+	op := gd.Spec().Paths["/widgets"].Post
+	if op.Source == nil || op.Source.File != "overrides_test.go" || op.Source.Line == 0 {
+		t.Fatalf("expected a source link relative to the working directory, got %+v", op.Source)
+	}
+	if !strings.HasPrefix(op.Source.URL, "https://example.com/repo/overrides_test.go#L") {
+		t.Errorf("url = %q", op.Source.URL)
+	}
+	if !strings.Contains(op.Description, "[View source]("+op.Source.URL+")") {
+		t.Errorf("description = %q", op.Description)
+	}
+
+	// Source links are a DevMode feature.
+	r = gin.New()
+	r.POST("/widgets", createWidget)
+	if op := Mount(r, nil, Config{SourceLinks: true}).Spec().Paths["/widgets"].Post; op.Source != nil {
+		t.Errorf("expected no source link outside DevMode, got %+v", op.Source)
+	}
+}
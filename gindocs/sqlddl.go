@@ -0,0 +1,207 @@
+package gindocs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// sqlColumn is a single column of a generated CREATE TABLE statement.
+type sqlColumn struct {
+	name       string
+	sqlType    string
+	notNull    bool
+	unique     bool
+	index      string // named unique index, shared by composite keys
+	primaryKey bool
+	def        *string
+}
+
+// generateSQLDDL renders PostgreSQL-flavoured CREATE TABLE statements for the
+// given GORM models. Associations (struct and slice fields) are skipped.
+func generateSQLDDL(models []interface{}, namer schema.Namer) string {
+	if namer == nil {
+		namer = schema.NamingStrategy{}
+	}
+
+	var buf strings.Builder
+	buf.WriteString("-- Generated by gindocs from registered models. Review before running.\n")
+
+	for _, model := range models {
+		t := reflect.TypeOf(model)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct || t.Name() == "" {
+			continue
+		}
+
+		table := namer.TableName(t.Name())
+		if tabler, ok := reflect.New(t).Interface().(schema.Tabler); ok {
+			table = tabler.TableName()
+		}
+
+		columns := sqlColumns(t, table, namer)
+		if len(columns) == 0 {
+			continue
+		}
+
+		// Columns sharing a named unique index are unique together, not
+		// each on its own.
+		var indexNames []string
+		indexColumns := make(map[string][]string)
+		for _, col := range columns {
+			if col.index != "" {
+				if _, ok := indexColumns[col.index]; !ok {
+					indexNames = append(indexNames, col.index)
+				}
+				indexColumns[col.index] = append(indexColumns[col.index], col.name)
+			}
+		}
+
+		var lines, keys []string
+		for _, col := range columns {
+			if len(indexColumns[col.index]) > 1 {
+				col.unique = false
+			}
+			lines = append(lines, "  "+col.definition())
+			if col.primaryKey {
+				keys = append(keys, col.name)
+			}
+		}
+		if len(keys) > 0 {
+			lines = append(lines, "  PRIMARY KEY ("+strings.Join(keys, ", ")+")")
+		}
+		for _, name := range indexNames {
+			if cols := indexColumns[name]; len(cols) > 1 {
+				lines = append(lines, "  CONSTRAINT "+name+" UNIQUE ("+strings.Join(cols, ", ")+")")
+			}
+		}
+
+		fmt.Fprintf(&buf, "\nCREATE TABLE %s (\n%s\n);\n", table, strings.Join(lines, ",\n"))
+	}
+
+	return buf.String()
+}
+
+// sqlColumns collects the columns of a model, flattening embedded structs.
+func sqlColumns(t reflect.Type, table string, namer schema.Namer) []sqlColumn {
+	var columns []sqlColumn
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tags := parseGORMTag(field.Tag.Get("gorm"))
+		if tags.GORMSkip {
+			continue
+		}
+
+		fieldType := derefType(field.Type)
+		if field.Anonymous && fieldType.Kind() == reflect.Struct && sqlColumnType(fieldType, tags) == "" {
+			columns = append(columns, sqlColumns(fieldType, table, namer)...)
+			continue
+		}
+
+		sqlType := sqlColumnType(field.Type, tags)
+		if sqlType == "" {
+			// Associations and other non-scalar fields have no column.
+			continue
+		}
+
+		name := tags.GORMColumn
+		if name == "" {
+			name = namer.ColumnName(table, field.Name)
+		}
+
+		primaryKey := tags.PrimaryKey || field.Name == "ID"
+		columns = append(columns, sqlColumn{
+			name:       name,
+			sqlType:    sqlType,
+			notNull:    tags.GORMNotNull || primaryKey,
+			unique:     tags.UniqueIndex,
+			index:      tags.GORMUnique,
+			primaryKey: primaryKey,
+			def:        tags.GORMDefault,
+		})
+	}
+
+	return columns
+}
+
+// sqlColumnType maps a Go field type to a SQL column type, or "" if the field
+// is not stored as a column.
+func sqlColumnType(t reflect.Type, tags TagInfo) string {
+	if tags.GORMType != "" {
+		return strings.ToUpper(tags.GORMType)
+	}
+
+	t = derefType(t)
+	if valueType := sqlNullValueType(t); valueType != nil {
+		t = valueType
+	}
+
+	switch {
+	case t == reflect.TypeOf(time.Time{}), t.String() == "gorm.DeletedAt":
+		return "TIMESTAMP"
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return "BYTEA"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "BOOLEAN"
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "SMALLINT"
+	case reflect.Int, reflect.Int32, reflect.Uint16, reflect.Uint32:
+		return "INTEGER"
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return "BIGINT"
+	case reflect.Float32:
+		return "REAL"
+	case reflect.Float64:
+		return "DOUBLE PRECISION"
+	case reflect.String:
+		if tags.GORMSize != nil {
+			return fmt.Sprintf("VARCHAR(%d)", *tags.GORMSize)
+		}
+		return "TEXT"
+	}
+
+	return ""
+}
+
+// definition renders the column as it appears inside CREATE TABLE.
+func (c sqlColumn) definition() string {
+	def := c.name + " " + c.sqlType
+	if c.notNull {
+		def += " NOT NULL"
+	}
+	if c.unique {
+		def += " UNIQUE"
+	}
+	if c.def != nil {
+		def += " DEFAULT " + sqlDefaultLiteral(*c.def, c.sqlType)
+	}
+	return def
+}
+
+// sqlDefaultLiteral quotes a GORM default value unless it's numeric, boolean,
+// NULL, or a function call.
+func sqlDefaultLiteral(value, sqlType string) string {
+	upper := strings.ToUpper(value)
+	switch {
+	case upper == "NULL", upper == "TRUE", upper == "FALSE", strings.Contains(value, "("):
+		return value
+	case !strings.Contains(sqlType, "CHAR") && sqlType != "TEXT":
+		if _, err := fmt.Sscanf(value, "%g", new(float64)); err == nil {
+			return value
+		}
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,46 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+type TestDDLModel struct {
+	ID     uint   `gorm:"primaryKey"`
+	Email  string `gorm:"uniqueIndex;size:255;not null"`
+	Status string `gorm:"default:active"`
+	Score  int    `gorm:"default:0"`
+	Notes  string `gorm:"column:remarks"`
+	Owner  *TestUser
+	Hidden string `gorm:"-"`
+	OrgID  uint   `gorm:"uniqueIndex:idx_org_slug"`
+	Slug   string `gorm:"uniqueIndex:idx_org_slug,sort:desc"`
+	Handle string `gorm:"uniqueIndex:idx_handle"`
+}
+
+func TestGenerateSQLDDL(t *testing.T) {
+	ddl := generateSQLDDL([]interface{}{TestDDLModel{}}, nil)
+
+	for _, want := range []string{
+		"CREATE TABLE test_ddl_models (",
+		"id BIGINT NOT NULL",
+		"email VARCHAR(255) NOT NULL UNIQUE",
+		"status TEXT DEFAULT 'active'",
+		"score INTEGER DEFAULT 0",
+		"remarks TEXT",
+		"PRIMARY KEY (id)",
+		"org_id BIGINT,",
+		"slug TEXT,",
+		"handle TEXT UNIQUE",
+		"CONSTRAINT idx_org_slug UNIQUE (org_id, slug)",
+	} {
+		if !strings.Contains(ddl, want) {
+			t.Errorf("DDL missing %q:\n%s", want, ddl)
+		}
+	}
+	for _, unwanted := range []string{"owner", "hidden"} {
+		if strings.Contains(ddl, unwanted) {
+			t.Errorf("DDL should not contain %q:\n%s", unwanted, ddl)
+		}
+	}
+}
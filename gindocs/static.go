@@ -0,0 +1,188 @@
+package gindocs
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// uiSource tells a UI page where to load the spec and UI assets from.
+type uiSource struct {
+	specURL string
+
+	// spec, when set, is the spec JSON embedded in the page instead of
+	// loading specURL.
+	spec []byte
+
+	// assets holds the contents of CDN assets to inline, by URL.
+	assets map[string]string
+}
+
+// offline reports whether the page is a static export that works without
+// a server.
+func (s uiSource) offline() bool {
+	return s.spec != nil
+}
+
+// specOption returns the UI option that loads the spec: urlKey with the spec
+// URL, or contentKey with the embedded spec.
+func (s uiSource) specOption(urlKey, contentKey string) string {
+	if s.spec != nil {
+		return contentKey + ": " + string(s.spec)
+	}
+	return urlKey + `: "` + template.JSEscapeString(s.specURL) + `"`
+}
+
+// script returns a script tag for url, inlined when its contents are known.
+func (s uiSource) script(url string) string {
+	if content, ok := s.assets[url]; ok {
+		return "<script>" + strings.ReplaceAll(content, "</script", `<\/script`) + "</script>"
+	}
+	return `<script src="` + url + `"></script>`
+}
+
+// stylesheet returns a stylesheet tag for url, inlined when its contents are
+// known.
+func (s uiSource) stylesheet(url string) string {
+	if content, ok := s.assets[url]; ok {
+		return "<style>" + strings.ReplaceAll(content, "</style", `<\/style`) + "</style>"
+	}
+	return `<link rel="stylesheet" href="` + url + `">`
+}
+
+// uiAssetFiles maps the CDN assets of each UI to their Config.UIAssets file
+// names.
+var uiAssetFiles = map[UIType]map[string]string{
+	UIScalar: {
+		scalarScriptURL: "api-reference.js",
+	},
+	UISwagger: {
+		swaggerCSSURL:    "swagger-ui.css",
+		swaggerBundleURL: "swagger-ui-bundle.js",
+		swaggerPresetURL: "swagger-ui-standalone-preset.js",
+	},
+}
+
+// ExportHTML returns the documentation as a single HTML file that works
+// offline: the spec and the assets of the ui are embedded in the page.
+func (gd *GinDocs) ExportHTML(ui UIType) (string, error) {
+	return gd.staticHTML(gd.getSpec(), ui)
+}
+
+// ExportHTMLZip writes a zip archive with the offline HTML page as
+// index.html, plus openapi.json and openapi.yaml.
+func (gd *GinDocs) ExportHTMLZip(w io.Writer, ui UIType) error {
+	return gd.writeHTMLZip(w, gd.getSpec(), ui)
+}
+
+// staticHTML renders the ui page for spec with the spec and UI assets
+// inlined.
+func (gd *GinDocs) staticHTML(spec *OpenAPISpec, ui UIType) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("gindocs: marshaling spec: %w", err)
+	}
+	assets, err := gd.uiAssets(ui)
+	if err != nil {
+		return "", err
+	}
+
+	title := gd.config.Title
+	if title == "" {
+		title = "API Documentation"
+	}
+	cfg := gd.config
+	cfg.CustomSections = gd.uiSections()
+	// Static pages are shared, so they never carry the DevMode token.
+	cfg.DevMode = false
+
+	src := uiSource{spec: data, assets: assets}
+	if ui == UIScalar {
+		return renderScalarHTML(title, src, "", cfg), nil
+	}
+	return renderSwaggerHTML(title, src, "", cfg), nil
+}
+
+// writeHTMLZip writes the zip archive of ExportHTMLZip for spec.
+func (gd *GinDocs) writeHTMLZip(w io.Writer, spec *OpenAPISpec, ui UIType) error {
+	html, err := gd.staticHTML(spec, ui)
+	if err != nil {
+		return err
+	}
+	jsonData, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("gindocs: marshaling spec: %w", err)
+	}
+	yamlData, err := specToYAML(spec)
+	if err != nil {
+		return fmt.Errorf("gindocs: marshaling spec: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"index.html", []byte(html)},
+		{"openapi.json", jsonData},
+		{"openapi.yaml", yamlData},
+	}
+	for _, file := range files {
+		f, err := zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(file.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// uiAssets returns the contents of the ui's assets by CDN URL, read from
+// Config.UIAssets or else downloaded from the CDN. Downloads are cached for
+// the life of the engine.
+func (gd *GinDocs) uiAssets(ui UIType) (map[string]string, error) {
+	gd.assetsMu.Lock()
+	defer gd.assetsMu.Unlock()
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	assets := make(map[string]string)
+	for url, name := range uiAssetFiles[ui] {
+		if gd.config.UIAssets != nil {
+			if data, err := fs.ReadFile(gd.config.UIAssets, name); err == nil {
+				assets[url] = string(data)
+				continue
+			}
+		}
+		if content, ok := gd.downloadedAssets[url]; ok {
+			assets[url] = content
+			continue
+		}
+
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("gindocs: downloading %s: %w", name, err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("gindocs: downloading %s: %w", name, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("gindocs: downloading %s: %s", name, resp.Status)
+		}
+		assets[url] = string(data)
+		if gd.downloadedAssets == nil {
+			gd.downloadedAssets = make(map[string]string)
+		}
+		gd.downloadedAssets[url] = string(data)
+	}
+	return assets, nil
+}
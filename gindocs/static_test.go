@@ -0,0 +1,93 @@
+package gindocs
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestExportHTML(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/users", func(c *gin.Context) {})
+	docs := Mount(r, nil, Config{
+		Title: "Users API",
+		UIAssets: fstest.MapFS{
+			"api-reference.js":                {Data: []byte("/* scalar */ var s = '</script>';")},
+			"swagger-ui.css":                  {Data: []byte("/* swagger css */")},
+			"swagger-ui-bundle.js":            {Data: []byte("/* swagger bundle */")},
+			"swagger-ui-standalone-preset.js": {Data: []byte("/* swagger preset */")},
+		},
+	})
+
+	for _, ui := range []UIType{UIScalar, UISwagger} {
+		html, err := docs.ExportHTML(ui)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(html, "cdn.jsdelivr.net") {
+			t.Errorf("ui %d: page still loads assets from the CDN", ui)
+		}
+		if strings.Contains(html, "Switch to") {
+			t.Errorf("ui %d: offline page links to the other UI", ui)
+		}
+		if !strings.Contains(html, `"/users"`) {
+			t.Errorf("ui %d: spec is not embedded", ui)
+		}
+	}
+
+	html, _ := docs.ExportHTML(UIScalar)
+	if !strings.Contains(html, `var s = '<\/script>'`) {
+		t.Error("inlined script should not close the script tag")
+	}
+
+	var buf bytes.Buffer
+	if err := docs.ExportHTMLZip(&buf, UISwagger); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "index.html,openapi.json,openapi.yaml" {
+		t.Errorf("zip files = %s", got)
+	}
+}
+
+func TestUIAssetsCached(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte("/* bundle */"))
+	}))
+	defer srv.Close()
+
+	saved := uiAssetFiles[UIScalar]
+	uiAssetFiles[UIScalar] = map[string]string{srv.URL + "/api-reference.js": "api-reference.js"}
+	defer func() { uiAssetFiles[UIScalar] = saved }()
+
+	gd := newGinDocs(gin.New(), nil, mergeConfig())
+	for i := 0; i < 2; i++ {
+		assets, err := gd.uiAssets(UIScalar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if assets[srv.URL+"/api-reference.js"] != "/* bundle */" {
+			t.Errorf("assets = %v", assets)
+		}
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("downloaded %d times, want once", n)
+	}
+}
@@ -0,0 +1,90 @@
+package gindocs
+
+import (
+	"reflect"
+	"strings"
+)
+
+// StreamObject documents the payloads exchanged over a WebSocket connection
+// or sent as server-sent events. It is emitted as x-websocket or x-sse.
+type StreamObject struct {
+	Messages []*SchemaObject `json:"messages"`
+}
+
+// streamKind identifies the streaming protocol of a route override.
+type streamKind int
+
+const (
+	streamNone streamKind = iota
+	streamWebSocket
+	streamSSE
+)
+
+// WebSocket documents the route as a WebSocket upgrade. A 101 response
+// replaces the inferred success responses and the message payloads are
+// listed under x-websocket. Success responses set with Response are kept.
+func (r *RouteOverride) WebSocket(messages ...interface{}) *RouteOverride {
+	r.stream = streamWebSocket
+	r.streamTypes = variantTypes(messages)
+	return r
+}
+
+// SSE documents the route as a server-sent events stream. A text/event-stream
+// 200 response replaces the inferred success responses and the event payloads
+// are listed under x-sse. A 200 set with Response is kept and gains the
+// text/event-stream content.
+func (r *RouteOverride) SSE(events ...interface{}) *RouteOverride {
+	r.stream = streamSSE
+	r.streamTypes = variantTypes(events)
+	return r
+}
+
+// applyStream documents a WebSocket or SSE endpoint on op. schemaFor builds
+// the schema of each payload type. Success responses whose codes are in
+// explicit were set with Response and are kept.
+func applyStream(op *OperationObject, kind streamKind, types []reflect.Type, explicit map[string]bool, schemaFor func(reflect.Type) *SchemaObject) {
+	stream := &StreamObject{Messages: []*SchemaObject{}}
+	for _, t := range types {
+		stream.Messages = append(stream.Messages, schemaFor(t))
+	}
+
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") && !explicit[code] {
+			delete(op.Responses, code)
+		}
+	}
+	if op.Responses == nil {
+		op.Responses = make(map[string]*Response)
+	}
+	op.RequestBody = nil
+
+	switch kind {
+	case streamWebSocket:
+		op.WebSocket = stream
+		op.Responses["101"] = &Response{Description: "Switching Protocols"}
+	case streamSSE:
+		op.SSE = stream
+		var schema *SchemaObject
+		switch len(stream.Messages) {
+		case 0:
+			schema = &SchemaObject{Type: "string"}
+		case 1:
+			schema = stream.Messages[0]
+		default:
+			schema = &SchemaObject{OneOf: stream.Messages}
+		}
+		// An explicit 200 keeps its description and content; the event
+		// stream is added unless it documents one itself.
+		response := op.Responses["200"]
+		if response == nil {
+			response = &Response{Description: "Event stream"}
+			op.Responses["200"] = response
+		}
+		if response.Content == nil {
+			response.Content = make(map[string]MediaType)
+		}
+		if _, ok := response.Content["text/event-stream"]; !ok {
+			response.Content["text/event-stream"] = MediaType{Schema: schema}
+		}
+	}
+}
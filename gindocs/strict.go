@@ -0,0 +1,110 @@
+package gindocs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StrictPolicy lists the documentation rules enforced by strict mode.
+type StrictPolicy struct {
+	// RequireSummary requires a hand-written summary rather than the one
+	// generated from the method and path.
+	RequireSummary bool
+
+	// RequireDescription requires a non-empty description.
+	RequireDescription bool
+
+	// RequireSuccessSchema requires at least one 2xx response with a schema.
+	// Operations documenting 204 No Content are exempt.
+	RequireSuccessSchema bool
+
+	// RequireErrorResponse requires at least one 4xx or 5xx response.
+	RequireErrorResponse bool
+}
+
+// StrictError lists the operations that violate the strict policy.
+type StrictError struct {
+	Violations []string
+}
+
+func (e *StrictError) Error() string {
+	return fmt.Sprintf("gindocs: %d strict mode violation(s):\n  %s",
+		len(e.Violations), strings.Join(e.Violations, "\n  "))
+}
+
+// Check builds the spec and returns a *StrictError listing every operation
+// that violates Config.Strict. It returns nil when strict mode is off.
+func (gd *GinDocs) Check() error {
+	if gd.config.Strict == nil {
+		return nil
+	}
+
+	_, err := gd.checkedBuild()
+	return err
+}
+
+// strictCheck returns a *StrictError listing the operations of spec that
+// violate policy, or nil.
+func strictCheck(policy *StrictPolicy, spec *OpenAPISpec) error {
+	var violations []string
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+			for _, problem := range strictProblems(policy, method, path, op) {
+				violations = append(violations, method+" "+path+": "+problem)
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &StrictError{Violations: violations}
+	}
+	return nil
+}
+
+// MustCheck is like Check but panics on violations. Call it once the routes
+// and overrides are registered, so the app fails at startup.
+func (gd *GinDocs) MustCheck() {
+	if err := gd.Check(); err != nil {
+		panic(err)
+	}
+}
+
+// strictProblems returns the policy rules an operation breaks.
+func strictProblems(policy *StrictPolicy, method, path string, op *OperationObject) []string {
+	var problems []string
+
+	if policy.RequireSummary && !hasCustomSummary(method, path, op) {
+		problems = append(problems, "missing summary")
+	}
+	if policy.RequireDescription && strings.TrimSpace(op.Description) == "" {
+		problems = append(problems, "missing description")
+	}
+
+	hasSuccess, hasError := false, false
+	for code, resp := range op.Responses {
+		switch {
+		case code == "204", code == "101" && op.WebSocket != nil:
+			hasSuccess = true
+		case strings.HasPrefix(code, "2"):
+			for _, media := range resp.Content {
+				if media.Schema != nil {
+					hasSuccess = true
+				}
+			}
+		case strings.HasPrefix(code, "4"), strings.HasPrefix(code, "5"):
+			hasError = true
+		}
+	}
+	if policy.RequireSuccessSchema && !hasSuccess {
+		problems = append(problems, "no 2xx response with a schema")
+	}
+	if policy.RequireErrorResponse && !hasError {
+		problems = append(problems, "no error response")
+	}
+
+	return problems
+}
@@ -0,0 +1,81 @@
+package gindocs
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStrictCheck(t *testing.T) {
+	type Item struct {
+		ID int `json:"id"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/items", func(c *gin.Context) {})
+	r.GET("/items/:id", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{Strict: &StrictPolicy{
+		RequireSummary:       true,
+		RequireSuccessSchema: true,
+	}})
+	gd.Route("GET /items").Summary("List items").Response(200, []Item{}, "Items")
+
+	err := gd.Check()
+	var strictErr *StrictError
+	if !errors.As(err, &strictErr) {
+		t.Fatalf("expected *StrictError, got %v", err)
+	}
+	want := []string{
+		"GET /items/{id}: missing summary",
+		"GET /items/{id}: no 2xx response with a schema",
+	}
+	if strings.Join(strictErr.Violations, "\n") != strings.Join(want, "\n") {
+		t.Errorf("violations = %q, want %q", strictErr.Violations, want)
+	}
+}
+
+func TestStrictBuildPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.GET("/items", func(c *gin.Context) {})
+	r.GET("/users", func(c *gin.Context) {})
+
+	// Overrides registered after Mount count toward the check.
+	gd := Mount(r, nil, Config{Strict: &StrictPolicy{RequireDescription: true}})
+	gd.Route("GET /items").Description("Lists items.")
+
+	// A violating spec is never cached: every build fails.
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("request %d: expected a failed build, got %d", i, w.Code)
+		}
+	}
+
+	func() {
+		defer func() {
+			err, _ := recover().(*StrictError)
+			if err == nil || len(err.Violations) != 1 || err.Violations[0] != "GET /users: missing description" {
+				t.Errorf("expected the build to panic on GET /users only, got %v", err)
+			}
+		}()
+		gd.Spec()
+	}()
+
+	gd.Route("GET /users").Description("Lists users.")
+	if err := gd.Check(); err != nil {
+		t.Fatalf("expected the fixed spec to pass, got %v", err)
+	}
+	gd.MustCheck()
+	if gd.Spec().Paths["/users"].Get.Description != "Lists users." {
+		t.Error("expected the passing spec to be served")
+	}
+}
@@ -0,0 +1,118 @@
+package gindocs
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// SubDocs is a documenter scoped to one feature area. Routes and groups
+// documented through it default to its tags, security, and error model, so
+// each feature package can document its own routes with shared conventions.
+// With a Prefix, every route under it gets the defaults, and Route and Group
+// take paths relative to it.
+type SubDocs struct {
+	gd *GinDocs
+
+	prefix     string
+	tags       []string
+	security   []string
+	errorModel reflect.Type
+}
+
+// Sub returns a SubDocs named name. Its routes are tagged with name unless
+// Tags is called.
+func (gd *GinDocs) Sub(name string) *SubDocs {
+	return &SubDocs{gd: gd, tags: []string{name}}
+}
+
+// Prefix scopes the sub to the routes under prefix (Gin syntax, e.g.
+// "/api/billing"): they get the sub's defaults without a Route call, and the
+// paths given to Route and Group are relative to prefix.
+func (s *SubDocs) Prefix(prefix string) *SubDocs {
+	if s.prefix == "" {
+		s.gd.subs = append(s.gd.subs, s)
+	}
+	s.prefix = strings.TrimSuffix(prefix, "/")
+	return s
+}
+
+// Tags sets the default tags for the sub's routes.
+func (s *SubDocs) Tags(tags ...string) *SubDocs {
+	s.tags = tags
+	return s
+}
+
+// Security sets the default security scheme names for the sub's routes.
+func (s *SubDocs) Security(schemes ...string) *SubDocs {
+	s.security = append(s.security, schemes...)
+	return s
+}
+
+// ErrorModel sets the body schema of the sub's 4xx and 5xx responses,
+// taking precedence over Config.ErrorModel.
+func (s *SubDocs) ErrorModel(v interface{}) *SubDocs {
+	s.errorModel = reflect.TypeOf(v)
+	return s
+}
+
+// Route returns a RouteOverride for the "METHOD /path" key with the sub's
+// defaults. The path is relative to the sub's prefix.
+func (s *SubDocs) Route(key string) *RouteOverride {
+	method, path, ok := strings.Cut(key, " ")
+	if !ok {
+		method, path = "GET", key
+	}
+	override := s.gd.Route(method + " " + s.prefix + path)
+	override.sub = s
+	return override
+}
+
+// Group returns a GroupOverride for the pattern with the sub's defaults.
+// Glob and regular expression patterns are relative to the sub's prefix.
+func (s *SubDocs) Group(pattern string) *GroupOverride {
+	if s.prefix != "" {
+		methods, path := "", pattern
+		if m, rest, ok := strings.Cut(pattern, " "); ok && !strings.HasPrefix(m, "/") && !strings.HasPrefix(m, "^") {
+			methods, path = m+" ", strings.TrimSpace(rest)
+		}
+		if strings.HasPrefix(path, "^") {
+			path = "^" + regexp.QuoteMeta(s.prefix) + strings.TrimPrefix(path, "^")
+		} else {
+			path = s.prefix + path
+		}
+		pattern = methods + path
+	}
+	override := s.gd.Group(pattern)
+	override.sub = s
+	return override
+}
+
+// covers reports whether a route path is under the sub's prefix.
+func (s *SubDocs) covers(path string) bool {
+	return s.prefix != "" && (path == s.prefix || strings.HasPrefix(path, s.prefix+"/"))
+}
+
+// applySubDefaults applies a sub's defaults to an operation.
+func (gd *GinDocs) applySubDefaults(op *OperationObject, s *SubDocs) {
+	if len(s.tags) > 0 {
+		op.Tags = s.tags
+	}
+	if len(s.security) > 0 {
+		op.Security = nil
+		for _, scheme := range s.security {
+			op.Security = append(op.Security, SecurityRequirement{
+				scheme: []string{},
+			})
+		}
+	}
+	if s.errorModel != nil {
+		for code, resp := range op.Responses {
+			if code >= "400" {
+				resp.Content = map[string]MediaType{
+					"application/json": {Schema: typeToSchema(s.errorModel, gd.registry)},
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package gindocs
+
+import "testing"
+
+func TestSubDocs_Defaults(t *testing.T) {
+	type BillingError struct {
+		Code string `json:"code"`
+	}
+
+	gd := newGinDocs(nil, nil, mergeConfig())
+	billing := gd.Sub("Billing").Security("bearerAuth").ErrorModel(BillingError{})
+	billing.Route("GET /billing/invoices/:id")
+	billing.Route("POST /billing/invoices").Tags("Invoices")
+
+	get := gd.buildOperation(RouteMetadata{Method: "GET", Path: "/billing/invoices/:id", PathParams: []string{"id"}})
+	if len(get.Tags) != 1 || get.Tags[0] != "Billing" {
+		t.Errorf("expected default tag Billing, got %v", get.Tags)
+	}
+	if len(get.Security) != 1 || get.Security[0]["bearerAuth"] == nil {
+		t.Errorf("expected bearerAuth security, got %v", get.Security)
+	}
+	if schema := get.Responses["404"].Content["application/json"].Schema; schema == nil || schema.Ref != RefPath("BillingError") {
+		t.Errorf("expected 404 to use BillingError, got %+v", schema)
+	}
+	if get.Responses["200"].Content != nil {
+		t.Error("success responses should not use the error model")
+	}
+
+	post := gd.buildOperation(RouteMetadata{Method: "POST", Path: "/billing/invoices"})
+	if len(post.Tags) != 1 || post.Tags[0] != "Invoices" {
+		t.Errorf("expected route tags to win over sub defaults, got %v", post.Tags)
+	}
+}
+
+func TestSubDocs_Prefix(t *testing.T) {
+	gd := newGinDocs(nil, nil, mergeConfig())
+	billing := gd.Sub("Billing").Prefix("/api/billing/").Security("bearerAuth")
+	billing.Route("GET /invoices/:id").Summary("Get an invoice")
+	billing.Group("POST /invoices*").Tags("Invoices")
+	billing.Group(`^/plans/\d+$`).Tags("Plans")
+
+	// Routes under the prefix get the defaults without a Route call.
+	plans := gd.buildOperation(RouteMetadata{Method: "GET", Path: "/api/billing/plans"})
+	if len(plans.Tags) != 1 || plans.Tags[0] != "Billing" || len(plans.Security) != 1 {
+		t.Errorf("expected sub defaults under the prefix, got tags %v security %v", plans.Tags, plans.Security)
+	}
+	if other := gd.buildOperation(RouteMetadata{Method: "GET", Path: "/api/billingx"}); len(other.Security) != 0 {
+		t.Error("a sibling path sharing the prefix's text should not get the defaults")
+	}
+
+	// Route and Group paths are relative to the prefix.
+	get := gd.buildOperation(RouteMetadata{Method: "GET", Path: "/api/billing/invoices/:id", PathParams: []string{"id"}})
+	if get.Summary != "Get an invoice" {
+		t.Errorf("summary = %q, want the relative Route override", get.Summary)
+	}
+	post := gd.buildOperation(RouteMetadata{Method: "POST", Path: "/api/billing/invoices"})
+	if len(post.Tags) != 1 || post.Tags[0] != "Invoices" {
+		t.Errorf("POST tags = %v, want the relative Group's", post.Tags)
+	}
+	plan := gd.buildOperation(RouteMetadata{Method: "GET", Path: "/api/billing/plans/7"})
+	if len(plan.Tags) != 1 || plan.Tags[0] != "Plans" {
+		t.Errorf("plan tags = %v, want the relative regexp Group's", plan.Tags)
+	}
+}
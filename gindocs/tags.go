@@ -1,6 +1,7 @@
 package gindocs
 
 import (
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -13,15 +14,24 @@ type TagInfo struct {
 	JSONSkip  bool
 
 	// Binding/validate tag
-	Required    bool
-	MinLength   *int
-	MaxLength   *int
-	Minimum     *float64
-	Maximum     *float64
-	Enum        []string
-	Format      string // e.g., "email", "uri", "uuid"
-	Pattern     string
-	BindingSkip bool
+	Required         bool
+	MinLength        *int
+	MaxLength        *int
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum *float64
+	ExclusiveMaximum *float64
+	Enum             []string
+	Format           string // e.g., "email", "uri", "uuid"
+	Pattern          string
+	BindingSkip      bool
+
+	// Notes are rules with no JSON Schema keyword (e.g., required_if),
+	// rendered into the description.
+	Notes []string
+
+	// Items holds the rules that follow "dive" and apply to slice elements.
+	Items *TagInfo
 
 	// GORM tag
 	PrimaryKey     bool
@@ -30,16 +40,27 @@ type TagInfo struct {
 	GORMSize       *int
 	GORMDefault    *string
 	UniqueIndex    bool
+	GORMUnique     string // uniqueIndex name; fields sharing one are unique together
 	GORMSkip       bool
 	GORMType       string
+	GORMColumn     string
+	GORMNotNull    bool
 
 	// Docs tag
 	Description string
 	Example     string
 	Deprecated  bool
 	Hidden      bool
+	WriteOnly   bool
 	DocsFormat  string
 	DocsEnum    []string
+	DocsTitle   string
+	DocsDefault *string
+	MultipleOf  *float64
+
+	// DocsMinimum and DocsMaximum override numeric bounds from binding rules.
+	DocsMinimum *float64
+	DocsMaximum *float64
 }
 
 // parseJSONTag parses a json struct tag value.
@@ -74,9 +95,16 @@ func parseBindingTag(tag string) TagInfo {
 	}
 
 	parts := strings.Split(tag, ",")
-	for _, part := range parts {
+	for i, part := range parts {
 		part = strings.TrimSpace(part)
 
+		// Everything after "dive" validates the elements of a slice or map.
+		if part == "dive" {
+			items := parseBindingTag(strings.Join(parts[i+1:], ","))
+			info.Items = &items
+			break
+		}
+
 		switch {
 		case part == "required":
 			info.Required = true
@@ -94,20 +122,45 @@ func parseBindingTag(tag string) TagInfo {
 			info.Format = "ipv4"
 		case part == "datetime":
 			info.Format = "date-time"
+		case part == "alpha":
+			info.Pattern = "^[a-zA-Z]+$"
+		case part == "alphanum":
+			info.Pattern = "^[a-zA-Z0-9]+$"
+		case part == "numeric":
+			info.Pattern = "^[-+]?[0-9]+(?:\\.[0-9]+)?$"
+		case part == "number":
+			info.Pattern = "^[0-9]+$"
+		case part == "hexadecimal":
+			info.Pattern = "^(0[xX])?[0-9a-fA-F]+$"
+		case part == "lowercase":
+			info.Pattern = "^[^A-Z]*$"
+		case part == "uppercase":
+			info.Pattern = "^[^a-z]*$"
+		case strings.HasPrefix(part, "regexp="):
+			info.Pattern = strings.TrimPrefix(part, "regexp=")
+		case strings.HasPrefix(part, "required_"), strings.HasPrefix(part, "excluded_"),
+			strings.HasSuffix(strings.SplitN(part, "=", 2)[0], "field"):
+			if note := validationNote(part); note != "" {
+				info.Notes = append(info.Notes, note)
+			}
 		case strings.HasPrefix(part, "oneof="):
 			values := strings.TrimPrefix(part, "oneof=")
 			info.Enum = strings.Fields(values)
+		// min= and max= mean length for strings and slices but range for
+		// numbers. Both readings are recorded; fieldToSchema picks one by kind.
 		case strings.HasPrefix(part, "min="):
-			if v, err := strconv.Atoi(strings.TrimPrefix(part, "min=")); err == nil {
-				info.MinLength = intPtr(v)
-				f := float64(v)
-				info.Minimum = &f
+			if v, err := strconv.ParseFloat(strings.TrimPrefix(part, "min="), 64); err == nil {
+				info.Minimum = &v
+				if v == float64(int(v)) {
+					info.MinLength = intPtr(int(v))
+				}
 			}
 		case strings.HasPrefix(part, "max="):
-			if v, err := strconv.Atoi(strings.TrimPrefix(part, "max=")); err == nil {
-				info.MaxLength = intPtr(v)
-				f := float64(v)
-				info.Maximum = &f
+			if v, err := strconv.ParseFloat(strings.TrimPrefix(part, "max="), 64); err == nil {
+				info.Maximum = &v
+				if v == float64(int(v)) {
+					info.MaxLength = intPtr(int(v))
+				}
 			}
 		case strings.HasPrefix(part, "gte="):
 			if v, err := strconv.ParseFloat(strings.TrimPrefix(part, "gte="), 64); err == nil {
@@ -115,7 +168,7 @@ func parseBindingTag(tag string) TagInfo {
 			}
 		case strings.HasPrefix(part, "gt="):
 			if v, err := strconv.ParseFloat(strings.TrimPrefix(part, "gt="), 64); err == nil {
-				info.Minimum = &v
+				info.ExclusiveMinimum = &v
 			}
 		case strings.HasPrefix(part, "lte="):
 			if v, err := strconv.ParseFloat(strings.TrimPrefix(part, "lte="), 64); err == nil {
@@ -123,7 +176,7 @@ func parseBindingTag(tag string) TagInfo {
 			}
 		case strings.HasPrefix(part, "lt="):
 			if v, err := strconv.ParseFloat(strings.TrimPrefix(part, "lt="), 64); err == nil {
-				info.Maximum = &v
+				info.ExclusiveMaximum = &v
 			}
 		case strings.HasPrefix(part, "len="):
 			if v, err := strconv.Atoi(strings.TrimPrefix(part, "len=")); err == nil {
@@ -136,6 +189,55 @@ func parseBindingTag(tag string) TagInfo {
 	return info
 }
 
+// validationNote describes a cross-field validator rule in plain language.
+// Returns "" for rules it doesn't know.
+func validationNote(rule string) string {
+	name, param, _ := strings.Cut(rule, "=")
+	fields := strings.Fields(param)
+
+	switch name {
+	case "required_if":
+		return "Required when " + describeFieldValues(fields, " and ")
+	case "required_unless":
+		return "Required unless " + describeFieldValues(fields, " and ")
+	case "required_with":
+		return "Required when " + strings.Join(fields, " or ") + " is present"
+	case "required_with_all":
+		return "Required when " + strings.Join(fields, " and ") + " are present"
+	case "required_without":
+		return "Required when " + strings.Join(fields, " or ") + " is absent"
+	case "required_without_all":
+		return "Required when " + strings.Join(fields, " and ") + " are absent"
+	case "excluded_if":
+		return "Must be omitted when " + describeFieldValues(fields, " and ")
+	case "excluded_with":
+		return "Must be omitted when " + strings.Join(fields, " or ") + " is present"
+	case "eqfield":
+		return "Must equal " + param
+	case "nefield":
+		return "Must differ from " + param
+	case "gtfield":
+		return "Must be greater than " + param
+	case "gtefield":
+		return "Must be greater than or equal to " + param
+	case "ltfield":
+		return "Must be less than " + param
+	case "ltefield":
+		return "Must be less than or equal to " + param
+	}
+	return ""
+}
+
+// describeFieldValues renders "Field value Field value" pairs as
+// "Field is value" clauses joined by sep.
+func describeFieldValues(fields []string, sep string) string {
+	var clauses []string
+	for i := 0; i+1 < len(fields); i += 2 {
+		clauses = append(clauses, fields[i]+" is "+fields[i+1])
+	}
+	return strings.Join(clauses, sep)
+}
+
 // parseGORMTag parses a gorm struct tag value.
 func parseGORMTag(tag string) TagInfo {
 	var info TagInfo
@@ -160,8 +262,15 @@ func parseGORMTag(tag string) TagInfo {
 			info.AutoCreateTime = true
 		case lower == "autoupdatetime":
 			info.AutoUpdateTime = true
-		case lower == "uniqueindex" || strings.HasPrefix(lower, "uniqueindex:"):
+		case lower == "not null" || lower == "not_null":
+			info.GORMNotNull = true
+		case lower == "unique" || lower == "uniqueindex":
+			info.UniqueIndex = true
+		case strings.HasPrefix(lower, "uniqueindex:"):
 			info.UniqueIndex = true
+			// Options such as sort:desc follow the name after a comma.
+			name, _, _ := strings.Cut(part[len("uniqueindex:"):], ",")
+			info.GORMUnique = strings.TrimSpace(name)
 		case strings.HasPrefix(lower, "size:"):
 			if v, err := strconv.Atoi(strings.TrimPrefix(lower, "size:")); err == nil {
 				info.GORMSize = intPtr(v)
@@ -174,6 +283,8 @@ func parseGORMTag(tag string) TagInfo {
 			info.GORMDefault = &val
 		case strings.HasPrefix(lower, "type:"):
 			info.GORMType = strings.TrimPrefix(part, "type:")
+		case strings.HasPrefix(lower, "column:"):
+			info.GORMColumn = part[len("column:"):]
 		}
 	}
 
@@ -181,36 +292,223 @@ func parseGORMTag(tag string) TagInfo {
 }
 
 // parseDocsTag parses a docs struct tag value.
+// Values may be single-quoted or use \, to contain commas,
+// e.g. `docs:"description:'Price, in USD',minimum:0"`.
 func parseDocsTag(tag string) TagInfo {
 	var info TagInfo
 	if tag == "" {
 		return info
 	}
 
-	parts := strings.Split(tag, ",")
-	for _, part := range parts {
+	for _, part := range splitDocsTag(tag) {
 		part = strings.TrimSpace(part)
+		key, value, _ := strings.Cut(part, ":")
 
-		switch {
-		case part == "deprecated":
+		switch key {
+		case "deprecated":
 			info.Deprecated = true
-		case part == "hidden":
+		case "hidden":
 			info.Hidden = true
-		case strings.HasPrefix(part, "description:"):
-			info.Description = strings.TrimPrefix(part, "description:")
-		case strings.HasPrefix(part, "example:"):
-			info.Example = strings.TrimPrefix(part, "example:")
-		case strings.HasPrefix(part, "format:"):
-			info.DocsFormat = strings.TrimPrefix(part, "format:")
-		case strings.HasPrefix(part, "enum:"):
-			enumStr := strings.TrimPrefix(part, "enum:")
-			info.DocsEnum = strings.Split(enumStr, "|")
+		case "writeonly":
+			info.WriteOnly = true
+		case "description":
+			info.Description = value
+		case "example":
+			info.Example = value
+		case "format":
+			info.DocsFormat = value
+		case "enum":
+			info.DocsEnum = strings.Split(value, "|")
+		case "title":
+			info.DocsTitle = value
+		case "pattern":
+			info.Pattern = value
+		case "default":
+			info.DocsDefault = &value
+		case "minimum":
+			info.DocsMinimum = parseFloatPtr(value)
+		case "maximum":
+			info.DocsMaximum = parseFloatPtr(value)
+		case "multipleOf":
+			info.MultipleOf = parseFloatPtr(value)
+		}
+	}
+
+	return info
+}
+
+// splitDocsTag splits a docs tag on commas. A value starting with a single
+// quote runs to the closing quote, commas included; elsewhere quotes are
+// literal. Only \, and \\ are escapes, so patterns such as ^\d+$ keep
+// their backslashes.
+func splitDocsTag(tag string) []string {
+	var parts []string
+	var current strings.Builder
+	// colon is set once the part's key is read; valueStart only right after it.
+	quoted, colon, valueStart := false, false, false
+
+	runes := []rune(tag)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		atValueStart := valueStart
+		valueStart = false
+		switch {
+		case r == '\\' && i+1 < len(runes) && (runes[i+1] == ',' || runes[i+1] == '\\'):
+			i++
+			current.WriteRune(runes[i])
+		case r == '\'' && (quoted || atValueStart):
+			quoted = !quoted
+		case r == ',' && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+			colon = false
+		default:
+			current.WriteRune(r)
+			if r == ':' && !colon {
+				colon, valueStart = true, true
+			}
 		}
 	}
+	parts = append(parts, current.String())
+
+	return parts
+}
+
+// parseFloatPtr parses a float, returning nil if the value isn't a number.
+func parseFloatPtr(value string) *float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+// fieldTags parses and merges all documentation-relevant tags of a struct field.
+func (r *TypeRegistry) fieldTags(field reflect.StructField) TagInfo {
+	info := mergeTags(
+		field.Tag.Get("json"),
+		combineValidationTags(field.Tag.Get("binding"), field.Tag.Get("validate"), r.preferValidate),
+		field.Tag.Get("gorm"),
+		field.Tag.Get("docs"),
+	)
 
 	return info
 }
 
+// markSensitive marks the properties of schema whose names are sensitive as
+// writeOnly.
+func markSensitive(schema *SchemaObject, sensitive []string) {
+	if schema == nil {
+		return
+	}
+	for name, prop := range schema.Properties {
+		if prop != nil && isSensitiveName(name, sensitive) {
+			prop.WriteOnly = true
+		}
+	}
+}
+
+// markSensitiveRequestFields marks the sensitive properties of every schema
+// reachable from a request body as writeOnly. Schemas only used in responses,
+// such as a login response's token, are left alone.
+func markSensitiveRequestFields(spec *OpenAPISpec, sensitive []string) {
+	if len(sensitive) == 0 {
+		return
+	}
+	seen := make(map[string]bool)
+	var visit func(s *SchemaObject)
+	visit = func(s *SchemaObject) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			name := strings.TrimPrefix(s.Ref, RefPath(""))
+			if !seen[name] {
+				seen[name] = true
+				visit(spec.Components.Schemas[name])
+			}
+			return
+		}
+		markSensitive(s, sensitive)
+		for _, child := range schemaChildren(s) {
+			visit(child)
+		}
+	}
+
+	for _, pathItem := range spec.Paths {
+		for _, method := range httpMethods {
+			if op := pathItem.GetOperation(method); op != nil && op.RequestBody != nil {
+				for _, media := range op.RequestBody.Content {
+					visit(media.Schema)
+				}
+			}
+		}
+	}
+}
+
+// isSensitiveName reports whether a field name ends with one of the sensitive
+// names, ignoring case and separators (e.g., "reset_token" matches "token").
+func isSensitiveName(name string, sensitive []string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+	for _, s := range sensitive {
+		if s != "" && strings.HasSuffix(normalized, strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// combineValidationTags joins the binding and validate tag values into a single
+// rule list. The preferred tag is placed last so its rules win on conflicts.
+// Each tag's rules before and after "dive" are joined separately, so one
+// tag's dive doesn't capture the other's field rules.
+func combineValidationTags(binding, validate string, preferValidate bool) string {
+	if binding == "-" || validate == "-" {
+		// Preserve the skip marker when the other tag is empty.
+		if binding == "" || validate == "" {
+			return "-"
+		}
+		if binding == "-" {
+			binding = ""
+		} else {
+			validate = ""
+		}
+	}
+
+	bindingHead, bindingItems, bindingDive := cutDive(binding)
+	validateHead, validateItems, validateDive := cutDive(validate)
+	first, second := validateHead, bindingHead
+	if preferValidate {
+		first, second = bindingHead, validateHead
+	}
+
+	var parts []string
+	for _, tag := range []string{first, second} {
+		if tag != "" {
+			parts = append(parts, tag)
+		}
+	}
+	if bindingDive || validateDive {
+		parts = append(parts, "dive")
+		if items := combineValidationTags(bindingItems, validateItems, preferValidate); items != "" {
+			parts = append(parts, items)
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// cutDive splits a validation tag at its first "dive" rule.
+func cutDive(tag string) (head, items string, found bool) {
+	parts := strings.Split(tag, ",")
+	for i, part := range parts {
+		if strings.TrimSpace(part) == "dive" {
+			return strings.Join(parts[:i], ","), strings.Join(parts[i+1:], ","), true
+		}
+	}
+	return tag, "", false
+}
+
 // mergeTags merges parsed tag info from all tag sources into a single TagInfo.
 func mergeTags(jsonTag, bindingTag, gormTag, docsTag string) TagInfo {
 	name, omitEmpty, jsonSkip := parseJSONTag(jsonTag)
@@ -225,15 +523,19 @@ func mergeTags(jsonTag, bindingTag, gormTag, docsTag string) TagInfo {
 		JSONSkip:  jsonSkip,
 
 		// Binding
-		Required:    binding.Required,
-		MinLength:   binding.MinLength,
-		MaxLength:   binding.MaxLength,
-		Minimum:     binding.Minimum,
-		Maximum:     binding.Maximum,
-		Enum:        binding.Enum,
-		Format:      binding.Format,
-		Pattern:     binding.Pattern,
-		BindingSkip: binding.BindingSkip,
+		Required:         binding.Required,
+		MinLength:        binding.MinLength,
+		MaxLength:        binding.MaxLength,
+		Minimum:          binding.Minimum,
+		Maximum:          binding.Maximum,
+		ExclusiveMinimum: binding.ExclusiveMinimum,
+		ExclusiveMaximum: binding.ExclusiveMaximum,
+		Enum:             binding.Enum,
+		Format:           binding.Format,
+		Pattern:          binding.Pattern,
+		BindingSkip:      binding.BindingSkip,
+		Notes:            binding.Notes,
+		Items:            binding.Items,
 
 		// GORM
 		PrimaryKey:     gorm.PrimaryKey,
@@ -242,16 +544,36 @@ func mergeTags(jsonTag, bindingTag, gormTag, docsTag string) TagInfo {
 		GORMSize:       gorm.GORMSize,
 		GORMDefault:    gorm.GORMDefault,
 		UniqueIndex:    gorm.UniqueIndex,
+		GORMUnique:     gorm.GORMUnique,
 		GORMSkip:       gorm.GORMSkip,
 		GORMType:       gorm.GORMType,
+		GORMColumn:     gorm.GORMColumn,
+		GORMNotNull:    gorm.GORMNotNull,
 
 		// Docs
 		Description: docs.Description,
 		Example:     docs.Example,
 		Deprecated:  docs.Deprecated,
 		Hidden:      docs.Hidden,
+		WriteOnly:   docs.WriteOnly,
 		DocsFormat:  docs.DocsFormat,
 		DocsEnum:    docs.DocsEnum,
+		DocsTitle:   docs.DocsTitle,
+		DocsDefault: docs.DocsDefault,
+		MultipleOf:  docs.MultipleOf,
+		DocsMinimum: docs.DocsMinimum,
+		DocsMaximum: docs.DocsMaximum,
+	}
+
+	// Docs pattern and numeric bounds override binding rules.
+	if docs.Pattern != "" {
+		info.Pattern = docs.Pattern
+	}
+	if docs.DocsMinimum != nil {
+		info.Minimum = docs.DocsMinimum
+	}
+	if docs.DocsMaximum != nil {
+		info.Maximum = docs.DocsMaximum
 	}
 
 	// Docs format overrides binding format.
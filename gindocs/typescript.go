@@ -0,0 +1,275 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// generateTypeScript renders a TypeScript interface or type alias for every
+// component schema and an Api interface with a typed method per operation.
+func generateTypeScript(spec *OpenAPISpec) string {
+	var buf strings.Builder
+	buf.WriteString("// Code generated by gindocs. DO NOT EDIT.\n")
+
+	if spec.Components != nil {
+		for _, name := range sortedKeys(spec.Components.Schemas) {
+			schema := spec.Components.Schemas[name]
+			buf.WriteString("\n")
+			writeTSDoc(&buf, schema.Description, "")
+			if isPlainObject(schema) {
+				fmt.Fprintf(&buf, "export interface %s %s\n", factoryIdent(name), tsObject(schema, ""))
+			} else {
+				fmt.Fprintf(&buf, "export type %s = %s;\n", factoryIdent(name), tsType(schema, ""))
+			}
+		}
+	}
+
+	buf.WriteString("\nexport interface Api {\n")
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+			writeTSOperation(&buf, method, path, op)
+		}
+	}
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+// writeTSOperation writes the Api method signature of one operation: a params
+// object for path, query and header parameters, a body for the request body,
+// and a Promise of the success response.
+func writeTSOperation(buf *strings.Builder, method, path string, op *OperationObject) {
+	id := op.OperationID
+	if id == "" {
+		id = generateOperationID(method, path)
+	}
+	name := []rune(factoryIdent(id))
+	if len(name) == 0 {
+		// An operationId of only punctuation has no identifier characters.
+		name = []rune(factoryIdent(generateOperationID(method, path)))
+	}
+	if len(name) > 0 {
+		name[0] = unicode.ToLower(name[0])
+	}
+
+	doc := method + " " + path
+	if op.Summary != "" {
+		doc += " — " + op.Summary
+	}
+	writeTSDoc(buf, doc, "  ")
+
+	var args []string
+	if len(op.Parameters) > 0 {
+		params := &SchemaObject{Type: "object", Properties: map[string]*SchemaObject{}}
+		for _, param := range op.Parameters {
+			params.Properties[param.Name] = param.Schema
+			if param.Required {
+				params.Required = append(params.Required, param.Name)
+			}
+		}
+		args = append(args, "params: "+tsObject(params, "  "))
+	}
+	if op.RequestBody != nil {
+		for _, media := range sortedMediaTypes(op.RequestBody.Content) {
+			args = append(args, "body: "+tsType(media.Schema, "  "))
+			break
+		}
+	}
+
+	fmt.Fprintf(buf, "  %s(%s): Promise<%s>;\n", string(name), strings.Join(args, ", "), tsSuccessType(op))
+}
+
+// tsSuccessType returns the TypeScript type of the lowest 2xx response body.
+func tsSuccessType(op *OperationObject) string {
+	codes := sortedKeys(op.Responses)
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		for _, media := range sortedMediaTypes(op.Responses[code].Content) {
+			return tsType(media.Schema, "  ")
+		}
+		return "void"
+	}
+	return "void"
+}
+
+// sortedMediaTypes returns the media types of a content map, JSON first.
+func sortedMediaTypes(content map[string]MediaType) []MediaType {
+	keys := sortedKeys(content)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] == "application/json" && keys[j] != "application/json"
+	})
+	media := make([]MediaType, 0, len(keys))
+	for _, key := range keys {
+		media = append(media, content[key])
+	}
+	return media
+}
+
+// isPlainObject reports whether a schema is an object with fixed properties,
+// rendered as a TypeScript interface or a Go struct.
+func isPlainObject(schema *SchemaObject) bool {
+	return schema.Ref == "" && schema.Type == "object" && len(schema.Properties) > 0 &&
+		schema.AdditionalProperties == nil && !schema.Nullable
+}
+
+// tsType returns the TypeScript type of a schema. indent is the indentation
+// of the line the type starts on.
+func tsType(schema *SchemaObject, indent string) string {
+	if schema == nil {
+		return "unknown"
+	}
+	t := tsBaseType(schema, indent)
+	if schema.Nullable {
+		t += " | null"
+	}
+	return t
+}
+
+// tsBaseType returns the TypeScript type of a schema, ignoring nullability.
+func tsBaseType(schema *SchemaObject, indent string) string {
+	switch {
+	case schema.Ref != "":
+		return factoryIdent(strings.TrimPrefix(schema.Ref, RefPath("")))
+	case len(schema.Enum) > 0:
+		values := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			data, _ := json.Marshal(v)
+			values = append(values, string(data))
+		}
+		return strings.Join(values, " | ")
+	case len(schema.OneOf) > 0:
+		return tsUnion(schema.OneOf, " | ", indent)
+	case len(schema.AnyOf) > 0:
+		return tsUnion(schema.AnyOf, " | ", indent)
+	case len(schema.AllOf) > 0:
+		return tsUnion(schema.AllOf, " & ", indent)
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "binary" {
+			return "Blob"
+		}
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "null":
+		return "null"
+	case "array":
+		item := tsType(schema.Items, indent)
+		if tsCompound(schema.Items) {
+			return "(" + item + ")[]"
+		}
+		return item + "[]"
+	case "object":
+		if len(schema.Properties) > 0 {
+			return tsObject(schema, indent)
+		}
+		if schema.AdditionalProperties != nil {
+			return "Record<string, " + tsType(schema.AdditionalProperties, indent) + ">"
+		}
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+// tsCompound reports whether the TypeScript type of a schema is a union or
+// intersection, which needs parentheses as an array element type.
+func tsCompound(schema *SchemaObject) bool {
+	switch {
+	case schema == nil:
+		return false
+	case schema.Nullable:
+		return true
+	case schema.Ref != "":
+		return false
+	case len(schema.Enum) > 0:
+		return len(schema.Enum) > 1
+	case len(schema.OneOf) > 0:
+		return len(schema.OneOf) > 1
+	case len(schema.AnyOf) > 0:
+		return len(schema.AnyOf) > 1
+	}
+	return len(schema.AllOf) > 1
+}
+
+// tsUnion joins the types of several schemas with sep.
+func tsUnion(schemas []*SchemaObject, sep, indent string) string {
+	types := make([]string, 0, len(schemas))
+	for _, s := range schemas {
+		types = append(types, tsType(s, indent))
+	}
+	return strings.Join(types, sep)
+}
+
+// tsObject renders an object schema as a TypeScript object type literal.
+// Properties that aren't required are optional.
+func tsObject(schema *SchemaObject, indent string) string {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, name := range sortedKeys(schema.Properties) {
+		prop := schema.Properties[name]
+		if prop != nil {
+			writeTSDoc(&b, prop.Description, indent+"  ")
+		}
+		optional := "?"
+		if required[name] {
+			optional = ""
+		}
+		readonly := ""
+		if prop != nil && prop.ReadOnly {
+			readonly = "readonly "
+		}
+		fmt.Fprintf(&b, "%s  %s%s%s: %s;\n", indent, readonly, tsPropertyName(name), optional, tsType(prop, indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// tsPropertyName quotes property names that aren't valid identifiers.
+func tsPropertyName(name string) string {
+	for i, r := range name {
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return strconv.Quote(name)
+		}
+	}
+	if name == "" {
+		return `""`
+	}
+	return name
+}
+
+// writeTSDoc writes text as a JSDoc comment, if it isn't empty.
+func writeTSDoc(buf *strings.Builder, text, indent string) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "*/", "*\\/"))
+	if text == "" {
+		return
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(buf, "%s/** %s */\n", indent, text)
+		return
+	}
+	fmt.Fprintf(buf, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(buf, "%s * %s\n", indent, strings.TrimRight(line, " "))
+	}
+	fmt.Fprintf(buf, "%s */\n", indent)
+}
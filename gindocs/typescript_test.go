@@ -0,0 +1,76 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	spec := &OpenAPISpec{
+		Paths: map[string]*PathItem{
+			"/users/{id}": {
+				Put: &OperationObject{
+					OperationID: "updateUser",
+					Summary:     "Update a user",
+					Parameters: []ParameterObject{
+						{Name: "id", In: "path", Required: true, Schema: &SchemaObject{Type: "integer"}},
+					},
+					RequestBody: &RequestBodyObject{Content: map[string]MediaType{
+						"application/json": {Schema: &SchemaObject{Ref: RefPath("User")}},
+					}},
+					Responses: map[string]*Response{
+						"200": {Content: map[string]MediaType{
+							"application/json": {Schema: &SchemaObject{Ref: RefPath("User")}},
+						}},
+					},
+				},
+				Delete: &OperationObject{
+					OperationID: "deleteUser",
+					Responses:   map[string]*Response{"204": {Description: "Deleted"}},
+				},
+			},
+			"/ping": {
+				Get: &OperationObject{OperationID: "--"},
+			},
+		},
+		Components: &ComponentsObject{Schemas: map[string]*SchemaObject{
+			"User": {
+				Type:        "object",
+				Description: "A registered user.",
+				Required:    []string{"id", "email"},
+				Properties: map[string]*SchemaObject{
+					"id":       {Type: "integer", ReadOnly: true},
+					"email":    {Type: "string"},
+					"role":     {Type: "string", Enum: []interface{}{"admin", "member"}},
+					"nickname": {Type: "string", Nullable: true},
+					"tags":     {Type: "array", Items: &SchemaObject{Type: "string"}},
+					"notes": {Type: "array", Items: &SchemaObject{
+						Type: "object", Nullable: true, Properties: map[string]*SchemaObject{"text": {Type: "string"}},
+					}},
+					"x-trace-id": {Type: "string"},
+				},
+			},
+			"Role": {Type: "string", Enum: []interface{}{"admin", "member"}},
+		}},
+	}
+
+	code := generateTypeScript(spec)
+	for _, want := range []string{
+		"/** A registered user. */\nexport interface User {",
+		"  readonly id: number;",
+		"  email: string;",
+		`  role?: "admin" | "member";`,
+		"  nickname?: string | null;",
+		"  tags?: string[];",
+		`  "x-trace-id"?: string;`,
+		`export type Role = "admin" | "member";`,
+		"  /** PUT /users/{id} — Update a user */\n  updateUser(params: {\n    id: number;\n  }, body: User): Promise<User>;",
+		"  deleteUser(): Promise<void>;",
+		"  notes?: ({\n    text?: string;\n  } | null)[];",
+		"  getPing(): Promise<void>;",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in:\n%s", want, code)
+		}
+	}
+}
@@ -1,51 +1,91 @@
 package gindocs
 
 import (
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"strings"
 )
 
-// renderScalarHTML generates the full Scalar UI HTML page.
-func renderScalarHTML(title, specURL string, cfg Config) string {
-	customCSS := ""
-	if cfg.CustomCSS != "" {
-		customCSS = fmt.Sprintf("<style>%s</style>", cfg.CustomCSS)
+// scalarScriptURL is the Scalar API reference bundle loaded from CDN.
+const scalarScriptURL = "https://cdn.jsdelivr.net/npm/@scalar/api-reference"
+
+// scalarOptions returns the Scalar configuration for cfg as a JSON object.
+func scalarOptions(cfg Config) string {
+	options := map[string]interface{}{}
+	if cfg.Scalar.Theme != "" {
+		options["theme"] = cfg.Scalar.Theme
+	}
+	if cfg.Scalar.Layout != "" {
+		options["layout"] = cfg.Scalar.Layout
+	}
+	if cfg.Scalar.DarkMode {
+		options["darkMode"] = true
+	}
+	if cfg.Scalar.HideDownloadButton {
+		options["hideDownloadButton"] = true
+	}
+	if cfg.Scalar.DefaultOpenAllTags {
+		options["defaultOpenAllTags"] = true
+	}
+	if cfg.ReadOnly {
+		options["hiddenClients"] = true
 	}
 
-	// Build auth configuration for Scalar.
-	authJSON := ""
-	if cfg.Auth.Type != AuthNone {
-		switch cfg.Auth.Type {
-		case AuthBearer:
-			authJSON = `authentication: { preferredSecurityScheme: "bearerAuth" },`
-		case AuthAPIKey:
-			authJSON = `authentication: { preferredSecurityScheme: "apiKeyAuth" },`
-		case AuthBasic:
-			authJSON = `authentication: { preferredSecurityScheme: "basicAuth" },`
-		}
+	authentication := map[string]interface{}{}
+	switch cfg.Auth.Type {
+	case AuthBearer:
+		authentication["preferredSecurityScheme"] = "bearerAuth"
+	case AuthAPIKey:
+		authentication["preferredSecurityScheme"] = "apiKeyAuth"
+	case AuthBasic:
+		authentication["preferredSecurityScheme"] = "basicAuth"
+	}
+	switch scheme, token := devToken(cfg); {
+	case token == "":
+	case scheme == "bearerAuth":
+		authentication["securitySchemes"] = map[string]interface{}{scheme: map[string]string{"token": token}}
+	case scheme == "apiKeyAuth":
+		authentication["securitySchemes"] = map[string]interface{}{scheme: map[string]string{"value": token}}
+	}
+	if len(authentication) > 0 {
+		options["authentication"] = authentication
 	}
 
-	hideModels := ""
-	if cfg.ReadOnly {
-		hideModels = `hiddenClients: true,`
+	// json.Marshal escapes <, > and &, so the object is safe in a script.
+	data, _ := json.Marshal(options)
+	return string(data)
+}
+
+// renderScalarHTML generates the full Scalar UI HTML page. switcher is extra
+// HTML, such as the version dropdown, shown next to the UI switch link.
+func renderScalarHTML(title string, src uiSource, switcher string, cfg Config) string {
+	customCSS := ""
+	if cfg.CustomCSS != "" {
+		customCSS = fmt.Sprintf("<style>%s</style>", cfg.CustomCSS)
 	}
 
+	options := scalarOptions(cfg)
+
 	// Custom sections rendered below the API reference.
 	var customSectionsHTML strings.Builder
 	if len(cfg.CustomSections) > 0 {
 		customSectionsHTML.WriteString(`<div style="padding:24px 32px;max-width:900px;margin:0 auto;">`)
 		for _, section := range cfg.CustomSections {
 			customSectionsHTML.WriteString(fmt.Sprintf(
-				`<div style="margin-bottom:2rem;"><h2 style="font-size:1.4rem;font-weight:600;margin-bottom:0.5rem;color:#1a1a2e;">%s</h2><div style="white-space:pre-wrap;line-height:1.7;color:#4a4a6a;">%s</div></div>`,
+				`<div style="margin-bottom:2rem;"><h2 style="font-size:1.4rem;font-weight:600;margin-bottom:0.5rem;color:#1a1a2e;">%s</h2><div class="gd-markdown" style="line-height:1.7;color:#4a4a6a;">%s</div></div>`,
 				template.HTMLEscapeString(section.Title),
-				template.HTMLEscapeString(section.Content),
+				markdownToHTML(section.Content, 2),
 			))
 		}
 		customSectionsHTML.WriteString(`</div>`)
 	}
 
-	switcherLink := fmt.Sprintf(`<a href="?ui=swagger" style="color:#fff;background:#49cc90;padding:6px 14px;border-radius:4px;text-decoration:none;font-size:13px;font-weight:600;">Switch to Swagger</a>`)
+	switcherLink, signIn := "", ""
+	if !src.offline() {
+		switcherLink = `<a href="?ui=swagger" style="color:#fff;background:#49cc90;padding:6px 14px;border-radius:4px;text-decoration:none;font-size:13px;font-weight:600;">Switch to Swagger</a>`
+		signIn = signInHTML(cfg, UIScalar)
+	}
 
 	return fmt.Sprintf(`<!DOCTYPE html>
 <html lang="en">
@@ -53,38 +93,48 @@ func renderScalarHTML(title, specURL string, cfg Config) string {
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
     <title>%s</title>
+    %s
     <style>
         body { margin: 0; }
         #ui-switcher {
             position: fixed; top: 12px; right: 20px; z-index: 10000;
             display: flex; align-items: center; gap: 8px;
         }
+        %s
     </style>
     %s
+    %s
 </head>
 <body>
-    <div id="ui-switcher">%s</div>
+    <div id="ui-switcher">%s %s %s</div>
 
-    <script id="api-reference" data-url="%s"></script>
-    <script src="https://cdn.jsdelivr.net/npm/@scalar/api-reference"></script>
+    <div id="api-reference"></div>
+    %s
     <script>
-        document.getElementById('api-reference').dataset.configuration = JSON.stringify({
-            theme: '%s',
-            %s
-            %s
+        window.gdScalar = Scalar.createApiReference('#api-reference', {
+            ...%s,
+            %s,
+            // Anchor operations by operationId so shared links survive spec changes.
+            generateOperationSlug: (operation) => operation.operationId || (operation.method + operation.path),
         });
     </script>
 
     %s
+    %s
 </body>
 </html>`,
-		template.HTMLEscapeString(title),
+		template.HTMLEscapeString(pageTitle(title, cfg)),
+		headTags(title, cfg),
+		markdownCSS,
 		customCSS,
+		cfg.HeadHTML,
+		switcher,
 		switcherLink,
-		template.HTMLEscapeString(specURL),
-		template.JSEscapeString(cfg.ScalarTheme),
-		authJSON,
-		hideModels,
+		signIn,
+		src.script(scalarScriptURL),
+		options,
+		src.specOption("url", "content"),
 		customSectionsHTML.String(),
+		cfg.BodyFooterHTML,
 	)
 }
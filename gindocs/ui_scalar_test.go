@@ -0,0 +1,32 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScalarOptions(t *testing.T) {
+	cfg := mergeConfig(Config{
+		UI:       UIScalar,
+		ReadOnly: true,
+		Auth:     AuthConfig{Type: AuthBearer},
+		Scalar:   ScalarConfig{Layout: "classic", DarkMode: true, HideDownloadButton: true, DefaultOpenAllTags: true},
+	})
+	want := `{"authentication":{"preferredSecurityScheme":"bearerAuth"},"darkMode":true,"defaultOpenAllTags":true,"hiddenClients":true,"hideDownloadButton":true,"layout":"classic","theme":"kepler"}`
+	if got := scalarOptions(cfg); got != want {
+		t.Errorf("scalarOptions =\n%s\nwant\n%s", got, want)
+	}
+
+	html := renderScalarHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg)
+	if !strings.Contains(html, "..."+want+",") {
+		t.Error("expected the options in the Scalar configuration")
+	}
+
+	// The deprecated ScalarTheme still sets the theme.
+	if got := mergeConfig(Config{ScalarTheme: "moon"}).Scalar.Theme; got != "moon" {
+		t.Errorf("Scalar.Theme = %q, want moon", got)
+	}
+	if got := mergeConfig(Config{ScalarTheme: "moon", Scalar: ScalarConfig{Theme: "mars"}}).Scalar.Theme; got != "mars" {
+		t.Errorf("Scalar.Theme = %q, want mars", got)
+	}
+}
@@ -1,6 +1,7 @@
 package gindocs
 
 import (
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"strings"
@@ -9,13 +10,47 @@ import (
 // swaggerUIVersion is the Swagger UI version loaded from CDN.
 const swaggerUIVersion = "5.18.2"
 
-// renderSwaggerHTML generates the full Swagger UI HTML page.
-func renderSwaggerHTML(title, specURL string, cfg Config) string {
-	readOnlyStr := "false"
-	if cfg.ReadOnly {
-		readOnlyStr = "true"
+// Swagger UI assets loaded from CDN.
+const (
+	swaggerCSSURL    = "https://cdn.jsdelivr.net/npm/swagger-ui-dist@" + swaggerUIVersion + "/swagger-ui.css"
+	swaggerBundleURL = "https://cdn.jsdelivr.net/npm/swagger-ui-dist@" + swaggerUIVersion + "/swagger-ui-bundle.js"
+	swaggerPresetURL = "https://cdn.jsdelivr.net/npm/swagger-ui-dist@" + swaggerUIVersion + "/swagger-ui-standalone-preset.js"
+)
+
+// swaggerOptions returns the SwaggerUIBundle options for cfg as a JSON
+// object.
+func swaggerOptions(cfg Config) string {
+	sw := cfg.Swagger
+	options := map[string]interface{}{
+		"tryItOutEnabled": !cfg.ReadOnly,
+	}
+	if sw.TryItOutEnabled != nil {
+		options["tryItOutEnabled"] = *sw.TryItOutEnabled && !cfg.ReadOnly
+	}
+	if sw.DocExpansion != "" {
+		options["docExpansion"] = sw.DocExpansion
+	}
+	if sw.Filter {
+		options["filter"] = true
 	}
+	if sw.PersistAuthorization {
+		options["persistAuthorization"] = true
+	}
+	if sw.DefaultModelsExpandDepth != nil {
+		options["defaultModelsExpandDepth"] = *sw.DefaultModelsExpandDepth
+	}
+	if sw.OperationsSorter != "" {
+		options["operationsSorter"] = sw.OperationsSorter
+	}
+
+	// json.Marshal escapes <, > and &, so the object is safe in a script.
+	data, _ := json.Marshal(options)
+	return string(data)
+}
 
+// renderSwaggerHTML generates the full Swagger UI HTML page. switcher is extra
+// HTML, such as the version dropdown, shown next to the UI switch link.
+func renderSwaggerHTML(title string, src uiSource, switcher string, cfg Config) string {
 	logoHTML := ""
 	if cfg.Logo != "" {
 		logoHTML = fmt.Sprintf(`<img src="%s" alt="Logo" style="max-height:40px;margin-right:12px;">`, template.HTMLEscapeString(cfg.Logo))
@@ -50,6 +85,12 @@ func renderSwaggerHTML(title, specURL string, cfg Config) string {
         },`, template.JSEscapeString(name))
 		}
 	}
+	if scheme, token := devToken(cfg); token != "" {
+		// json.Marshal escapes <, > and &, so the string is safe in a script.
+		value, _ := json.Marshal(token)
+		authConfigJS += fmt.Sprintf(`
+        onComplete: () => { window.ui.preauthorizeApiKey("%s", %s); },`, scheme, value)
+	}
 
 	// Build the custom sections markdown if any.
 	var customSectionsHTML strings.Builder
@@ -57,15 +98,19 @@ func renderSwaggerHTML(title, specURL string, cfg Config) string {
 		customSectionsHTML.WriteString(`<div id="custom-sections" style="padding:20px 40px;max-width:900px;">`)
 		for _, section := range cfg.CustomSections {
 			customSectionsHTML.WriteString(fmt.Sprintf(
-				`<div style="margin-bottom:2rem;"><h2 style="color:#333;border-bottom:2px solid #49cc90;padding-bottom:8px;">%s</h2><div style="white-space:pre-wrap;line-height:1.6;color:#3b4151;">%s</div></div>`,
+				`<div style="margin-bottom:2rem;"><h2 style="color:#333;border-bottom:2px solid #49cc90;padding-bottom:8px;">%s</h2><div class="gd-markdown" style="line-height:1.6;color:#3b4151;">%s</div></div>`,
 				template.HTMLEscapeString(section.Title),
-				template.HTMLEscapeString(section.Content),
+				markdownToHTML(section.Content, 2),
 			))
 		}
 		customSectionsHTML.WriteString(`</div>`)
 	}
 
-	switcherLink := `<a href="?ui=scalar" style="color:#fff;background:#6c63ff;padding:6px 14px;border-radius:4px;text-decoration:none;font-size:13px;font-weight:600;">Switch to Scalar</a>`
+	switcherLink, signIn := "", ""
+	if !src.offline() {
+		switcherLink = `<a href="?ui=scalar" style="color:#fff;background:#6c63ff;padding:6px 14px;border-radius:4px;text-decoration:none;font-size:13px;font-weight:600;">Switch to Scalar</a>`
+		signIn = signInHTML(cfg, UISwagger)
+	}
 
 	return fmt.Sprintf(`<!DOCTYPE html>
 <html lang="en">
@@ -73,7 +118,8 @@ func renderSwaggerHTML(title, specURL string, cfg Config) string {
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
     <title>%s</title>
-    <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@%s/swagger-ui.css">
+    %s
+    %s
     <style>
         html { box-sizing: border-box; overflow-y: scroll; }
         *, *:before, *:after { box-sizing: inherit; }
@@ -86,20 +132,22 @@ func renderSwaggerHTML(title, specURL string, cfg Config) string {
         }
         .swagger-ui .topbar { background-color: #2d3748; padding: 8px 0; }
         .swagger-ui .topbar .download-url-wrapper { display: none; }
+        %s
     </style>
     %s
+    %s
 </head>
 <body>
-    <div id="ui-switcher">%s %s</div>
+    <div id="ui-switcher">%s %s %s %s</div>
     <div id="swagger-ui"></div>
     %s
 
-    <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@%s/swagger-ui-bundle.js"></script>
-    <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@%s/swagger-ui-standalone-preset.js"></script>
+    %s
+    %s
     <script>
     window.onload = function() {
         window.ui = SwaggerUIBundle({
-            url: "%s",
+            %s,
             dom_id: '#swagger-ui',
             deepLinking: true,
             presets: [
@@ -110,23 +158,30 @@ func renderSwaggerHTML(title, specURL string, cfg Config) string {
                 SwaggerUIBundle.plugins.DownloadUrl
             ],
             layout: "StandaloneLayout",
-            tryItOutEnabled: !%s,
+            ...%s,
             %s
         });
     };
     </script>
+    %s
 </body>
 </html>`,
-		template.HTMLEscapeString(title),
-		swaggerUIVersion,
+		template.HTMLEscapeString(pageTitle(title, cfg)),
+		headTags(title, cfg),
+		src.stylesheet(swaggerCSSURL),
+		markdownCSS,
 		customCSS,
+		cfg.HeadHTML,
 		logoHTML,
+		switcher,
 		switcherLink,
+		signIn,
 		customSectionsHTML.String(),
-		swaggerUIVersion,
-		swaggerUIVersion,
-		template.JSEscapeString(specURL),
-		readOnlyStr,
+		src.script(swaggerBundleURL),
+		src.script(swaggerPresetURL),
+		src.specOption("url", "spec"),
+		swaggerOptions(cfg),
 		authConfigJS,
+		cfg.BodyFooterHTML,
 	)
 }
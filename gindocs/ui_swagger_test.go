@@ -0,0 +1,32 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSwaggerOptions(t *testing.T) {
+	if got := swaggerOptions(mergeConfig(Config{})); got != `{"tryItOutEnabled":true}` {
+		t.Errorf("default options = %s", got)
+	}
+	if got := swaggerOptions(mergeConfig(Config{ReadOnly: true})); got != `{"tryItOutEnabled":false}` {
+		t.Errorf("read-only options = %s", got)
+	}
+
+	off, depth := false, -1
+	cfg := mergeConfig(Config{Swagger: SwaggerConfig{
+		DocExpansion:             "none",
+		Filter:                   true,
+		PersistAuthorization:     true,
+		TryItOutEnabled:          &off,
+		DefaultModelsExpandDepth: &depth,
+		OperationsSorter:         "method",
+	}})
+	want := `{"defaultModelsExpandDepth":-1,"docExpansion":"none","filter":true,"operationsSorter":"method","persistAuthorization":true,"tryItOutEnabled":false}`
+	if got := swaggerOptions(cfg); got != want {
+		t.Errorf("swaggerOptions =\n%s\nwant\n%s", got, want)
+	}
+	if html := renderSwaggerHTML("API", uiSource{specURL: "/docs/openapi.json"}, "", cfg); !strings.Contains(html, "..."+want+",") {
+		t.Error("expected the options in the SwaggerUIBundle configuration")
+	}
+}
@@ -0,0 +1,170 @@
+package gindocs
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrafficSource reports observed request counts keyed by "METHOD /path".
+// Paths may use Gin (":id") or OpenAPI ("{id}") parameter syntax, so counts
+// can come from UsageCounter or from a parsed access log.
+type TrafficSource interface {
+	RequestCounts() map[string]int64
+}
+
+// UsageCounter is an in-memory TrafficSource fed by its Middleware.
+type UsageCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// NewUsageCounter creates an empty UsageCounter.
+func NewUsageCounter() *UsageCounter {
+	return &UsageCounter{counts: make(map[string]int64)}
+}
+
+// Middleware counts requests by method and matched route pattern.
+// Requests that match no route are ignored.
+func (u *UsageCounter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			return
+		}
+		u.mu.Lock()
+		u.counts[c.Request.Method+" "+route]++
+		u.mu.Unlock()
+	}
+}
+
+// RequestCounts returns a snapshot of the counts.
+func (u *UsageCounter) RequestCounts() map[string]int64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	counts := make(map[string]int64, len(u.counts))
+	for key, n := range u.counts {
+		counts[key] = n
+	}
+	return counts
+}
+
+// OperationUsage is the observed call count of one operation.
+type OperationUsage struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+	Calls       int64  `json:"calls"`
+}
+
+// UsageReport correlates documented operations with observed traffic.
+type UsageReport struct {
+	// Documented lists every documented operation with its call count.
+	Documented []OperationUsage `json:"documented"`
+
+	// Undocumented lists routes that received traffic but aren't in the spec.
+	Undocumented []OperationUsage `json:"undocumented"`
+
+	// Dead lists documented operations that received no traffic.
+	Dead []OperationUsage `json:"dead"`
+}
+
+// generateUsageReport matches request counts against the operations in spec.
+func generateUsageReport(spec *OpenAPISpec, counts map[string]int64) UsageReport {
+	observed := make(map[string]int64, len(counts))
+	for key, n := range counts {
+		method, route, ok := strings.Cut(key, " ")
+		if !ok {
+			continue
+		}
+		observed[strings.ToUpper(method)+" "+ginPathToOpenAPI(route)] += n
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	report := UsageReport{
+		Documented:   []OperationUsage{},
+		Undocumented: []OperationUsage{},
+		Dead:         []OperationUsage{},
+	}
+	for _, path := range paths {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op == nil {
+				continue
+			}
+
+			key := method + " " + path
+			usage := OperationUsage{Method: method, Path: path, OperationID: op.OperationID, Calls: observed[key]}
+			delete(observed, key)
+
+			report.Documented = append(report.Documented, usage)
+			if usage.Calls == 0 {
+				report.Dead = append(report.Dead, usage)
+			}
+		}
+	}
+
+	for key, n := range observed {
+		method, path, _ := strings.Cut(key, " ")
+		report.Undocumented = append(report.Undocumented, OperationUsage{Method: method, Path: path, Calls: n})
+	}
+	sort.Slice(report.Undocumented, func(i, j int) bool {
+		a, b := report.Undocumented[i], report.Undocumented[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+
+	return report
+}
+
+// handleUsage reports documented operations against observed traffic.
+func (gd *GinDocs) handleUsage(c *gin.Context) {
+	if gd.config.TrafficSource == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no traffic source configured"})
+		return
+	}
+
+	// Requests to the docs themselves are neither documented nor dead, and
+	// operations hidden from this request must not show up as undocumented.
+	full, spec := gd.getSpec(), gd.requestSpec(c)
+	docRoutes := make(map[string]bool)
+	for _, r := range gd.router.Routes() {
+		if gd.isDocRoute(r) {
+			docRoutes[r.Method+" "+r.Path] = true
+		}
+	}
+	counts := gd.config.TrafficSource.RequestCounts()
+	for key := range counts {
+		method, route, _ := strings.Cut(key, " ")
+		path := ginPathToOpenAPI(route)
+		hidden := operationAt(full, method, path) != nil && operationAt(spec, method, path) == nil
+		if docRoutes[key] || isUnderPrefix(route, gd.config.Prefix) || hidden {
+			delete(counts, key)
+		}
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.JSON(http.StatusOK, generateUsageReport(spec, counts))
+}
+
+// operationAt returns the operation of spec at method and OpenAPI path, or
+// nil.
+func operationAt(spec *OpenAPISpec, method, path string) *OperationObject {
+	if item := spec.Paths[path]; item != nil {
+		return item.GetOperation(strings.ToUpper(method))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package gindocs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGenerateUsageReport(t *testing.T) {
+	spec := &OpenAPISpec{Paths: map[string]*PathItem{
+		"/users":      {Get: &OperationObject{OperationID: "listUsers"}, Post: &OperationObject{OperationID: "createUser"}},
+		"/users/{id}": {Get: &OperationObject{OperationID: "getUser"}},
+	}}
+	counts := map[string]int64{
+		"GET /users":        3,
+		"GET /users/:id":    2,
+		"get /users/{id}":   1,
+		"DELETE /users/:id": 4,
+	}
+
+	report := generateUsageReport(spec, counts)
+
+	if len(report.Documented) != 3 {
+		t.Fatalf("expected 3 documented operations, got %d", len(report.Documented))
+	}
+	for _, u := range report.Documented {
+		if u.OperationID == "getUser" && u.Calls != 3 {
+			t.Errorf("expected getUser to merge Gin and OpenAPI paths into 3 calls, got %d", u.Calls)
+		}
+	}
+	if len(report.Dead) != 1 || report.Dead[0].OperationID != "createUser" {
+		t.Errorf("expected createUser to be dead, got %+v", report.Dead)
+	}
+	if len(report.Undocumented) != 1 || report.Undocumented[0] != (OperationUsage{Method: "DELETE", Path: "/users/{id}", Calls: 4}) {
+		t.Errorf("unexpected undocumented routes: %+v", report.Undocumented)
+	}
+}
+
+func TestUsageEndpointFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	counter := NewUsageCounter()
+	r := gin.New()
+	r.Use(counter.Middleware())
+	r.GET("/users", func(c *gin.Context) {})
+	r.GET("/admin", func(c *gin.Context) {})
+	gd := Mount(r, nil, Config{TrafficSource: counter})
+	gd.Route("GET /admin").Audience("internal")
+
+	for _, url := range []string{"/users", "/admin"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, url, nil))
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/usage?audience=public", nil))
+	if body := w.Body.String(); !strings.Contains(body, `"/users"`) || strings.Contains(body, "/admin") {
+		t.Errorf("expected only /users in the public report, got %s", body)
+	}
+}
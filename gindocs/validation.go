@@ -0,0 +1,75 @@
+package gindocs
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ValidationError is the default body of documented validation failures.
+type ValidationError struct {
+	Error  string       `json:"error" docs:"example:validation failed"`
+	Errors []FieldError `json:"errors"`
+}
+
+// FieldError describes one failed validation rule.
+type FieldError struct {
+	Field   string `json:"field" docs:"description:JSON name of the invalid field,example:email"`
+	Rule    string `json:"rule" docs:"description:Validation rule that failed,example:required"`
+	Message string `json:"message" docs:"example:email is required"`
+}
+
+// addValidationResponse documents the validation error response of an
+// operation whose request body has binding constraints. Existing responses
+// with the same status code are kept.
+func (gd *GinDocs) addValidationResponse(op *OperationObject) {
+	status := gd.config.ValidationErrorStatus
+	if status == 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	code := strconv.Itoa(status)
+	if _, ok := op.Responses[code]; ok {
+		return
+	}
+
+	var model interface{} = ValidationError{}
+	if gd.config.ValidationErrorModel != nil {
+		model = gd.config.ValidationErrorModel
+	}
+	op.Responses[code] = &Response{
+		Description: "Validation failed",
+		Content: map[string]MediaType{
+			"application/json": {Schema: typeToSchema(reflect.TypeOf(model), gd.registry)},
+		},
+	}
+}
+
+// hasBindingConstraints reports whether a struct, or a struct it contains,
+// has binding or validate rules.
+func hasBindingConstraints(t reflect.Type, seen map[reflect.Type]bool) bool {
+	t = derefType(t)
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = derefType(t.Elem())
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) || seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		for _, key := range []string{"binding", "validate"} {
+			if tag := field.Tag.Get(key); tag != "" && tag != "-" {
+				return true
+			}
+		}
+		if hasBindingConstraints(field.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
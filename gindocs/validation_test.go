@@ -0,0 +1,40 @@
+package gindocs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddValidationResponse(t *testing.T) {
+	type Address struct {
+		City string `json:"city" binding:"required"`
+	}
+	type CreateOrder struct {
+		Note    string    `json:"note"`
+		Address []Address `json:"address"`
+	}
+	type Ping struct {
+		Message string `json:"message"`
+	}
+
+	if !hasBindingConstraints(reflect.TypeOf(CreateOrder{}), map[reflect.Type]bool{}) {
+		t.Error("expected nested binding rules to be detected")
+	}
+
+	gd := newGinDocs(nil, nil, mergeConfig())
+	gd.Route("POST /orders").RequestBody(CreateOrder{})
+	gd.Route("POST /ping").RequestBody(Ping{})
+
+	op := gd.buildOperation(RouteMetadata{Method: "POST", Path: "/orders"})
+	resp, ok := op.Responses["422"]
+	if !ok {
+		t.Fatal("expected a 422 response")
+	}
+	if schema := resp.Content["application/json"].Schema; schema == nil || schema.Ref != RefPath("ValidationError") {
+		t.Errorf("expected ValidationError schema, got %+v", schema)
+	}
+
+	if _, ok := gd.buildOperation(RouteMetadata{Method: "POST", Path: "/ping"}).Responses["422"]; ok {
+		t.Error("unconstrained bodies should not document a 422 response")
+	}
+}
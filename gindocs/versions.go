@@ -0,0 +1,135 @@
+package gindocs
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// versionContextKey holds the spec version served by a version route.
+const versionContextKey = "gindocs:version"
+
+// SpecFilter selects the operations included in a spec document. path is
+// in OpenAPI form ("/users/{id}").
+type SpecFilter func(path, method string, op *OperationObject) bool
+
+// PrefixFilter selects operations whose path starts with prefix.
+func PrefixFilter(prefix string) SpecFilter {
+	prefix = ginPathToOpenAPI(prefix)
+	return func(path, method string, op *OperationObject) bool {
+		return strings.HasPrefix(path, prefix)
+	}
+}
+
+// TagFilter selects operations with at least one of the tags.
+func TagFilter(tags ...string) SpecFilter {
+	set := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		set[tag] = true
+	}
+	return func(path, method string, op *OperationObject) bool {
+		return hasAnyTag(op, set)
+	}
+}
+
+// specVersion is a named spec document registered with Version.
+type specVersion struct {
+	name    string
+	filters []SpecFilter
+}
+
+// Version registers a named spec document with the operations every filter
+// accepts, e.g. docs.Version("v1", gindocs.PrefixFilter("/api/v1")). It is
+// served at {prefix}/v1 (UI), {prefix}/v1/openapi.json and
+// {prefix}/v1/openapi.yaml, and the docs pages get a version dropdown.
+// A name whose routes are taken, e.g. by an earlier version, is skipped and
+// reported in Warnings. When the docs are disabled (see Config.Disabled), Version does nothing.
+func (gd *GinDocs) Version(name string, filters ...SpecFilter) *GinDocs {
+	if gd.disabled {
+		return gd
+	}
+
+	// Registering a route twice panics in gin, so a name that is taken, by
+	// another version or a docs route, is reported and skipped.
+	base := gd.config.Prefix + "/" + name
+	for _, r := range gd.router.Routes() {
+		if r.Method == http.MethodGet && (r.Path == base || r.Path == base+"/openapi.json" || r.Path == base+"/openapi.yaml") {
+			gd.versionProblems = append(gd.versionProblems,
+				fmt.Sprintf("Version(%q): GET %s is already registered; the version is ignored", name, r.Path))
+			gd.InvalidateCache()
+			return gd
+		}
+	}
+
+	gd.versions = append(gd.versions, specVersion{name: name, filters: filters})
+	withVersion := func(handler gin.HandlerFunc) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Set(versionContextKey, name)
+			handler(c)
+		}
+	}
+	gd.router.GET(base, withVersion(gd.handleUI))
+	gd.router.GET(base+"/openapi.json", withVersion(gd.handleSpecJSON))
+	gd.router.GET(base+"/openapi.yaml", withVersion(gd.handleSpecYAML))
+
+	return gd
+}
+
+// requestVersion returns the version served by the current request, if any.
+func (gd *GinDocs) requestVersion(c *gin.Context) *specVersion {
+	name := c.GetString(versionContextKey)
+	for i := range gd.versions {
+		if gd.versions[i].name == name {
+			return &gd.versions[i]
+		}
+	}
+	return nil
+}
+
+// versionSpec narrows spec to the operations of a version.
+func versionSpec(spec *OpenAPISpec, v *specVersion) *OpenAPISpec {
+	filtered := filterOperations(spec, func(path, method string, op *OperationObject) bool {
+		for _, filter := range v.filters {
+			if !filter(path, method, op) {
+				return false
+			}
+		}
+		return true
+	})
+	pruneSchemas(filtered)
+	pruneTags(filtered)
+	return filtered
+}
+
+// versionSwitcherHTML renders the version dropdown for the docs pages served
+// at docsURL, or nothing when no versions are registered.
+func (gd *GinDocs) versionSwitcherHTML(docsURL, current string) string {
+	if len(gd.versions) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(`<select id="gd-version" aria-label="API version" style="padding:5px 8px;border-radius:4px;border:1px solid #ccc;font-size:13px;">`)
+	option := func(label, url string, selected bool) {
+		attr := ""
+		if selected {
+			attr = " selected"
+		}
+		fmt.Fprintf(&b, `<option value="%s"%s>%s</option>`, template.HTMLEscapeString(url), attr, template.HTMLEscapeString(label))
+	}
+	all := docsURL
+	if gd.config.LandingPage != nil {
+		all += "/reference"
+	}
+	option("All versions", all, current == "")
+	for _, v := range gd.versions {
+		option(v.name, docsURL+"/"+v.name, v.name == current)
+	}
+	b.WriteString(`</select>`)
+	// A script rather than an inline handler, which a strict CSP blocks.
+	b.WriteString(`<script>document.getElementById("gd-version").addEventListener("change", function () { location.href = this.value + location.search; });</script>`)
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package gindocs
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Warnings returns the non-fatal issues found while building the spec, such as
+// overrides for routes that don't exist, unsupported types documented as
+// strings, and schema name collisions.
+func (gd *GinDocs) Warnings() []string {
+	gd.getSpec()
+
+	gd.specMu.RLock()
+	defer gd.specMu.RUnlock()
+	return append([]string{}, gd.warnings...)
+}
+
+// collectWarnings gathers the issues found while assembling spec from routes.
+func (gd *GinDocs) collectWarnings(routes []RouteMetadata, spec *OpenAPISpec) []string {
+	warnings := append([]string{}, gd.registry.warnings...)
+
+	registered := make(map[string]bool)
+	handlers := make(map[string]bool)
+	for _, r := range gd.router.Routes() {
+		registered[r.Method+" "+r.Path] = true
+		handlers[r.Handler] = true
+	}
+	for key := range gd.manualRoutes {
+		registered[key] = true
+	}
+	documented := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		documented[r.Method+" "+r.Path] = true
+	}
+
+	for _, key := range sortedKeys(gd.routeOverrides) {
+		switch {
+		case !registered[key]:
+			warnings = append(warnings, fmt.Sprintf("Route(%q): no such route", key))
+		case !documented[key] && !gd.routeOverrides[key].hidden:
+			warnings = append(warnings, fmt.Sprintf("Route(%q): route is excluded from the docs", key))
+		}
+		for _, problem := range gd.routeOverrides[key].problems {
+			warnings = append(warnings, fmt.Sprintf("Route(%q): %s", key, problem))
+		}
+	}
+
+	for _, key := range sortedKeys(gd.hidden) {
+		if !registered[key] {
+			warnings = append(warnings, fmt.Sprintf("Hide(%q): no such route", key))
+		}
+	}
+
+	for _, name := range sortedKeys(gd.handlerOverrides) {
+		if !handlers[name] {
+			warnings = append(warnings, fmt.Sprintf("RouteFor(%s): handler is not registered on any route", name))
+		}
+		for _, problem := range gd.handlerOverrides[name].problems {
+			warnings = append(warnings, fmt.Sprintf("RouteFor(%s): %s", name, problem))
+		}
+	}
+
+	for _, group := range gd.groupOverrides {
+		matched := false
+		for _, r := range routes {
+			if group.matches(r.Method, r.Path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			warnings = append(warnings, fmt.Sprintf("Group(%q): matches no documented routes", group.pattern))
+		}
+		for _, problem := range group.problems {
+			warnings = append(warnings, fmt.Sprintf("Group(%q): %s", group.pattern, problem))
+		}
+	}
+
+	warnings = append(warnings, gd.versionProblems...)
+
+	for _, key := range sortedKeys(gd.config.InfoExtensions) {
+		if !strings.HasPrefix(key, "x-") {
+			warnings = append(warnings, fmt.Sprintf("InfoExtensions: %q is skipped, extension names must start with \"x-\"", key))
+		}
+	}
+
+	for _, path := range sortedKeys(spec.Paths) {
+		for _, method := range httpMethods {
+			op := spec.Paths[path].GetOperation(method)
+			if op != nil && op.PayloadEstimate != nil && op.PayloadEstimate.ExceedsThreshold {
+				warnings = append(warnings, fmt.Sprintf("%s %s: typical response is %d bytes, above PayloadWarnBytes (%d)",
+					method, path, op.PayloadEstimate.Bytes, gd.config.PayloadWarnBytes))
+			}
+		}
+	}
+
+	sort.Strings(warnings)
+	return warnings
+}
+
+// handleWarnings serves the build warnings in DevMode.
+func (gd *GinDocs) handleWarnings(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache")
+	c.JSON(http.StatusOK, gin.H{"warnings": gd.Warnings()})
+}
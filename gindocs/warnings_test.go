@@ -0,0 +1,43 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWarnings(t *testing.T) {
+	type Widget struct {
+		Name    string   `json:"name"`
+		Updates chan int `json:"updates"`
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/widgets", func(c *gin.Context) {})
+	r.GET("/internal/health", func(c *gin.Context) {})
+
+	gd := Mount(r, nil, Config{
+		ExcludePrefixes: []string{"/internal"},
+		InfoExtensions:  map[string]interface{}{"x-owner": "platform", "owner": "platform"},
+	})
+	gd.Route("GET /widgets").Response(200, []Widget{}, "Widgets").ResponseJSON(404, `{"error":`, "Not found")
+	gd.Route("POST /widgets")
+	gd.Route("GET /internal/health")
+	gd.Group("/admin/*").Tags("Admin")
+
+	warnings := strings.Join(gd.Warnings(), "\n")
+	for _, want := range []string{
+		`Route("POST /widgets"): no such route`,
+		`Route("GET /internal/health"): route is excluded from the docs`,
+		`Route("GET /widgets"): ResponseJSON(404): invalid JSON sample`,
+		`Group("/admin/*"): matches no documented routes`,
+		"type chan int (chan) is not supported; documented as string",
+		`InfoExtensions: "owner" is skipped`,
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("expected warning %q, got:\n%s", want, warnings)
+		}
+	}
+}
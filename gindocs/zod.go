@@ -0,0 +1,406 @@
+package gindocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// zodGen renders Zod schemas for the component schemas of a spec.
+type zodGen struct {
+	schemas map[string]*SchemaObject
+
+	// order lists schema names with dependencies first.
+	order []string
+	// cyclic holds schemas that reference themselves through $refs; they are
+	// declared with an explicit TypeScript type, since z.infer can't follow
+	// the cycle, and referenced lazily.
+	cyclic map[string]bool
+}
+
+// generateZod renders a Zod schema and inferred type for every component
+// schema, honoring required fields, enums, formats, and numeric and length
+// bounds.
+func generateZod(spec *OpenAPISpec) string {
+	g := &zodGen{cyclic: map[string]bool{}}
+	if spec.Components != nil {
+		g.schemas = spec.Components.Schemas
+	}
+	g.sort()
+
+	var buf strings.Builder
+	buf.WriteString("// Code generated by gindocs. DO NOT EDIT.\n\n")
+	buf.WriteString("import { z } from \"zod\";\n")
+
+	for _, name := range g.order {
+		schema := g.schemas[name]
+		ident := factoryIdent(name)
+		buf.WriteString("\n")
+		writeTSDoc(&buf, schema.Description, "")
+		if g.cyclic[name] {
+			output := zodOutputSchema(schema)
+			if isPlainObject(output) {
+				fmt.Fprintf(&buf, "export interface %s %s\n", ident, tsObject(output, ""))
+			} else {
+				fmt.Fprintf(&buf, "export type %s = %s;\n", ident, tsType(output, ""))
+			}
+			fmt.Fprintf(&buf, "export const %sSchema: z.ZodType<%s> = %s;\n", ident, ident, g.zod(schema, ""))
+			continue
+		}
+		fmt.Fprintf(&buf, "export const %sSchema = %s;\n", ident, g.zod(schema, ""))
+		fmt.Fprintf(&buf, "export type %s = z.infer<typeof %sSchema>;\n", ident, ident)
+	}
+
+	return buf.String()
+}
+
+// sort orders the schemas so each is declared after the schemas it
+// references, and marks schemas that are part of a reference cycle.
+func (g *zodGen) sort() {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int)
+	var stack []string
+
+	var visit func(name string)
+	visit = func(name string) {
+		switch state[name] {
+		case done:
+			return
+		case visiting:
+			for i := len(stack) - 1; i >= 0; i-- {
+				g.cyclic[stack[i]] = true
+				if stack[i] == name {
+					break
+				}
+			}
+			return
+		}
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, ref := range schemaRefs(g.schemas[name]) {
+			if _, ok := g.schemas[ref]; ok {
+				visit(ref)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+		g.order = append(g.order, name)
+	}
+
+	for _, name := range sortedKeys(g.schemas) {
+		visit(name)
+	}
+}
+
+// schemaRefs returns the sorted component names a schema references.
+func schemaRefs(schema *SchemaObject) []string {
+	refs := make(map[string]bool)
+	var walk func(s *SchemaObject)
+	walk = func(s *SchemaObject) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			refs[strings.TrimPrefix(s.Ref, RefPath(""))] = true
+			return
+		}
+		for _, child := range schemaChildren(s) {
+			walk(child)
+		}
+	}
+	walk(schema)
+	return sortedKeys(refs)
+}
+
+// zodOutputSchema returns a copy of schema shaped like the output of its Zod
+// schema, so its TypeScript type matches: read-only properties are optional
+// and binary strings are plain strings.
+func zodOutputSchema(schema *SchemaObject) *SchemaObject {
+	if schema == nil {
+		return nil
+	}
+	out := *schema
+	if out.Format == "binary" {
+		out.Format = ""
+	}
+	if schema.Properties != nil {
+		out.Properties = make(map[string]*SchemaObject, len(schema.Properties))
+		out.Required = nil
+		for _, name := range schema.Required {
+			if prop := schema.Properties[name]; prop == nil || !prop.ReadOnly {
+				out.Required = append(out.Required, name)
+			}
+		}
+		for name, prop := range schema.Properties {
+			out.Properties[name] = zodOutputSchema(prop)
+		}
+	}
+	out.Items = zodOutputSchema(schema.Items)
+	out.AdditionalProperties = zodOutputSchema(schema.AdditionalProperties)
+	for _, list := range []*[]*SchemaObject{&out.AllOf, &out.OneOf, &out.AnyOf} {
+		if *list == nil {
+			continue
+		}
+		copied := make([]*SchemaObject, len(*list))
+		for i, s := range *list {
+			copied[i] = zodOutputSchema(s)
+		}
+		*list = copied
+	}
+	return &out
+}
+
+// zod returns the Zod expression of a schema. indent is the indentation of
+// the line the expression starts on.
+func (g *zodGen) zod(schema *SchemaObject, indent string) string {
+	if schema == nil {
+		return "z.unknown()"
+	}
+	expr := g.zodBase(schema, indent)
+	if schema.Nullable {
+		expr += ".nullable()"
+	}
+	return expr
+}
+
+// zodBase returns the Zod expression of a schema, ignoring nullability.
+func (g *zodGen) zodBase(schema *SchemaObject, indent string) string {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, RefPath(""))
+		ident := factoryIdent(name) + "Schema"
+		if g.cyclic[name] {
+			return "z.lazy(() => " + ident + ")"
+		}
+		return ident
+	}
+
+	// anyOf [T, null] is a nullable T.
+	for _, variants := range [][]*SchemaObject{schema.AnyOf, schema.OneOf} {
+		if len(variants) == 2 {
+			for i, v := range variants {
+				if v != nil && v.Type == "null" {
+					return g.zod(variants[1-i], indent) + ".nullable()"
+				}
+			}
+		}
+	}
+
+	switch {
+	case len(schema.OneOf) > 0:
+		return g.union(schema.OneOf, indent)
+	case len(schema.AnyOf) > 0:
+		return g.union(schema.AnyOf, indent)
+	case len(schema.AllOf) > 0:
+		expr := g.zod(schema.AllOf[0], indent)
+		for _, s := range schema.AllOf[1:] {
+			expr = "z.intersection(" + expr + ", " + g.zod(s, indent) + ")"
+		}
+		return expr
+	case len(schema.Enum) > 0:
+		return zodEnum(schema.Enum)
+	}
+
+	switch schema.Type {
+	case "string":
+		return zodString(schema)
+	case "integer", "number":
+		return zodNumber(schema)
+	case "boolean":
+		return "z.boolean()"
+	case "null":
+		return "z.null()"
+	case "array":
+		expr := "z.array(" + g.zod(schema.Items, indent) + ")"
+		if schema.MinItems != nil {
+			expr += fmt.Sprintf(".min(%d)", *schema.MinItems)
+		}
+		if schema.MaxItems != nil {
+			expr += fmt.Sprintf(".max(%d)", *schema.MaxItems)
+		}
+		return expr
+	case "object":
+		if len(schema.Properties) > 0 {
+			return g.object(schema, indent)
+		}
+		if schema.AdditionalProperties != nil {
+			return "z.record(z.string(), " + g.zod(schema.AdditionalProperties, indent) + ")"
+		}
+		return "z.record(z.string(), z.unknown())"
+	}
+	return "z.unknown()"
+}
+
+// object renders an object schema as z.object. Properties that aren't
+// required, and read-only properties the server fills in, are optional.
+func (g *zodGen) object(schema *SchemaObject, indent string) string {
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("z.object({\n")
+	for _, name := range sortedKeys(schema.Properties) {
+		prop := schema.Properties[name]
+		expr := g.zod(prop, indent+"  ")
+		if !required[name] || (prop != nil && prop.ReadOnly) {
+			expr += ".optional()"
+		}
+		fmt.Fprintf(&b, "%s  %s: %s,\n", indent, tsPropertyName(name), expr)
+	}
+	b.WriteString(indent + "})")
+	return b.String()
+}
+
+// union renders oneOf/anyOf variants as z.union.
+func (g *zodGen) union(variants []*SchemaObject, indent string) string {
+	if len(variants) == 1 {
+		return g.zod(variants[0], indent)
+	}
+	exprs := make([]string, 0, len(variants))
+	for _, v := range variants {
+		exprs = append(exprs, g.zod(v, indent))
+	}
+	return "z.union([" + strings.Join(exprs, ", ") + "])"
+}
+
+// zodEnum renders enum values as z.enum for strings, else as literals.
+func zodEnum(values []interface{}) string {
+	literals := make([]string, 0, len(values))
+	allStrings := true
+	for _, v := range values {
+		if _, ok := v.(string); !ok {
+			allStrings = false
+		}
+		data, _ := json.Marshal(v)
+		literals = append(literals, string(data))
+	}
+	if allStrings {
+		return "z.enum([" + strings.Join(literals, ", ") + "])"
+	}
+	if len(literals) == 1 {
+		return "z.literal(" + literals[0] + ")"
+	}
+	for i, literal := range literals {
+		literals[i] = "z.literal(" + literal + ")"
+	}
+	return "z.union([" + strings.Join(literals, ", ") + "])"
+}
+
+// zodString renders a string schema with its format, length, and pattern
+// constraints.
+func zodString(schema *SchemaObject) string {
+	expr := "z.string()"
+	switch schema.Format {
+	case "email":
+		expr += ".email()"
+	case "uri", "url":
+		expr += ".url()"
+	case "uuid":
+		expr += ".uuid()"
+	case "date-time":
+		expr += ".datetime({ offset: true })"
+	case "date":
+		expr += ".date()"
+	case "ipv4":
+		expr += `.ip({ version: "v4" })`
+	case "ipv6":
+		expr += `.ip({ version: "v6" })`
+	}
+	if schema.MinLength != nil {
+		expr += fmt.Sprintf(".min(%d)", *schema.MinLength)
+	}
+	if schema.MaxLength != nil {
+		expr += fmt.Sprintf(".max(%d)", *schema.MaxLength)
+	}
+	// Patterns JavaScript can't express are left to the server.
+	if source, flags, ok := jsRegExp(schema.Pattern); ok && source != "" {
+		expr += ".regex(new RegExp(" + strconv.Quote(source)
+		if flags != "" {
+			expr += ", " + strconv.Quote(flags)
+		}
+		expr += "))"
+	}
+	return expr
+}
+
+// jsRegExp translates an RE2 pattern to a JavaScript RegExp source and
+// flags: a leading (?i), (?m) or (?s) becomes a flag, (?P<name> a named
+// group, and \A and \z anchors. ok is false for syntax JavaScript lacks or
+// reads differently, such as (?U), scoped flags, \Q…\E, \pL and POSIX
+// classes.
+func jsRegExp(pattern string) (source, flags string, ok bool) {
+	if strings.Contains(pattern, "[:") && strings.Contains(pattern, ":]") {
+		return "", "", false
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			switch pattern[i] {
+			case 'A':
+				b.WriteByte('^')
+			case 'z':
+				b.WriteByte('$')
+			case 'Q', 'E', 'C', 'p', 'P':
+				return "", "", false
+			default:
+				b.WriteByte(c)
+				b.WriteByte(pattern[i])
+			}
+		case c == '(' && strings.HasPrefix(pattern[i:], "(?P<"):
+			b.WriteString("(?<")
+			i += len("(?P<") - 1
+		case c == '(' && strings.HasPrefix(pattern[i:], "(?"):
+			j := i + 2
+			for j < len(pattern) && strings.IndexByte("imsU-", pattern[j]) >= 0 {
+				j++
+			}
+			if j == i+2 {
+				// (?:, lookarounds and named groups read the same.
+				b.WriteByte(c)
+				continue
+			}
+			set := pattern[i+2 : j]
+			if i != 0 || j >= len(pattern) || pattern[j] != ')' || strings.ContainsAny(set, "U-") {
+				return "", "", false
+			}
+			flags = set
+			i = j
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), flags, true
+}
+
+// zodNumber renders a number or integer schema with its bounds.
+func zodNumber(schema *SchemaObject) string {
+	expr := "z.number()"
+	if schema.Type == "integer" {
+		expr += ".int()"
+	}
+	bounds := []struct {
+		method string
+		value  *float64
+	}{
+		{"gte", schema.Minimum},
+		{"lte", schema.Maximum},
+		{"gt", schema.ExclusiveMinimum},
+		{"lt", schema.ExclusiveMaximum},
+		{"multipleOf", schema.MultipleOf},
+	}
+	for _, bound := range bounds {
+		if bound.value != nil {
+			expr += "." + bound.method + "(" + strconv.FormatFloat(*bound.value, 'f', -1, 64) + ")"
+		}
+	}
+	return expr
+}
@@ -0,0 +1,84 @@
+package gindocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateZod(t *testing.T) {
+	minLen, maxLen, min := 2, 100, 18.0
+	spec := &OpenAPISpec{Components: &ComponentsObject{Schemas: map[string]*SchemaObject{
+		"Account": {
+			Type:     "object",
+			Required: []string{"id", "owner", "email"},
+			Properties: map[string]*SchemaObject{
+				"id":    {Type: "integer", ReadOnly: true},
+				"owner": {Ref: RefPath("User")},
+				"email": {Type: "string", Format: "email"},
+				"code":  {Type: "string", Pattern: `(?i)^[a-z]{3}\z`},
+				"slug":  {Type: "string", Pattern: `[[:alpha:]]+`},
+			},
+		},
+		"User": {
+			Type:     "object",
+			Required: []string{"name"},
+			Properties: map[string]*SchemaObject{
+				"name":    {Type: "string", MinLength: &minLen, MaxLength: &maxLen},
+				"age":     {Type: "integer", Minimum: &min},
+				"role":    {Type: "string", Enum: []interface{}{"admin", "member"}},
+				"manager": {Ref: RefPath("User"), Nullable: true},
+			},
+		},
+	}}}
+
+	code := generateZod(spec)
+	for _, want := range []string{
+		`import { z } from "zod";`,
+		"export interface User {\n  age?: number;\n  manager?: User | null;\n  name: string;",
+		"export const UserSchema: z.ZodType<User> = z.object({",
+		"  name: z.string().min(2).max(100),",
+		"  age: z.number().int().gte(18).optional(),",
+		`  role: z.enum(["admin", "member"]).optional(),`,
+		"  manager: z.lazy(() => UserSchema).nullable().optional(),",
+		"export const AccountSchema = z.object({",
+		"  id: z.number().int().optional(),",
+		"  owner: z.lazy(() => UserSchema),",
+		"  email: z.string().email(),",
+		`  code: z.string().regex(new RegExp("^[a-z]{3}$", "i")).optional(),`,
+		"  slug: z.string().optional(),",
+		"export type Account = z.infer<typeof AccountSchema>;",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected %q in:\n%s", want, code)
+		}
+	}
+	if strings.Index(code, "UserSchema:") > strings.Index(code, "AccountSchema =") {
+		t.Errorf("expected User to be declared before Account:\n%s", code)
+	}
+	if strings.Contains(code, "ZodTypeAny") || strings.Contains(code, "z.infer<typeof UserSchema>") {
+		t.Errorf("expected an explicit type for the cyclic User:\n%s", code)
+	}
+}
+
+func TestJSRegExp(t *testing.T) {
+	tests := []struct {
+		pattern, source, flags string
+		ok                     bool
+	}{
+		{`^\d{3}$`, `^\d{3}$`, "", true},
+		{`(?i)^abc`, `^abc`, "i", true},
+		{`\Aab\\z\z`, `^ab\\z$`, "", true},
+		{`(?P<year>\d{4})-(?:\d{2})`, `(?<year>\d{4})-(?:\d{2})`, "", true},
+		{`a(?i)b`, "", "", false},
+		{`(?i:ab)`, "", "", false},
+		{`(?U)a+`, "", "", false},
+		{`\pL+`, "", "", false},
+		{`[[:digit:]]`, "", "", false},
+	}
+	for _, tt := range tests {
+		source, flags, ok := jsRegExp(tt.pattern)
+		if source != tt.source || flags != tt.flags || ok != tt.ok {
+			t.Errorf("jsRegExp(%q) = %q, %q, %v", tt.pattern, source, flags, ok)
+		}
+	}
+}